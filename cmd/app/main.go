@@ -1,7 +1,15 @@
 package main
 
-import "tenders/internal"
+import (
+	"os"
+	"tenders/internal"
+)
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		internal.Migrate(os.Args[2:])
+		return
+	}
+
 	internal.Run()
 }
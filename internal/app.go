@@ -1,44 +1,129 @@
 package internal
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
 	"tenders/internal/application/service"
 	"tenders/internal/infrastructure/config"
 	"tenders/internal/infrastructure/persistence"
+	"tenders/internal/infrastructure/scheduler"
+	"tenders/internal/infrastructure/stream"
+	"tenders/internal/infrastructure/webhook"
 	"tenders/internal/interfaces/handlers"
 	"tenders/internal/interfaces/middleware"
+	"tenders/internal/utils/auth"
+	"tenders/internal/utils/i18n"
 )
 
+// eventStreamPath долгоживущий SSE-поток, на него не распространяется таймаут запроса
+const eventStreamPath = "/api/events/stream"
+
 func Run() {
 	mux := http.NewServeMux()
-	handler := middleware.Logging(mux)
 
-	dbConf := config.NewConfig().PostgresConfig()
-	repositories := persistence.NewRepositories(config.NewPostgresConn(dbConf))
+	conf := config.NewConfig()
+	dbConf := conf.PostgresConfig()
+	authConf := conf.AuthConfig()
+	i18n.SetDefaultLanguage(conf.LocaleConfig().DefaultLanguage)
+	conn := config.NewPostgresConn(dbConf)
+	applyMigrations(conn, dbConf.Seed)
+	repositories := persistence.NewRepositories(conn)
+
+	authService := service.NewAuthService(repositories.EmployeeRepo, auth.NewTokenManager(authConf.SecretKey, authConf.TokenTTL))
+	authHandler := handlers.NewAuthHandler(authService)
+	handler := middleware.Logging(
+		middleware.RequestId(
+			middleware.Localization(
+				middleware.Deadline(dbConf.RequestTimeout, eventStreamPath)(
+					middleware.Authentication(authService, authConf.AllowLegacyUsername)(
+						middleware.OrganizationContext(mux),
+					),
+				),
+			),
+		),
+	)
+
+	permissionService := service.NewPermissionService(repositories.OrganizationRepo, authConf.AdminUsername)
 
-	tenderService := service.NewTenderService(repositories.TenderRepo, repositories.EmployeeRepo, repositories.OrganizationRepo)
+	tenderService := service.NewTenderService(
+		repositories.TenderRepo, repositories.OrganizationRepo, permissionService, repositories.UnitOfWork,
+	)
 	tenderHandler := handlers.NewTenderHandler(tenderService)
 
+	tenderCloser := scheduler.NewTenderCloser(
+		tenderService, conf.SchedulerConfig().TenderCloseInterval, dbConf.RequestTimeout,
+	)
+	go tenderCloser.Run(context.Background())
+
 	bidService := service.NewBidService(
 		repositories.EmployeeRepo, repositories.OrganizationRepo, repositories.BidRepo, repositories.TenderRepo,
+		repositories.BidDecisionRepo, permissionService, repositories.UnitOfWork,
 	)
 	bidHandler := handlers.NewBidHandler(bidService)
 
 	reviewService := service.NewReviewService(
-		repositories.EmployeeRepo, repositories.OrganizationRepo,
-		repositories.BidRepo, repositories.TenderRepo, repositories.ReviewRepo,
+		repositories.EmployeeRepo, repositories.BidRepo, repositories.TenderRepo, repositories.ReviewRepo,
+		permissionService, repositories.UnitOfWork,
 	)
 	feedbackHandler := handlers.NewReviewHandler(reviewService)
 
+	evaluationHandler := handlers.NewEvaluationHandler(service.NewEvaluationService(
+		repositories.TenderRepo, repositories.BidRepo, repositories.EvaluationRepo, permissionService, repositories.UnitOfWork,
+	))
+
+	auditHandler := handlers.NewAuditHandler(service.NewAuditService(
+		repositories.AuditRepo, repositories.OrganizationRepo, permissionService,
+	))
+
+	employeeService := service.NewEmployeeService(
+		repositories.EmployeeRepo, repositories.OrganizationRepo, permissionService, repositories.UnitOfWork,
+		authConf.AdminUsername,
+	)
+	if err := employeeService.EnsureAdmin(context.Background(), authConf.AdminPassword); err != nil {
+		log.Fatalf("Failed to create admin: %v", err)
+	}
+	employeeHandler := handlers.NewEmployeeHandler(employeeService)
+
+	organizationHandler := handlers.NewOrganizationHandler(service.NewOrganizationService(
+		repositories.OrganizationRepo, repositories.EmployeeRepo, permissionService, repositories.UnitOfWork,
+	))
+
+	webhookHandler := handlers.NewWebhookHandler(service.NewWebhookService(
+		repositories.OrganizationRepo, repositories.WebhookRepo, permissionService,
+	))
+
+	webhookConf := conf.WebhookConfig()
+	dispatcher := webhook.NewDispatcher(
+		repositories.DeliveryRepo, webhookConf.DispatchInterval, webhookConf.MaxAttempts, webhookConf.Timeout,
+	)
+	go dispatcher.Run(context.Background())
+
+	notifier := stream.NewNotifier(dbConf.ConnString(), persistence.ChangeEventChannel)
+	go notifier.Run(context.Background())
+	eventStreamHandler := handlers.NewEventStreamHandler(
+		service.NewEventStreamService(
+			repositories.OrganizationRepo, repositories.ChangeEventRepo, notifier, permissionService,
+		),
+		dbConf.RequestTimeout,
+	)
+
 	mux.HandleFunc("GET /api/ping", handlers.Ping)
 
+	// auth
+	mux.HandleFunc("POST /api/auth/token", authHandler.IssueToken)
+
 	// tenders
 	mux.HandleFunc("POST /api/tenders/new", tenderHandler.CreateTender)
 	mux.HandleFunc("GET /api/tenders", tenderHandler.GetAllTenders)
 	mux.HandleFunc("GET /api/tenders/my", tenderHandler.GetAllTendersByUsername)
+	mux.HandleFunc("GET /api/tenders/{tenderId}", tenderHandler.GetTenderById)
+	mux.HandleFunc("GET /api/tenders/{tenderId}/versions", tenderHandler.GetTenderVersions)
+	mux.HandleFunc("GET /api/tenders/{tenderId}/versions/{version}", tenderHandler.GetTenderVersion)
 	mux.HandleFunc("GET /api/tenders/{tenderId}/status", tenderHandler.GetTenderStatusById)
+	mux.HandleFunc("GET /api/tenders/{tenderId}/diff", tenderHandler.GetTenderDiff)
+	mux.HandleFunc("GET /api/tenders/{tenderId}/transitions", tenderHandler.GetTenderTransitions)
 	mux.HandleFunc("PUT /api/tenders/{tenderId}/status", tenderHandler.UpdateTenderStatusById)
 	mux.HandleFunc("PATCH /api/tenders/{tenderId}/edit", tenderHandler.EditTender)
 	mux.HandleFunc("PUT /api/tenders/{tenderId}/rollback/{version}", tenderHandler.RollbackTender)
@@ -47,7 +132,10 @@ func Run() {
 	mux.HandleFunc("POST /api/bids/new", bidHandler.CreateBid)
 	mux.HandleFunc("GET /api/bids/my", bidHandler.GetAllBidsByUsername)
 	mux.HandleFunc("GET /api/bids/{tenderId}/list", bidHandler.GetAllBidsByTender)
+	mux.HandleFunc("GET /api/bids/{tenderId}/price_stats", bidHandler.GetPriceStats)
 	mux.HandleFunc("GET /api/bids/{bidId}/status", bidHandler.GetBidStatusById)
+	mux.HandleFunc("GET /api/bids/{bidId}/diff", bidHandler.GetBidDiff)
+	mux.HandleFunc("GET /api/bids/{bidId}/transitions", bidHandler.GetBidTransitions)
 	mux.HandleFunc("PUT /api/bids/{bidId}/status", bidHandler.UpdateBidStatusById)
 	mux.HandleFunc("PATCH /api/bids/{bidId}/edit", bidHandler.EditBid)
 	mux.HandleFunc("PUT /api/bids/{bidId}/rollback/{version}", bidHandler.RollbackBid)
@@ -57,6 +145,38 @@ func Run() {
 	mux.HandleFunc("GET /api/bids/{tenderId}/reviews", feedbackHandler.GetReviewsList)
 	mux.HandleFunc("PUT /api/bids/{bidId}/feedback", feedbackHandler.SubmitFeedback)
 
+	// evaluation
+	mux.HandleFunc("GET /api/tenders/{tenderId}/criteria", evaluationHandler.GetCriteria)
+	mux.HandleFunc("PUT /api/tenders/{tenderId}/criteria", evaluationHandler.SetCriteria)
+	mux.HandleFunc("PUT /api/bids/{bidId}/scores", evaluationHandler.SubmitScores)
+	mux.HandleFunc("GET /api/tenders/{tenderId}/ranking", evaluationHandler.GetRanking)
+
+	// audit
+	mux.HandleFunc("GET /api/audit", auditHandler.GetAuditEvents)
+
+	// employees
+	mux.HandleFunc("POST /api/employees", employeeHandler.CreateEmployee)
+	mux.HandleFunc("PATCH /api/employees/{employeeId}", employeeHandler.EditEmployee)
+	mux.HandleFunc("PUT /api/employees/{employeeId}/deactivate", employeeHandler.DeactivateEmployee)
+
+	// organizations
+	mux.HandleFunc("POST /api/organizations", organizationHandler.CreateOrganization)
+	mux.HandleFunc("PATCH /api/organizations/{organizationId}", organizationHandler.EditOrganization)
+	mux.HandleFunc("PUT /api/organizations/{organizationId}/deactivate", organizationHandler.DeactivateOrganization)
+	mux.HandleFunc("GET /api/organizations/{organizationId}/responsibles", organizationHandler.GetResponsibles)
+	mux.HandleFunc("POST /api/organizations/{organizationId}/responsibles", organizationHandler.AddResponsible)
+	mux.HandleFunc("DELETE /api/organizations/{organizationId}/responsibles/{employeeId}", organizationHandler.RemoveResponsible)
+
+	// webhooks
+	mux.HandleFunc("POST /api/webhooks", webhookHandler.CreateWebhook)
+	mux.HandleFunc("GET /api/webhooks", webhookHandler.GetWebhooks)
+	mux.HandleFunc("GET /api/webhooks/{webhookId}", webhookHandler.GetWebhookById)
+	mux.HandleFunc("PATCH /api/webhooks/{webhookId}", webhookHandler.EditWebhook)
+	mux.HandleFunc("DELETE /api/webhooks/{webhookId}", webhookHandler.DeleteWebhook)
+
+	// events
+	mux.HandleFunc("GET "+eventStreamPath, eventStreamHandler.StreamEvents)
+
 	fmt.Printf("Starting server on http://127.0.0.1:8080/\n")
 
 	if err := http.ListenAndServe(":8080", handler); err != nil {
@@ -0,0 +1,11 @@
+package interfaces
+
+import (
+	"context"
+	"tenders/internal/domain/entity"
+	"tenders/internal/domain/repository"
+)
+
+type AuditService interface {
+	FindAll(ctx context.Context, filter repository.AuditFilter, limit, offset int) ([]entity.AuditEvent, error)
+}
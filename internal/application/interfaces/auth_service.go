@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+	"tenders/internal/domain/entity"
+	"time"
+)
+
+type AuthService interface {
+	IssueToken(ctx context.Context, username, password string) (string, time.Time, error)
+	Authenticate(ctx context.Context, token string) (*entity.Employee, error)
+	AuthenticateByUsername(ctx context.Context, username string) (*entity.Employee, error)
+}
@@ -1,18 +1,30 @@
 package interfaces
 
 import (
+	"context"
 	"github.com/google/uuid"
 	"tenders/internal/domain/entity"
+	"tenders/internal/domain/repository"
+	"tenders/internal/domain/statemachine"
 	"tenders/internal/interfaces/dto/request"
+	"tenders/internal/utils/diff"
 )
 
 type BidService interface {
-	FindAllByEmployeeUsername(username string, limit, offset int) ([]entity.Bid, error)
-	FindAllByTenderId(tenderId uuid.UUID, username string, limit, offset int) ([]entity.Bid, error)
-	CreateNewBid(request *request.BidRequest) (*entity.Bid, error)
-	GetStatusByBidId(bidId uuid.UUID, username string) (string, error)
-	UpdateStatus(bidId uuid.UUID, status string, username string) (*entity.Bid, error)
-	EditBid(bidId uuid.UUID, username string, updateRequest *request.EditBidRequest) (*entity.Bid, error)
-	RollbackBid(bidId uuid.UUID, version int, username string) (*entity.Bid, error)
-	SubmitDecision(bidId uuid.UUID, username, decision string) (*entity.Bid, error)
+	FindAllByEmployee(ctx context.Context, filter repository.BidFilter, page repository.Page) (*repository.PageResult[entity.Bid], error)
+	FindAllByTenderId(
+		ctx context.Context, tenderId uuid.UUID, filter repository.BidFilter, page repository.Page,
+	) (*repository.PageResult[entity.Bid], error)
+	CreateNewBid(ctx context.Context, request *request.BidRequest) (*entity.Bid, error)
+	FindVisibleByBidId(ctx context.Context, bidId uuid.UUID) (*entity.Bid, error)
+	UpdateStatus(ctx context.Context, bidId uuid.UUID, status string, expectedVersion int) (*entity.Bid, error)
+	EditBid(ctx context.Context, bidId uuid.UUID, updateRequest *request.EditBidRequest, expectedVersion int) (*entity.Bid, error)
+	RollbackBid(ctx context.Context, bidId uuid.UUID, version int, expectedVersion int) (*entity.Bid, error)
+	SummarizeDecisions(ctx context.Context, bids ...entity.Bid) (map[uuid.UUID]entity.BidDecisionSummary, error)
+	// FindPriceStats возвращает минимум, медиану и максимум цен предложений тендера по валютам
+	FindPriceStats(ctx context.Context, tenderId uuid.UUID, filter repository.BidFilter) ([]entity.PriceStats, error)
+	DiffVersions(ctx context.Context, bidId uuid.UUID, from, to int) ([]diff.FieldChange, error)
+	SubmitDecision(ctx context.Context, bidId uuid.UUID, decision string) (*entity.Bid, error)
+	// AvailableTransitions возвращает предложение и переходы статуса, которые сейчас доступны текущему пользователю
+	AvailableTransitions(ctx context.Context, bidId uuid.UUID) (*entity.Bid, []statemachine.Transition, error)
 }
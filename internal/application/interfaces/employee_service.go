@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"tenders/internal/domain/entity"
+	"tenders/internal/interfaces/dto/request"
+)
+
+type EmployeeService interface {
+	Create(ctx context.Context, employeeRequest *request.EmployeeRequest) (*entity.Employee, error)
+	Edit(ctx context.Context, employeeId uuid.UUID, editRequest *request.EditEmployeeRequest) (*entity.Employee, error)
+	Deactivate(ctx context.Context, employeeId uuid.UUID) (*entity.Employee, error)
+	EnsureAdmin(ctx context.Context, password string) error
+}
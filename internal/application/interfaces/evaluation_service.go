@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"tenders/internal/domain/entity"
+)
+
+type EvaluationService interface {
+	// SetCriteria заменяет критерии оценки тендера, пока по ним не выставлено ни одной оценки
+	SetCriteria(ctx context.Context, tenderId uuid.UUID, criteria []entity.TenderCriterion) ([]entity.TenderCriterion, error)
+	FindCriteria(ctx context.Context, tenderId uuid.UUID) ([]entity.TenderCriterion, error)
+	// SubmitScores сохраняет оценки текущего сотрудника по всем критериям тендера
+	SubmitScores(ctx context.Context, bidId uuid.UUID, scores []entity.CriterionScore) (*entity.BidEvaluation, error)
+	// Ranking возвращает предложения тендера по убыванию взвешенной оценки
+	Ranking(ctx context.Context, tenderId uuid.UUID) ([]entity.BidRanking, error)
+}
@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+	"tenders/internal/domain/entity"
+	"tenders/internal/domain/repository"
+)
+
+type EventStreamService interface {
+	VisibilityFilter(ctx context.Context) (repository.ChangeEventFilter, error)
+	LastEventId(ctx context.Context) (int64, error)
+	FindAfter(ctx context.Context, filter repository.ChangeEventFilter, afterId int64, limit int) ([]entity.ChangeEvent, error)
+	Subscribe() (<-chan struct{}, func())
+}
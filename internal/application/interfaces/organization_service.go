@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"tenders/internal/domain/entity"
+	"tenders/internal/interfaces/dto/request"
+)
+
+type OrganizationService interface {
+	Create(ctx context.Context, organizationRequest *request.OrganizationRequest) (*entity.Organization, error)
+	Edit(ctx context.Context, organizationId uuid.UUID, editRequest *request.EditOrganizationRequest) (*entity.Organization, error)
+	Deactivate(ctx context.Context, organizationId uuid.UUID) (*entity.Organization, error)
+	FindResponsibles(ctx context.Context, organizationId uuid.UUID) ([]entity.Responsible, error)
+	AddResponsible(
+		ctx context.Context, organizationId, employeeId uuid.UUID, role entity.ResponsibleRole,
+	) ([]entity.Responsible, error)
+	RemoveResponsible(ctx context.Context, organizationId, employeeId uuid.UUID) error
+}
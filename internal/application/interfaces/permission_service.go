@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"tenders/internal/domain/entity"
+	"tenders/internal/domain/permission"
+)
+
+// PermissionService проверяет разрешения текущего пользователя по его роли в организации
+type PermissionService interface {
+	// Require возвращает текущего пользователя, если его роль в организации дает разрешение,
+	// иначе PermissionDeniedError с названием разрешения
+	Require(ctx context.Context, organizationId uuid.UUID, required permission.Permission) (*entity.Employee, error)
+	// Has проверяет разрешение так же, как Require, но отсутствие разрешения не считает ошибкой
+	Has(ctx context.Context, organizationId uuid.UUID, required permission.Permission) (bool, error)
+	// RequireAnywhere проверяет, что разрешение есть хотя бы в одной действующей организации
+	RequireAnywhere(ctx context.Context, required permission.Permission) (*entity.Employee, error)
+}
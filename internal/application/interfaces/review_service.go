@@ -1,11 +1,15 @@
 package interfaces
 
 import (
+	"context"
 	"github.com/google/uuid"
 	"tenders/internal/domain/entity"
+	"tenders/internal/domain/repository"
 )
 
 type ReviewService interface {
-	SubmitFeedback(bidId uuid.UUID, username string, feedback string) (*entity.Bid, error)
-	FindAllReviewsByBidAuthor(tenderId uuid.UUID, authorUsername string, requesterUsername string, limit, offset int) ([]entity.Review, error)
+	SubmitFeedback(ctx context.Context, bidId uuid.UUID, feedback string) (*entity.Bid, error)
+	FindAllReviewsByBidAuthor(
+		ctx context.Context, tenderId uuid.UUID, authorUsername string, page repository.Page,
+	) (*repository.PageResult[entity.Review], error)
 }
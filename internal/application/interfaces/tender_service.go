@@ -1,20 +1,33 @@
 package interfaces
 
 import (
+	"context"
 	"github.com/google/uuid"
 	"tenders/internal/domain/entity"
+	"tenders/internal/domain/repository"
+	"tenders/internal/domain/statemachine"
 	"tenders/internal/interfaces/dto/request"
+	"tenders/internal/utils/diff"
 )
 
 type TenderService interface {
-	Create(tenderRequest *request.TenderRequest) (*entity.Tender, error)
-	FindAllPublished(serviceTypes []string, limit, offset int) ([]entity.Tender, error)
-	FindAllAvailableByEmployeeUsername(username string, limit, offset int) ([]entity.Tender, error)
-	GetStatusByTenderId(id uuid.UUID, username string) (string, error)
-	UpdateStatus(tenderId uuid.UUID, status, username string) (*entity.Tender, error)
-	FindByTenderId(tenderId uuid.UUID) (*entity.Tender, error)
-	VerifyUserResponsibleForOrg(username string, organizationId uuid.UUID) (uuid.UUID, error)
-	GetTenderVersion(tenderId uuid.UUID, version int) (*entity.Tender, error)
-	EditTender(tenderId uuid.UUID, username string, updateRequest *request.EditTenderRequest) (*entity.Tender, error)
-	RollbackTender(tenderId uuid.UUID, version int, username string) (*entity.Tender, error)
+	Create(ctx context.Context, tenderRequest *request.TenderRequest) (*entity.Tender, error)
+	FindAllPublished(ctx context.Context, filter repository.TenderFilter, page repository.Page) (*repository.PageResult[entity.Tender], error)
+	SearchPublished(ctx context.Context, filter repository.TenderFilter, page repository.Page) (*repository.PageResult[entity.TenderSearchResult], error)
+	FindAllAvailableByEmployee(
+		ctx context.Context, filter repository.TenderFilter, page repository.Page,
+	) (*repository.PageResult[entity.Tender], error)
+	FindVisibleByTenderId(ctx context.Context, id uuid.UUID) (*entity.Tender, error)
+	FindDetailsByTenderId(ctx context.Context, id uuid.UUID) (*entity.Tender, error)
+	FindAllVersions(ctx context.Context, tenderId uuid.UUID, limit, offset int) ([]entity.TenderVersion, error)
+	DiffVersions(ctx context.Context, tenderId uuid.UUID, from, to int) ([]diff.FieldChange, error)
+	FindVersion(ctx context.Context, tenderId uuid.UUID, version int) (*entity.TenderVersion, error)
+	UpdateStatus(ctx context.Context, tenderId uuid.UUID, status string, expectedVersion int) (*entity.Tender, error)
+	FindByTenderId(ctx context.Context, tenderId uuid.UUID) (*entity.Tender, error)
+	EditTender(ctx context.Context, tenderId uuid.UUID, updateRequest *request.EditTenderRequest, expectedVersion int) (*entity.Tender, error)
+	RollbackTender(ctx context.Context, tenderId uuid.UUID, version int, expectedVersion int) (*entity.Tender, error)
+	// CloseExpired закрывает опубликованные тендеры, срок которых истек, и возвращает число закрытых
+	CloseExpired(ctx context.Context, limit int) (int, error)
+	// AvailableTransitions возвращает тендер и переходы статуса, которые сейчас доступны текущему пользователю
+	AvailableTransitions(ctx context.Context, tenderId uuid.UUID) (*entity.Tender, []statemachine.Transition, error)
 }
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"tenders/internal/domain/entity"
+	"tenders/internal/interfaces/dto/request"
+)
+
+type WebhookService interface {
+	Create(ctx context.Context, webhookRequest *request.WebhookRequest) (*entity.WebhookSubscription, error)
+	FindAll(ctx context.Context) ([]entity.WebhookSubscription, error)
+	FindById(ctx context.Context, webhookId uuid.UUID) (*entity.WebhookSubscription, error)
+	Edit(ctx context.Context, webhookId uuid.UUID, editRequest *request.EditWebhookRequest) (*entity.WebhookSubscription, error)
+	Delete(ctx context.Context, webhookId uuid.UUID) error
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"tenders/internal/application/interfaces"
+	"tenders/internal/domain/entity"
+	"tenders/internal/domain/permission"
+	"tenders/internal/domain/repository"
+	"tenders/internal/utils"
+	"tenders/internal/utils/auth"
+	"tenders/internal/utils/common/custom_types"
+	"tenders/internal/utils/requestid"
+	"time"
+)
+
+type AuditService struct {
+	auditRepo         repository.AuditRepository
+	organizationRepo  repository.OrganizationRepository
+	permissionService interfaces.PermissionService
+}
+
+func NewAuditService(
+	auditRepo repository.AuditRepository,
+	organizationRepo repository.OrganizationRepository,
+	permissionService interfaces.PermissionService,
+) interfaces.AuditService {
+	return &AuditService{
+		auditRepo:         auditRepo,
+		organizationRepo:  organizationRepo,
+		permissionService: permissionService,
+	}
+}
+
+// FindAll возвращает журнал изменений организаций, за которые отвечает текущий пользователь с разрешением
+// audit.view, или только выбранной в запросе
+func (s *AuditService) FindAll(
+	ctx context.Context, filter repository.AuditFilter, limit, offset int,
+) ([]entity.AuditEvent, error) {
+	employee, err := auth.CurrentEmployee(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	organizationIds, err := employeeOrganizationIds(ctx, s.organizationRepo, employee.Id)
+	if err != nil {
+		return nil, err
+	}
+	if len(organizationIds) == 0 {
+		return nil, utils.UnauthorizedAccessError
+	}
+
+	filter.OrganizationIds, err = permittedOrganizationIds(
+		ctx, s.permissionService, organizationIds, permission.AuditView,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(filter.OrganizationIds) == 0 {
+		return nil, utils.PermissionDeniedError.WithPermission(string(permission.AuditView))
+	}
+
+	return s.auditRepo.FindAll(ctx, filter, limit, offset)
+}
+
+// recordAudit дописывает в журнал событие от имени actor (nil для изменений самого приложения),
+// вызывается в транзакции изменения, чтобы событие и изменение сохранялись вместе
+func recordAudit(
+	ctx context.Context, auditRepo repository.AuditRepository, actor *entity.Employee, event entity.AuditEvent,
+) error {
+	event.Id = uuid.New()
+	if actor != nil {
+		event.ActorId = uuid.NullUUID{UUID: actor.Id, Valid: true}
+	}
+	event.RequestId = requestid.FromContext(ctx)
+	event.CreatedAt = custom_types.RFC3339Time(time.Now())
+	return auditRepo.Save(ctx, &event)
+}
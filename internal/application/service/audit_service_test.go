@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"reflect"
+	"tenders/internal/domain/entity"
+	"tenders/internal/domain/repository"
+	"tenders/internal/utils"
+	"tenders/internal/utils/auth"
+	"testing"
+)
+
+type fakeAuditRepo struct {
+	repository.AuditRepository
+	filter *repository.AuditFilter
+	saved  []entity.AuditEvent
+}
+
+func (r *fakeAuditRepo) Save(_ context.Context, event *entity.AuditEvent) error {
+	r.saved = append(r.saved, *event)
+	return nil
+}
+
+func (r *fakeAuditRepo) FindAll(
+	_ context.Context, filter repository.AuditFilter, _, _ int,
+) ([]entity.AuditEvent, error) {
+	r.filter = &filter
+	return []entity.AuditEvent{}, nil
+}
+
+func TestAuditServiceFindAllRequiresAuditView(t *testing.T) {
+	organizationRepo := newFakeOrganizationRepo()
+	ownerOrganization, viewerOrganization := uuid.New(), uuid.New()
+	employee := testEmployee(organizationRepo, ownerOrganization, entity.RoleOwner)
+	organizationRepo.assign(viewerOrganization, employee.Id, entity.RoleViewer)
+	viewer := testEmployee(organizationRepo, viewerOrganization, entity.RoleViewer)
+	outsider := &entity.Employee{Id: uuid.New(), Username: "outsider"}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		wantErr  error
+		wantOrgs []uuid.UUID
+	}{
+		{name: "viewer", ctx: withEmployee(viewer), wantErr: utils.PermissionDeniedError},
+		{name: "not responsible", ctx: withEmployee(outsider), wantErr: utils.UnauthorizedAccessError},
+		{name: "only permitted organizations", ctx: withEmployee(employee), wantOrgs: []uuid.UUID{ownerOrganization}},
+		{
+			name:     "selected organization",
+			ctx:      auth.WithOrganization(withEmployee(employee), ownerOrganization),
+			wantOrgs: []uuid.UUID{ownerOrganization},
+		},
+		{
+			name:    "selected organization without audit.view",
+			ctx:     auth.WithOrganization(withEmployee(employee), viewerOrganization),
+			wantErr: utils.PermissionDeniedError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auditRepo := &fakeAuditRepo{}
+			auditService := NewAuditService(
+				auditRepo, organizationRepo, NewPermissionService(organizationRepo, testAdminUsername),
+			)
+
+			_, err := auditService.FindAll(tt.ctx, repository.AuditFilter{}, 10, 0)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("FindAll err = %v, want %v", err, tt.wantErr)
+				}
+				if auditRepo.filter != nil {
+					t.Errorf("audit was read with filter %+v", auditRepo.filter)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FindAll: %v", err)
+			}
+			if !reflect.DeepEqual(auditRepo.filter.OrganizationIds, tt.wantOrgs) {
+				t.Errorf("organizations = %v, want %v", auditRepo.filter.OrganizationIds, tt.wantOrgs)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"golang.org/x/crypto/bcrypt"
+	"tenders/internal/application/interfaces"
+	"tenders/internal/domain/entity"
+	"tenders/internal/domain/repository"
+	"tenders/internal/utils"
+	"tenders/internal/utils/auth"
+	"time"
+)
+
+type AuthService struct {
+	employeeRepo repository.EmployeeRepository
+	tokenManager *auth.TokenManager
+}
+
+func NewAuthService(employeeRepo repository.EmployeeRepository, tokenManager *auth.TokenManager) interfaces.AuthService {
+	return &AuthService{
+		employeeRepo: employeeRepo,
+		tokenManager: tokenManager,
+	}
+}
+
+func (s *AuthService) IssueToken(ctx context.Context, username, password string) (string, time.Time, error) {
+	employee, err := s.employeeRepo.FindByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", time.Time{}, utils.InvalidCredentialsError
+		}
+		return "", time.Time{}, err
+	}
+
+	// Сотрудник без пароля или деактивированный сотрудник не может получить токен
+	if employee.PasswordHash == "" || !employee.IsActive {
+		return "", time.Time{}, utils.InvalidCredentialsError
+	}
+	if err = bcrypt.CompareHashAndPassword([]byte(employee.PasswordHash), []byte(password)); err != nil {
+		return "", time.Time{}, utils.InvalidCredentialsError
+	}
+
+	return s.tokenManager.Issue(employee)
+}
+
+// Authenticate проверяет подпись токена и что сотрудник, на которого он выпущен, все еще существует и активен
+func (s *AuthService) Authenticate(ctx context.Context, token string) (*entity.Employee, error) {
+	claims, err := s.tokenManager.Verify(token)
+	if err != nil {
+		return nil, utils.InvalidTokenError
+	}
+
+	employee, err := s.employeeRepo.FindById(ctx, claims.Subject)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.InvalidTokenError
+		}
+		return nil, err
+	}
+
+	if !employee.IsActive {
+		return nil, utils.InvalidTokenError
+	}
+	return employee, nil
+}
+
+// AuthenticateByUsername используется только в режиме совместимости со старым параметром username
+func (s *AuthService) AuthenticateByUsername(ctx context.Context, username string) (*entity.Employee, error) {
+	employee, err := s.employeeRepo.FindByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.UserNotExistsError
+		}
+		return nil, err
+	}
+
+	if !employee.IsActive {
+		return nil, utils.UserNotExistsError
+	}
+	return employee, nil
+}
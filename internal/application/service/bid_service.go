@@ -1,24 +1,32 @@
 package service
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"github.com/google/uuid"
 	"tenders/internal/application/interfaces"
 	"tenders/internal/domain/entity"
+	"tenders/internal/domain/permission"
 	"tenders/internal/domain/repository"
+	"tenders/internal/domain/statemachine"
 	"tenders/internal/interfaces/dto/request"
 	"tenders/internal/utils"
+	"tenders/internal/utils/auth"
 	"tenders/internal/utils/common/custom_types"
 	"tenders/internal/utils/consts"
+	"tenders/internal/utils/diff"
 	"time"
 )
 
 type BidService struct {
-	employeeRepo     repository.EmployeeRepository
-	organizationRepo repository.OrganizationRepository
-	tenderRepo       repository.TenderRepository
-	bidRepo          repository.BidRepository
+	employeeRepo      repository.EmployeeRepository
+	organizationRepo  repository.OrganizationRepository
+	tenderRepo        repository.TenderRepository
+	bidRepo           repository.BidRepository
+	bidDecisionRepo   repository.BidDecisionRepository
+	permissionService interfaces.PermissionService
+	unitOfWork        repository.UnitOfWork
 }
 
 func NewBidService(
@@ -26,24 +34,35 @@ func NewBidService(
 	organizationRepo repository.OrganizationRepository,
 	bidRepo repository.BidRepository,
 	tenderRepo repository.TenderRepository,
+	bidDecisionRepo repository.BidDecisionRepository,
+	permissionService interfaces.PermissionService,
+	unitOfWork repository.UnitOfWork,
 ) interfaces.BidService {
 	return &BidService{
-		organizationRepo: organizationRepo,
-		bidRepo:          bidRepo,
-		tenderRepo:       tenderRepo,
-		employeeRepo:     employeeRepo,
+		organizationRepo:  organizationRepo,
+		bidRepo:           bidRepo,
+		tenderRepo:        tenderRepo,
+		employeeRepo:      employeeRepo,
+		bidDecisionRepo:   bidDecisionRepo,
+		permissionService: permissionService,
+		unitOfWork:        unitOfWork,
 	}
 }
 
 // Можно зарефакторить и вынести кучу всего в отдельные функции, не успел..
 
-func (s *BidService) CreateNewBid(request *request.BidRequest) (*entity.Bid, error) {
+func (s *BidService) CreateNewBid(ctx context.Context, request *request.BidRequest) (*entity.Bid, error) {
 	bid, err := request.MapToBid()
 	if err != nil {
 		return nil, err
 	}
 
-	tender, err := s.tenderRepo.FindByTenderId(request.TenderId)
+	employee, err := auth.CurrentEmployee(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tender, err := s.tenderRepo.FindByTenderId(ctx, request.TenderId)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, utils.TenderNotExistsError
@@ -51,10 +70,29 @@ func (s *BidService) CreateNewBid(request *request.BidRequest) (*entity.Bid, err
 		return nil, err
 	}
 
+	if err = s.checkTenderPublished(ctx, tender); err != nil {
+		return nil, err
+	}
+
+	if tender.SubmissionExpired(time.Now()) {
+		return nil, utils.SubmissionClosedError
+	}
+
+	if err = checkBidPrice(tender, bid); err != nil {
+		return nil, err
+	}
+
+	// Организация-автор берется из контекста запроса, authorId можно не передавать
+	if request.AuthorType == consts.AuthorTypeOrganization {
+		if bid.AuthorId, err = s.resolveAuthorOrganization(ctx, employee, bid.AuthorId); err != nil {
+			return nil, err
+		}
+	}
+
 	if request.AuthorType == consts.AuthorTypeUser {
-		_, err = s.employeeRepo.FindById(bid.AuthorId)
+		_, err = s.employeeRepo.FindById(ctx, bid.AuthorId)
 	} else {
-		_, err = s.organizationRepo.FindById(bid.AuthorId)
+		_, err = s.organizationRepo.FindById(ctx, bid.AuthorId)
 	}
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -63,171 +101,290 @@ func (s *BidService) CreateNewBid(request *request.BidRequest) (*entity.Bid, err
 		return nil, err
 	}
 
+	// Создать предложение можно только от своего имени или от имени организации с разрешением bid.submit
+	if err = s.verifyBidAuthor(ctx, employee, bid, permission.BidSubmit); err != nil {
+		return nil, err
+	}
+
 	bid.BidId = uuid.New()
 	bid.Version = 1
 	bid.TenderVersion = tender.Version
 	bid.CreatedAt = custom_types.RFC3339Time(time.Now())
 	bid.Status = consts.BidCreated
 
-	return s.bidRepo.Create(bid)
+	var createdBid *entity.Bid
+	err = s.unitOfWork.WithinTx(ctx, func(repos *repository.TxRepositories) error {
+		createdBid, err = repos.Bid.Create(ctx, bid)
+		if err != nil {
+			return err
+		}
+		err = recordAudit(ctx, repos.Audit, employee, entity.AuditEvent{
+			OrganizationId: tender.OrganizationID,
+			EntityType:     consts.AuditEntityBid,
+			EntityId:       createdBid.BidId,
+			Action:         consts.AuditActionCreate,
+			VersionAfter:   createdBid.Version,
+		})
+		if err != nil {
+			return err
+		}
+		return publishBidChange(ctx, repos.ChangeEvent, tender.OrganizationID, createdBid, consts.AuditActionCreate, "")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return createdBid, nil
 }
 
-func (s *BidService) FindAllByEmployeeUsername(username string, limit, offset int) ([]entity.Bid, error) {
-	employeeId, err := s.employeeRepo.FindEmployeeIdByUsername(username)
+func (s *BidService) FindAllByEmployee(
+	ctx context.Context, filter repository.BidFilter, page repository.Page,
+) (*repository.PageResult[entity.Bid], error) {
+	employee, err := auth.CurrentEmployee(ctx)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, utils.UserNotExistsError
-		}
 		return nil, err
 	}
 
-	organization, err := s.organizationRepo.FindByEmployeeId(employeeId)
+	orgIds, err := employeeOrganizationIds(ctx, s.organizationRepo, employee.Id)
 	if err != nil {
-		if !errors.Is(err, sql.ErrNoRows) {
-			return nil, err
-		}
+		return nil, err
 	}
 
-	var orgId uuid.UUID
-	if organization != nil {
-		orgId = organization.Id
-	} else {
-		orgId = uuid.Nil
+	return s.bidRepo.FindAllByEmployeeIdAndOrgIds(ctx, employee.Id, orgIds, filter, page)
+}
+
+func (s *BidService) FindAllByTenderId(
+	ctx context.Context, tenderId uuid.UUID, filter repository.BidFilter, page repository.Page,
+) (*repository.PageResult[entity.Bid], error) {
+	if err := s.requireTenderBidView(ctx, tenderId); err != nil {
+		return nil, err
 	}
 
-	return s.bidRepo.FindAllByEmployeeIdAndOrgId(employeeId, orgId, limit, offset)
+	return s.bidRepo.FindAllByTenderId(ctx, tenderId, filter, page)
 }
 
-func (s *BidService) specifyEmployeeVerificationError(username string, err error) error {
-	if errors.Is(err, sql.ErrNoRows) {
-		_, userNotFoundErr := s.employeeRepo.FindEmployeeIdByUsername(username)
-		if userNotFoundErr != nil {
-			return utils.UserNotExistsError
+func (s *BidService) FindPriceStats(
+	ctx context.Context, tenderId uuid.UUID, filter repository.BidFilter,
+) ([]entity.PriceStats, error) {
+	if err := s.requireTenderBidView(ctx, tenderId); err != nil {
+		return nil, err
+	}
+
+	return s.bidRepo.FindPriceStatsByTenderId(ctx, tenderId, filter)
+}
+
+// requireTenderBidView проверяет, что текущему пользователю доступны предложения тендера
+func (s *BidService) requireTenderBidView(ctx context.Context, tenderId uuid.UUID) error {
+	if _, err := auth.CurrentEmployee(ctx); err != nil {
+		return err
+	}
+
+	tender, err := s.tenderRepo.FindByTenderId(ctx, tenderId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return utils.TenderNotExistsError
 		}
-		return utils.UnauthorizedAccessError
+		return err
 	}
+
+	_, err = s.permissionService.Require(ctx, tender.OrganizationID, permission.BidView)
 	return err
 }
 
-func (s *BidService) FindAllByTenderId(tenderId uuid.UUID, username string, limit, offset int) ([]entity.Bid, error) {
-	tender, err := s.tenderRepo.FindByTenderId(tenderId)
+// FindVisibleByBidId возвращает предложение, если текущий пользователь его автор или у него есть разрешение bid.view
+// в организации-авторе
+func (s *BidService) FindVisibleByBidId(ctx context.Context, bidId uuid.UUID) (*entity.Bid, error) {
+	bid, err := s.bidRepo.FindByBidId(ctx, bidId)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, utils.TenderNotExistsError
+			return nil, utils.BidNotExistsError
 		}
 		return nil, err
 	}
 
-	_, err = s.employeeRepo.FindEmployeeIdByUsernameIfResponsibleForOrg(username, tender.OrganizationID)
+	employee, err := auth.CurrentEmployee(ctx)
 	if err != nil {
-		return nil, s.specifyEmployeeVerificationError(username, err)
+		return nil, err
 	}
 
-	bids, err := s.bidRepo.FindAllByTenderId(tenderId, limit, offset)
-	if err != nil {
+	if err = s.verifyBidAuthor(ctx, employee, bid, permission.BidView); err != nil {
 		return nil, err
 	}
-	return bids, nil
+
+	return bid, nil
 }
 
-func (s *BidService) GetStatusByBidId(bidId uuid.UUID, username string) (string, error) {
-	bid, err := s.bidRepo.FindByBidId(bidId)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return "", utils.BidNotExistsError
-		}
-		return "", err
+// SummarizeDecisions возвращает сводку решений по предложениям, права на просмотр которых уже проверены
+func (s *BidService) SummarizeDecisions(
+	ctx context.Context, bids ...entity.Bid,
+) (map[uuid.UUID]entity.BidDecisionSummary, error) {
+	bidIds := make([]uuid.UUID, 0, len(bids))
+	for _, bid := range bids {
+		bidIds = append(bidIds, bid.BidId)
 	}
+	return s.bidDecisionRepo.SummarizeByBidIds(ctx, bidIds)
+}
 
-	employeeId, err := s.employeeRepo.FindEmployeeIdByUsername(username)
+// DiffVersions возвращает поля предложения, изменившиеся между версиями from и to
+func (s *BidService) DiffVersions(ctx context.Context, bidId uuid.UUID, from, to int) ([]diff.FieldChange, error) {
+	bid, err := s.FindVisibleByBidId(ctx, bidId)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return "", utils.UserNotExistsError
-		}
-		return "", err
+		return nil, err
 	}
 
-	// Если создал юзер
-	if bid.AuthorType == consts.AuthorTypeUser {
-		if bid.AuthorId != employeeId {
-			return "", utils.UnauthorizedAccessError
-		}
-		return bid.Status, nil
-	} else {
-		// Если создала организация
-		org, err := s.organizationRepo.FindByEmployeeId(employeeId)
-		if err != nil {
-			if errors.Is(err, sql.ErrNoRows) {
-				return "", utils.UnauthorizedAccessError
-			}
-			return "", err
-		}
-		if bid.AuthorId != org.Id {
-			return "", utils.UnauthorizedAccessError
-		}
-
-		return bid.Status, nil
+	fromBid, err := s.findVersion(ctx, bid, from)
+	if err != nil {
+		return nil, err
 	}
-}
 
-func (s *BidService) UpdateStatus(bidId uuid.UUID, status string, username string) (*entity.Bid, error) {
-	// Открываем транзакцию
-	tx, err := s.bidRepo.BeginTransaction()
+	toBid, err := s.findVersion(ctx, bid, to)
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		if p := recover(); p != nil {
-			tx.Rollback()
-			panic(p)
-		} else if err != nil {
-			tx.Rollback()
-		} else {
-			err = tx.Commit()
-		}
-	}()
 
-	bid, err := s.bidRepo.FindByBidId(bidId)
+	return diff.Fields(*fromBid, *toBid), nil
+}
+
+// findVersion возвращает указанную версию предложения: текущую из bid, предыдущие из bid_history
+func (s *BidService) findVersion(ctx context.Context, current *entity.Bid, version int) (*entity.Bid, error) {
+	if version == current.Version {
+		return current, nil
+	}
+
+	bid, err := s.bidRepo.FindVersionInHistory(ctx, current.BidId, version)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, utils.BidNotExistsError
+			return nil, utils.VersionNotExistsError
 		}
 		return nil, err
 	}
+	return bid, nil
+}
+
+// checkBidPrice проверяет, что цена предложения в валюте тендера и не превышает его бюджет
+func checkBidPrice(tender *entity.Tender, bid *entity.Bid) error {
+	if bid.Amount == nil {
+		return nil
+	}
+	if !tender.AcceptsCurrency(bid.Currency) {
+		return utils.NewValidationError([]string{"currency"})
+	}
+	if tender.ExceedsBudget(*bid.Amount) {
+		return utils.BudgetExceededError
+	}
+	return nil
+}
 
-	employeeId, err := s.employeeRepo.FindEmployeeIdByUsername(username)
+// checkTenderPublished проверяет, что тендер принимает предложения. Неопубликованный тендер виден только
+// сотрудникам с разрешением tender.view, остальным он не существует
+func (s *BidService) checkTenderPublished(ctx context.Context, tender *entity.Tender) error {
+	if tender.Status == consts.TenderPublished {
+		return nil
+	}
+
+	visible, err := s.permissionService.Has(ctx, tender.OrganizationID, permission.TenderView)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, utils.UserNotExistsError
-		}
-		return nil, err
+		return err
 	}
+	if !visible {
+		return utils.TenderNotExistsError
+	}
+	return utils.TenderNotPublishedError
+}
 
+// resolveAuthorOrganization возвращает организацию, от имени которой сотрудник подает предложение.
+// Переданный authorId должен совпадать с ней
+func (s *BidService) resolveAuthorOrganization(
+	ctx context.Context, employee *entity.Employee, authorId uuid.UUID,
+) (uuid.UUID, error) {
+	organizationId, err := currentOrganizationId(ctx, s.organizationRepo, employee.Id)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if authorId != uuid.Nil && authorId != organizationId {
+		return uuid.Nil, utils.OrganizationMismatchError
+	}
+	return organizationId, nil
+}
+
+// verifyBidAuthor проверяет, что сотрудник является автором предложения или у него есть разрешение required
+// в организации-авторе
+func (s *BidService) verifyBidAuthor(
+	ctx context.Context, employee *entity.Employee, bid *entity.Bid, required permission.Permission,
+) error {
 	if bid.AuthorType == consts.AuthorTypeUser {
-		if bid.AuthorId != employeeId {
-			return nil, utils.UnauthorizedAccessError
+		if bid.AuthorId != employee.Id {
+			return utils.UnauthorizedAccessError
 		}
-	} else {
-		org, err := s.organizationRepo.FindByEmployeeId(employeeId)
+		return nil
+	}
+
+	_, err := s.permissionService.Require(ctx, bid.AuthorId, required)
+	return err
+}
+
+func (s *BidService) UpdateStatus(ctx context.Context, bidId uuid.UUID, status string, expectedVersion int) (*entity.Bid, error) {
+	employee, err := auth.CurrentEmployee(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var bid *entity.Bid
+	err = s.unitOfWork.WithinTx(ctx, func(repos *repository.TxRepositories) error {
+		bid, err = repos.Bid.FindByBidId(ctx, bidId)
 		if err != nil {
 			if errors.Is(err, sql.ErrNoRows) {
-				return nil, utils.UnauthorizedAccessError
+				return utils.BidNotExistsError
 			}
-			return nil, err
+			return err
 		}
-		if bid.AuthorId != org.Id {
-			return nil, utils.UnauthorizedAccessError
+
+		if err = s.verifyBidAuthor(ctx, employee, bid, permission.BidSubmit); err != nil {
+			return err
 		}
-	}
 
-	if err = s.bidRepo.SaveHistoricalVersionTx(tx, bid); err != nil {
-		return nil, err
-	}
+		if err = checkExpectedVersion(expectedVersion, bid.Version); err != nil {
+			return err
+		}
+
+		// Повторная установка текущего статуса ничего не меняет и новую версию не создает
+		if bid.Status == status {
+			return nil
+		}
 
-	bid.Status = status
-	bid.Version += 1
+		if !statemachine.Bid.Can(bid.Status, status, statemachine.ActorBidAuthor) {
+			return utils.StatusTransitionError.WithFields("status")
+		}
 
-	err = s.bidRepo.UpdateBidTx(tx, bid)
+		submitted := bid.Status != consts.BidPublished && status == consts.BidPublished
+		tender, err := repos.Tender.FindByTenderId(ctx, bid.TenderId)
+		if err != nil {
+			return err
+		}
+		if submitted && tender.SubmissionExpired(time.Now()) {
+			return utils.SubmissionClosedError
+		}
+
+		if err = repos.Bid.SaveHistoricalVersion(ctx, bid); err != nil {
+			return err
+		}
+
+		bid.Status = status
+		bid.Version += 1
+
+		if err = repos.Bid.Update(ctx, bid, bid.Version-1); err != nil {
+			return err
+		}
+		if err = s.recordBidChange(ctx, repos, employee, bid, consts.AuditActionStatusChange); err != nil {
+			return err
+		}
+
+		if !submitted {
+			return nil
+		}
+		return enqueueOutbox(ctx, repos.Outbox, tender.OrganizationID, consts.EventBidSubmitted, newBidEventPayload(bid, ""))
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -235,173 +392,359 @@ func (s *BidService) UpdateStatus(bidId uuid.UUID, status string, username strin
 	return bid, nil
 }
 
-func (s *BidService) EditBid(bidId uuid.UUID, username string, updateRequest *request.EditBidRequest) (*entity.Bid, error) {
-	// Открываем транзакцию
-	tx, err := s.bidRepo.BeginTransaction()
+func (s *BidService) EditBid(
+	ctx context.Context, bidId uuid.UUID, updateRequest *request.EditBidRequest, expectedVersion int,
+) (*entity.Bid, error) {
+	employee, err := auth.CurrentEmployee(ctx)
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		if p := recover(); p != nil {
-			tx.Rollback()
-			panic(p)
-		} else if err != nil {
-			tx.Rollback()
-		} else {
-			err = tx.Commit()
+
+	var editedBid entity.Bid
+	err = s.unitOfWork.WithinTx(ctx, func(repos *repository.TxRepositories) error {
+		bid, err := repos.Bid.FindByBidId(ctx, bidId)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return utils.BidNotExistsError
+			}
+			return err
 		}
-	}()
 
-	bid, err := s.bidRepo.FindByBidIdTx(tx, bidId)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, utils.BidNotExistsError
+		if err = s.verifyBidAuthor(ctx, employee, bid, permission.BidSubmit); err != nil {
+			return err
 		}
-		return nil, err
-	}
 
-	employeeId, err := s.employeeRepo.FindEmployeeIdByUsername(username)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, utils.UserNotExistsError
+		if err = checkExpectedVersion(expectedVersion, bid.Version); err != nil {
+			return err
 		}
-		return nil, err
-	}
 
-	if bid.AuthorType == consts.AuthorTypeUser {
-		if bid.AuthorId != employeeId {
-			return nil, utils.UnauthorizedAccessError
+		// Отмененное или рассмотренное предложение больше не меняется
+		if statemachine.Bid.Final(bid.Status) {
+			return utils.StatusTransitionError
 		}
-	} else {
-		org, err := s.organizationRepo.FindByEmployeeId(employeeId)
+
+		tender, err := repos.Tender.FindByTenderId(ctx, bid.TenderId)
 		if err != nil {
-			if errors.Is(err, sql.ErrNoRows) {
-				return nil, utils.UnauthorizedAccessError
-			}
-			return nil, err
+			return err
 		}
-		if bid.AuthorId != org.Id {
-			return nil, utils.UnauthorizedAccessError
+		if tender.SubmissionExpired(time.Now()) {
+			return utils.SubmissionClosedError
 		}
-	}
-	editedBid, err := updateRequest.MapToBid(*bid)
-	if err != nil {
-		return nil, err
-	}
 
-	if err = s.bidRepo.SaveHistoricalVersionTx(tx, bid); err != nil {
-		return nil, err
-	}
+		editedBid, err = updateRequest.MapToBid(*bid)
+		if err != nil {
+			return err
+		}
 
-	editedBid.Version += 1
-	if err = s.bidRepo.UpdateBidTx(tx, &editedBid); err != nil {
+		// Цену, которая не меняется, не перепроверяем: бюджет тендера мог измениться после подачи
+		if updateRequest.ChangesPrice() {
+			if err = checkBidPrice(tender, &editedBid); err != nil {
+				return err
+			}
+		}
+
+		if err = repos.Bid.SaveHistoricalVersion(ctx, bid); err != nil {
+			return err
+		}
+
+		editedBid.Version += 1
+		if err = repos.Bid.Update(ctx, &editedBid, bid.Version); err != nil {
+			return err
+		}
+		return s.recordBidChange(ctx, repos, employee, &editedBid, consts.AuditActionEdit)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return &editedBid, nil
 }
 
-func (s *BidService) RollbackBid(bidId uuid.UUID, version int, username string) (*entity.Bid, error) {
-	// Открываем транзакцию
-	tx, err := s.bidRepo.BeginTransaction()
+func (s *BidService) RollbackBid(ctx context.Context, bidId uuid.UUID, version int, expectedVersion int) (*entity.Bid, error) {
+	employee, err := auth.CurrentEmployee(ctx)
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		if p := recover(); p != nil {
-			tx.Rollback()
-			panic(p)
-		} else if err != nil {
-			tx.Rollback()
-		} else {
-			err = tx.Commit()
+
+	var historicalBid *entity.Bid
+	err = s.unitOfWork.WithinTx(ctx, func(repos *repository.TxRepositories) error {
+		currentBid, err := repos.Bid.FindByBidId(ctx, bidId)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return utils.BidNotExistsError
+			}
+			return err
 		}
-	}()
 
-	currentBid, err := s.bidRepo.FindByBidIdTx(tx, bidId)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, utils.BidNotExistsError
+		if err = s.verifyBidAuthor(ctx, employee, currentBid, permission.BidSubmit); err != nil {
+			return err
+		}
+
+		if err = checkExpectedVersion(expectedVersion, currentBid.Version); err != nil {
+			return err
+		}
+
+		historicalBid, err = repos.Bid.FindVersionInHistory(ctx, bidId, version)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return utils.VersionNotExistsError
+			}
+			return err
 		}
+
+		// Откат не должен обходить жизненный цикл, например возвращать отмененное предложение
+		if !statemachine.Bid.CanRestore(currentBid.Status, historicalBid.Status, statemachine.ActorBidAuthor) {
+			return utils.StatusTransitionError
+		}
+
+		if err = repos.Bid.SaveHistoricalVersion(ctx, currentBid); err != nil {
+			return err
+		}
+
+		historicalBid.Version = currentBid.Version + 1
+
+		if err = repos.Bid.Update(ctx, historicalBid, currentBid.Version); err != nil {
+			return err
+		}
+		return s.recordBidChange(ctx, repos, employee, historicalBid, consts.AuditActionRollback)
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	employeeId, err := s.employeeRepo.FindEmployeeIdByUsername(username)
+	return historicalBid, nil
+}
+
+func (s *BidService) SubmitDecision(ctx context.Context, bidId uuid.UUID, decision string) (*entity.Bid, error) {
+	employee, err := auth.CurrentEmployee(ctx)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, utils.UserNotExistsError
-		}
 		return nil, err
 	}
 
-	if currentBid.AuthorType == consts.AuthorTypeUser {
-		if currentBid.AuthorId != employeeId {
-			return nil, utils.UnauthorizedAccessError
+	var bid *entity.Bid
+	err = s.unitOfWork.WithinTx(ctx, func(repos *repository.TxRepositories) error {
+		// Решения по одному предложению выполняются по очереди, иначе параллельные одобрения не видят
+		// голосов друг друга и кворум не набирается
+		bid, err = repos.Bid.LockByBidId(ctx, bidId)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return utils.BidNotExistsError
+			}
+			return err
 		}
-	} else {
-		org, err := s.organizationRepo.FindByEmployeeId(employeeId)
+
+		tender, err := repos.Tender.FindByTenderId(ctx, bid.TenderId)
 		if err != nil {
 			if errors.Is(err, sql.ErrNoRows) {
-				return nil, utils.UnauthorizedAccessError
+				return utils.TenderNotExistsError
 			}
-			return nil, err
+			return err
 		}
-		if currentBid.AuthorId != org.Id {
-			return nil, utils.UnauthorizedAccessError
+
+		if _, err = s.permissionService.Require(ctx, tender.OrganizationID, permission.BidDecide); err != nil {
+			return err
 		}
-	}
 
-	historicalBid, err := s.bidRepo.FindVersionInHistoryTx(tx, bidId, version)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, utils.VersionNotExistsError
+		if bid.Status == consts.BidApproved || bid.Status == consts.BidRejected {
+			return utils.BidAlreadyDecidedError
 		}
+
+		if !statemachine.Bid.Can(bid.Status, decision, statemachine.ActorDecision) {
+			return utils.StatusTransitionError
+		}
+
+		if tender.DecisionExpired(time.Now()) {
+			return utils.DecisionClosedError
+		}
+
+		bidDecision := &entity.BidDecision{
+			Id:         uuid.New(),
+			BidId:      bid.BidId,
+			EmployeeId: employee.Id,
+			Decision:   decision,
+			CreatedAt:  custom_types.RFC3339Time(time.Now()),
+		}
+		if err = repos.BidDecision.Save(ctx, bidDecision); err != nil {
+			return err
+		}
+
+		newStatus, err := s.resolveDecisionStatus(ctx, repos, bid, tender.OrganizationID, decision)
+		if err != nil {
+			return err
+		}
+
+		versionBefore := bid.Version
+		if newStatus != "" {
+			if err = repos.Bid.SaveHistoricalVersion(ctx, bid); err != nil {
+				return err
+			}
+
+			bid.Status = newStatus
+			bid.Version += 1
+			if err = repos.Bid.Update(ctx, bid, bid.Version-1); err != nil {
+				return err
+			}
+		}
+
+		err = recordAudit(ctx, repos.Audit, employee, entity.AuditEvent{
+			OrganizationId: tender.OrganizationID,
+			EntityType:     consts.AuditEntityBid,
+			EntityId:       bid.BidId,
+			Action:         consts.AuditActionDecision,
+			VersionBefore:  versionBefore,
+			VersionAfter:   bid.Version,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = publishBidChange(ctx, repos.ChangeEvent, tender.OrganizationID, bid, consts.AuditActionDecision, decision)
+		if err != nil {
+			return err
+		}
+
+		err = enqueueOutbox(ctx, repos.Outbox, tender.OrganizationID, consts.EventBidDecision, newBidEventPayload(bid, decision))
+		if err != nil {
+			return err
+		}
+
+		// Тендер закрывается одобрением, только если он опубликован: закрытый повторно не закрываем
+		if newStatus == consts.BidApproved && tender.Status != consts.TenderClosed &&
+			statemachine.Tender.Can(tender.Status, consts.TenderClosed, statemachine.ActorSystem) {
+			tender.Status = consts.TenderClosed
+			tender.Version = tender.Version + 1
+			setVersionAuthor(tender, employee)
+			if _, err = repos.Tender.Create(ctx, tender); err != nil {
+				return err
+			}
+			err = recordAudit(ctx, repos.Audit, employee, entity.AuditEvent{
+				OrganizationId: tender.OrganizationID,
+				EntityType:     consts.AuditEntityTender,
+				EntityId:       tender.TenderId,
+				Action:         consts.AuditActionStatusChange,
+				VersionBefore:  tender.Version - 1,
+				VersionAfter:   tender.Version,
+			})
+			if err != nil {
+				return err
+			}
+			if err = publishTenderChange(ctx, repos.ChangeEvent, tender, consts.AuditActionStatusChange); err != nil {
+				return err
+			}
+			return enqueueTenderStatusEvent(ctx, repos.Outbox, tender)
+		}
+		return nil
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	if err = s.bidRepo.SaveHistoricalVersionTx(tx, currentBid); err != nil {
-		return nil, err
+	return bid, nil
+}
+
+// recordBidChange записывает в журнал создание новой версии предложения и публикует его в ленту изменений,
+// событие относится к организации тендера
+func (s *BidService) recordBidChange(
+	ctx context.Context, repos *repository.TxRepositories, actor *entity.Employee, bid *entity.Bid, action string,
+) error {
+	tender, err := repos.Tender.FindByTenderId(ctx, bid.TenderId)
+	if err != nil {
+		return err
 	}
 
-	historicalBid.Version = currentBid.Version + 1
+	err = recordAudit(ctx, repos.Audit, actor, entity.AuditEvent{
+		OrganizationId: tender.OrganizationID,
+		EntityType:     consts.AuditEntityBid,
+		EntityId:       bid.BidId,
+		Action:         action,
+		VersionBefore:  bid.Version - 1,
+		VersionAfter:   bid.Version,
+	})
+	if err != nil {
+		return err
+	}
+	return publishBidChange(ctx, repos.ChangeEvent, tender.OrganizationID, bid, action, "")
+}
 
-	if err = s.bidRepo.UpdateBidTx(tx, historicalBid); err != nil {
-		return nil, err
+// resolveDecisionStatus возвращает итоговый статус предложения или пустую строку, если кворум еще не набран.
+// Одного отказа достаточно для отклонения, для одобрения нужно min(BidApprovalQuorum, число ответственных
+// с разрешением bid.decide) голосов
+func (s *BidService) resolveDecisionStatus(
+	ctx context.Context, repos *repository.TxRepositories, bid *entity.Bid, organizationId uuid.UUID, decision string,
+) (string, error) {
+	if decision == consts.BidRejected {
+		return consts.BidRejected, nil
 	}
 
-	return historicalBid, nil
+	approvals, err := repos.BidDecision.CountByBidIdAndDecision(ctx, bid.BidId, consts.BidApproved)
+	if err != nil {
+		return "", err
+	}
+
+	responsibles, err := repos.Organization.CountResponsibles(ctx, organizationId, permission.Roles(permission.BidDecide))
+	if err != nil {
+		return "", err
+	}
+
+	if approvals >= min(consts.BidApprovalQuorum, responsibles) {
+		return consts.BidApproved, nil
+	}
+	return "", nil
 }
 
-func (s *BidService) SubmitDecision(bidId uuid.UUID, username, decision string) (*entity.Bid, error) {
-	bid, err := s.bidRepo.FindByBidId(bidId)
+// AvailableTransitions возвращает переходы, доступные текущему пользователю: автору доступны публикация и отмена
+// до срока приема предложений, ответственным за тендер с разрешением bid.decide решения до срока принятия решений
+func (s *BidService) AvailableTransitions(ctx context.Context, bidId uuid.UUID) (*entity.Bid, []statemachine.Transition, error) {
+	employee, err := auth.CurrentEmployee(ctx)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, utils.BidNotExistsError
-		}
-		return nil, err
+		return nil, nil, err
 	}
 
-	tender, err := s.tenderRepo.FindByTenderId(bid.TenderId)
+	bid, err := s.bidRepo.FindByBidId(ctx, bidId)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, utils.TenderNotExistsError
+			return nil, nil, utils.BidNotExistsError
 		}
-		return nil, err
+		return nil, nil, err
 	}
 
-	_, err = s.employeeRepo.FindEmployeeIdByUsernameIfResponsibleForOrg(username, tender.OrganizationID)
+	tender, err := s.tenderRepo.FindByTenderId(ctx, bid.TenderId)
 	if err != nil {
-		return nil, s.specifyEmployeeVerificationError(username, err)
+		return nil, nil, err
 	}
 
-	if decision == consts.BidApproved {
-		tender.Status = consts.TenderClosed
-		tender.Version = tender.Version + 1
-		_, err = s.tenderRepo.Create(tender)
-		if err != nil {
-			return nil, err
+	var actors []statemachine.Actor
+	err = s.verifyBidAuthor(ctx, employee, bid, permission.BidSubmit)
+	if err == nil {
+		actors = append(actors, statemachine.ActorBidAuthor)
+	} else if !errors.Is(err, utils.UnauthorizedAccessError) && !errors.Is(err, utils.PermissionDeniedError) {
+		return nil, nil, err
+	}
+	authorErr := err
+
+	canDecide, err := s.permissionService.Has(ctx, tender.OrganizationID, permission.BidDecide)
+	if err != nil {
+		return nil, nil, err
+	}
+	if canDecide {
+		actors = append(actors, statemachine.ActorDecision)
+	}
+
+	if len(actors) == 0 {
+		return nil, nil, authorErr
+	}
+
+	now := time.Now()
+	transitions := []statemachine.Transition{}
+	for _, transition := range statemachine.Bid.Available(bid.Status, actors...) {
+		if transition.To == consts.BidPublished && tender.SubmissionExpired(now) {
+			continue
 		}
+		if (transition.To == consts.BidApproved || transition.To == consts.BidRejected) && tender.DecisionExpired(now) {
+			continue
+		}
+		transitions = append(transitions, transition)
 	}
 
-	return bid, nil
+	return bid, transitions, nil
 }
@@ -0,0 +1,407 @@
+package service
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"reflect"
+	"tenders/internal/application/interfaces"
+	"tenders/internal/domain/entity"
+	"tenders/internal/interfaces/dto/request"
+	"tenders/internal/utils"
+	"tenders/internal/utils/consts"
+	"testing"
+	"time"
+)
+
+// bidFixture опубликованный тендер организации и опубликованное к нему предложение сотрудника от своего имени
+type bidFixture struct {
+	organizationRepo *fakeOrganizationRepo
+	tenderRepo       *fakeTenderRepo
+	bidRepo          *fakeBidRepo
+	decisionRepo     *fakeBidDecisionRepo
+	outboxRepo       *fakeOutboxRepo
+	organizationId   uuid.UUID
+	tenderId         uuid.UUID
+	bidId            uuid.UUID
+	author           *entity.Employee
+	service          interfaces.BidService
+}
+
+func newBidFixture() *bidFixture {
+	organizationRepo := newFakeOrganizationRepo()
+	organizationId := uuid.New()
+	author := &entity.Employee{Id: uuid.New(), Username: "author", IsActive: true}
+	organizationRepo.employees.employees[author.Id] = author
+
+	tender := &entity.Tender{
+		TenderId:       uuid.New(),
+		Name:           "tender",
+		Status:         consts.TenderPublished,
+		Version:        1,
+		OrganizationID: organizationId,
+	}
+	bid := &entity.Bid{
+		BidId:         uuid.New(),
+		Name:          "bid",
+		TenderId:      tender.TenderId,
+		TenderVersion: tender.Version,
+		Status:        consts.BidPublished,
+		AuthorType:    consts.AuthorTypeUser,
+		AuthorId:      author.Id,
+		Version:       1,
+	}
+
+	f := &bidFixture{
+		organizationRepo: organizationRepo,
+		tenderRepo:       newFakeTenderRepo(tender),
+		bidRepo:          newFakeBidRepo(bid),
+		decisionRepo:     &fakeBidDecisionRepo{},
+		outboxRepo:       &fakeOutboxRepo{},
+		organizationId:   organizationId,
+		tenderId:         tender.TenderId,
+		bidId:            bid.BidId,
+		author:           author,
+	}
+
+	repos := organizationRepo.txRepositories()
+	repos.Tender = f.tenderRepo
+	repos.Bid = f.bidRepo
+	repos.BidDecision = f.decisionRepo
+	repos.Outbox = f.outboxRepo
+	repos.Audit = &fakeAuditRepo{}
+	repos.ChangeEvent = &fakeChangeEventRepo{}
+
+	f.service = NewBidService(
+		organizationRepo.employees, organizationRepo, f.bidRepo, f.tenderRepo, f.decisionRepo,
+		NewPermissionService(organizationRepo, testAdminUsername), &fakeUnitOfWork{repos: repos},
+	)
+	return f
+}
+
+func (f *bidFixture) bid() entity.Bid {
+	return *f.bidRepo.bids[f.bidId]
+}
+
+func (f *bidFixture) tender() entity.Tender {
+	return *f.tenderRepo.tenders[f.tenderId]
+}
+
+func TestBidServiceSubmitDecisionQuorum(t *testing.T) {
+	type vote struct {
+		voter      int
+		decision   string
+		wantStatus string
+		wantErr    error
+	}
+
+	tests := []struct {
+		name string
+		// voters ответственные за организацию тендера, голоса ссылаются на них по индексу
+		voters   []entity.ResponsibleRole
+		inactive []int
+		votes    []vote
+	}{
+		{
+			name: "three approvals",
+			voters: []entity.ResponsibleRole{
+				entity.RoleOwner, entity.RoleEvaluator, entity.RoleEvaluator, entity.RoleEvaluator,
+			},
+			votes: []vote{
+				{voter: 1, decision: consts.BidApproved, wantStatus: consts.BidPublished},
+				{voter: 2, decision: consts.BidApproved, wantStatus: consts.BidPublished},
+				{voter: 3, decision: consts.BidApproved, wantStatus: consts.BidApproved},
+				{voter: 0, decision: consts.BidRejected, wantErr: utils.BidAlreadyDecidedError},
+			},
+		},
+		{
+			name:   "repeat approval counts once",
+			voters: []entity.ResponsibleRole{entity.RoleOwner, entity.RoleEvaluator, entity.RoleEvaluator},
+			votes: []vote{
+				{voter: 1, decision: consts.BidApproved, wantStatus: consts.BidPublished},
+				{voter: 1, decision: consts.BidApproved, wantStatus: consts.BidPublished},
+				{voter: 1, decision: consts.BidApproved, wantStatus: consts.BidPublished},
+				{voter: 2, decision: consts.BidApproved, wantStatus: consts.BidPublished},
+				{voter: 0, decision: consts.BidApproved, wantStatus: consts.BidApproved},
+			},
+		},
+		{
+			name:   "quorum limited by deciders",
+			voters: []entity.ResponsibleRole{entity.RoleEvaluator, entity.RoleTenderManager, entity.RoleViewer},
+			votes: []vote{
+				{voter: 0, decision: consts.BidApproved, wantStatus: consts.BidApproved},
+			},
+		},
+		{
+			name:     "inactive deciders are not counted",
+			voters:   []entity.ResponsibleRole{entity.RoleOwner, entity.RoleEvaluator, entity.RoleEvaluator},
+			inactive: []int{2},
+			votes: []vote{
+				{voter: 0, decision: consts.BidApproved, wantStatus: consts.BidPublished},
+				{voter: 1, decision: consts.BidApproved, wantStatus: consts.BidApproved},
+			},
+		},
+		{
+			name:   "single rejection",
+			voters: []entity.ResponsibleRole{entity.RoleOwner, entity.RoleEvaluator, entity.RoleEvaluator},
+			votes: []vote{
+				{voter: 1, decision: consts.BidApproved, wantStatus: consts.BidPublished},
+				{voter: 2, decision: consts.BidRejected, wantStatus: consts.BidRejected},
+				{voter: 0, decision: consts.BidApproved, wantErr: utils.BidAlreadyDecidedError},
+			},
+		},
+		{
+			name:   "changed vote",
+			voters: []entity.ResponsibleRole{entity.RoleOwner, entity.RoleEvaluator},
+			votes: []vote{
+				{voter: 1, decision: consts.BidApproved, wantStatus: consts.BidPublished},
+				{voter: 1, decision: consts.BidRejected, wantStatus: consts.BidRejected},
+			},
+		},
+		{
+			name:   "without bid.decide",
+			voters: []entity.ResponsibleRole{entity.RoleOwner, entity.RoleTenderManager, entity.RoleViewer},
+			votes: []vote{
+				{voter: 1, decision: consts.BidApproved, wantErr: utils.PermissionDeniedError},
+				{voter: 2, decision: consts.BidRejected, wantErr: utils.PermissionDeniedError},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newBidFixture()
+			voters := make([]*entity.Employee, 0, len(tt.voters))
+			for _, role := range tt.voters {
+				voters = append(voters, testEmployee(f.organizationRepo, f.organizationId, role))
+			}
+			for _, i := range tt.inactive {
+				voters[i].IsActive = false
+			}
+
+			for i, vote := range tt.votes {
+				bid, err := f.service.SubmitDecision(withEmployee(voters[vote.voter]), f.bidId, vote.decision)
+				if vote.wantErr != nil {
+					if !errors.Is(err, vote.wantErr) {
+						t.Fatalf("vote %d: err = %v, want %v", i, err, vote.wantErr)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("vote %d: %v", i, err)
+				}
+				if bid.Status != vote.wantStatus || f.bid().Status != vote.wantStatus {
+					t.Fatalf("vote %d: status = %s, stored %s, want %s", i, bid.Status, f.bid().Status, vote.wantStatus)
+				}
+			}
+		})
+	}
+}
+
+func TestBidServiceSubmitDecisionApprovalClosesTender(t *testing.T) {
+	f := newBidFixture()
+	evaluator := testEmployee(f.organizationRepo, f.organizationId, entity.RoleEvaluator)
+
+	bid, err := f.service.SubmitDecision(withEmployee(evaluator), f.bidId, consts.BidApproved)
+	if err != nil {
+		t.Fatalf("SubmitDecision: %v", err)
+	}
+
+	if bid.Version != 2 || len(f.bidRepo.history) != 1 || f.bidRepo.history[0].Status != consts.BidPublished {
+		t.Errorf("bid version = %d, history = %+v, want version 2 after Published", bid.Version, f.bidRepo.history)
+	}
+	tender := f.tender()
+	if tender.Status != consts.TenderClosed || tender.Version != 2 || tender.CreatorID != evaluator.Id {
+		t.Errorf("tender = %s v%d by %s, want Closed v2 by evaluator", tender.Status, tender.Version, tender.CreatorID)
+	}
+	want := []string{consts.EventBidDecision, consts.EventTenderClosed}
+	if got := f.outboxRepo.eventTypes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("outbox = %v, want %v", got, want)
+	}
+}
+
+func TestBidServiceSubmitDecisionRejectionKeepsTender(t *testing.T) {
+	f := newBidFixture()
+	evaluator := testEmployee(f.organizationRepo, f.organizationId, entity.RoleEvaluator)
+
+	if _, err := f.service.SubmitDecision(withEmployee(evaluator), f.bidId, consts.BidRejected); err != nil {
+		t.Fatalf("SubmitDecision: %v", err)
+	}
+
+	if tender := f.tender(); tender.Status != consts.TenderPublished || tender.Version != 1 {
+		t.Errorf("tender = %s v%d, want Published v1", tender.Status, tender.Version)
+	}
+	want := []string{consts.EventBidDecision}
+	if got := f.outboxRepo.eventTypes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("outbox = %v, want %v", got, want)
+	}
+}
+
+func TestBidServiceExpectedVersion(t *testing.T) {
+	operations := []struct {
+		name string
+		run  func(f *bidFixture, expectedVersion int) (*entity.Bid, error)
+	}{
+		{"edit", func(f *bidFixture, expectedVersion int) (*entity.Bid, error) {
+			editRequest := &request.EditBidRequest{Name: "renamed"}
+			return f.service.EditBid(withEmployee(f.author), f.bidId, editRequest, expectedVersion)
+		}},
+		{"status", func(f *bidFixture, expectedVersion int) (*entity.Bid, error) {
+			return f.service.UpdateStatus(withEmployee(f.author), f.bidId, consts.BidCanceled, expectedVersion)
+		}},
+		{"rollback", func(f *bidFixture, expectedVersion int) (*entity.Bid, error) {
+			return f.service.RollbackBid(withEmployee(f.author), f.bidId, 1, expectedVersion)
+		}},
+	}
+	cases := []struct {
+		name            string
+		expectedVersion int
+		wantErr         error
+	}{
+		{name: "current", expectedVersion: 3},
+		{name: "without If-Match", expectedVersion: 0},
+		{name: "stale", expectedVersion: 2, wantErr: utils.VersionConflictError},
+		{name: "ahead", expectedVersion: 4, wantErr: utils.VersionConflictError},
+	}
+
+	for _, operation := range operations {
+		for _, tt := range cases {
+			t.Run(operation.name+"/"+tt.name, func(t *testing.T) {
+				f := newBidFixture()
+				current := f.bidRepo.bids[f.bidId]
+				f.bidRepo.history = append(f.bidRepo.history, *current)
+				current.Version = 3
+
+				bid, err := operation.run(f, tt.expectedVersion)
+				if tt.wantErr != nil {
+					if !errors.Is(err, tt.wantErr) {
+						t.Fatalf("err = %v, want %v", err, tt.wantErr)
+					}
+					if stored := f.bid(); stored.Version != 3 || len(f.bidRepo.history) != 1 {
+						t.Errorf("bid changed on conflict: v%d, history %d", stored.Version, len(f.bidRepo.history))
+					}
+					return
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				if bid.Version != 4 || f.bid().Version != 4 {
+					t.Errorf("version = %d, stored %d, want 4", bid.Version, f.bid().Version)
+				}
+			})
+		}
+	}
+}
+
+// TestBidServiceConcurrentEdit второй запрос прочитал ту же версию, но первый успел ее изменить:
+// If-Match совпадает с прочитанной версией, обновление отклоняет репозиторий
+func TestBidServiceConcurrentEdit(t *testing.T) {
+	f := newBidFixture()
+	f.bidRepo.beforeUpdate = func() {
+		f.bidRepo.beforeUpdate = nil
+		_, err := f.service.EditBid(withEmployee(f.author), f.bidId, &request.EditBidRequest{Name: "first"}, 1)
+		if err != nil {
+			t.Fatalf("first edit: %v", err)
+		}
+	}
+
+	_, err := f.service.EditBid(withEmployee(f.author), f.bidId, &request.EditBidRequest{Name: "second"}, 1)
+	if !errors.Is(err, utils.VersionConflictError) {
+		t.Fatalf("err = %v, want %v", err, utils.VersionConflictError)
+	}
+	if bid := f.bid(); bid.Name != "first" || bid.Version != 2 {
+		t.Errorf("bid = %s v%d, want first v2", bid.Name, bid.Version)
+	}
+}
+
+func TestBidServiceDeadlines(t *testing.T) {
+	createBid := func(f *bidFixture, _ *entity.Employee) error {
+		_, err := f.service.CreateNewBid(withEmployee(f.author), &request.BidRequest{
+			Name:        "another bid",
+			Description: "description",
+			TenderId:    f.tenderId,
+			AuthorType:  consts.AuthorTypeUser,
+			AuthorId:    f.author.Id,
+			Amount:      "100",
+			Currency:    "RUB",
+		})
+		return err
+	}
+	editBid := func(f *bidFixture, _ *entity.Employee) error {
+		_, err := f.service.EditBid(withEmployee(f.author), f.bidId, &request.EditBidRequest{Name: "renamed"}, 0)
+		return err
+	}
+	updateStatus := func(status string) func(f *bidFixture, _ *entity.Employee) error {
+		return func(f *bidFixture, _ *entity.Employee) error {
+			_, err := f.service.UpdateStatus(withEmployee(f.author), f.bidId, status, 0)
+			return err
+		}
+	}
+	approve := func(f *bidFixture, evaluator *entity.Employee) error {
+		_, err := f.service.SubmitDecision(withEmployee(evaluator), f.bidId, consts.BidApproved)
+		return err
+	}
+
+	tests := []struct {
+		name string
+		// submission и decision смещения сроков тендера от текущего времени, ноль оставляет срок пустым
+		submission time.Duration
+		decision   time.Duration
+		bidStatus  string
+		run        func(f *bidFixture, evaluator *entity.Employee) error
+		wantErr    error
+	}{
+		{name: "create before deadline", submission: time.Hour, run: createBid},
+		{name: "create without deadline", run: createBid},
+		{name: "create after deadline", submission: -time.Hour, run: createBid, wantErr: utils.SubmissionClosedError},
+		{name: "edit before deadline", submission: time.Hour, run: editBid},
+		{name: "edit after deadline", submission: -time.Hour, run: editBid, wantErr: utils.SubmissionClosedError},
+		{
+			name:       "publish after deadline",
+			submission: -time.Hour,
+			bidStatus:  consts.BidCreated,
+			run:        updateStatus(consts.BidPublished),
+			wantErr:    utils.SubmissionClosedError,
+		},
+		{name: "cancel after deadline", submission: -time.Hour, run: updateStatus(consts.BidCanceled)},
+		{name: "decide after submission deadline", submission: -time.Hour, decision: time.Hour, run: approve},
+		{
+			name:       "decide after decision deadline",
+			submission: -2 * time.Hour,
+			decision:   -time.Hour,
+			run:        approve,
+			wantErr:    utils.DecisionClosedError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newBidFixture()
+			evaluator := testEmployee(f.organizationRepo, f.organizationId, entity.RoleEvaluator)
+			tender := f.tenderRepo.tenders[f.tenderId]
+			if tt.submission != 0 {
+				tender.SubmissionDeadline = deadline(tt.submission)
+			}
+			if tt.decision != 0 {
+				tender.DecisionDeadline = deadline(tt.decision)
+			}
+			if tt.bidStatus != "" {
+				f.bidRepo.bids[f.bidId].Status = tt.bidStatus
+			}
+			before := f.bid()
+
+			err := tt.run(f, evaluator)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if len(f.bidRepo.bids) != 1 || !reflect.DeepEqual(f.bid(), before) || len(f.outboxRepo.saved) != 0 {
+				t.Errorf("bids changed after deadline: %+v", f.bidRepo.bids)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"tenders/internal/domain/entity"
+	"tenders/internal/domain/repository"
+	"tenders/internal/utils/common/custom_types"
+	"tenders/internal/utils/consts"
+	"time"
+)
+
+// ReviewEventPayload данные события об отзыве на автора предложения
+type ReviewEventPayload struct {
+	ReviewId    uuid.UUID `json:"reviewId"`
+	BidId       uuid.UUID `json:"bidId"`
+	Description string    `json:"description"`
+}
+
+// publishChange добавляет событие в ленту изменений, вызывается в транзакции изменения
+func publishChange(
+	ctx context.Context, changeEventRepo repository.ChangeEventRepository, event entity.ChangeEvent, data any,
+) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	event.Data = payload
+	event.CreatedAt = custom_types.RFC3339Time(time.Now())
+	return changeEventRepo.Save(ctx, &event)
+}
+
+// publishTenderChange событие о тендере видно ответственным за его организацию
+func publishTenderChange(
+	ctx context.Context, changeEventRepo repository.ChangeEventRepository, tender *entity.Tender, action string,
+) error {
+	return publishChange(ctx, changeEventRepo, entity.ChangeEvent{
+		EntityType:     consts.AuditEntityTender,
+		EntityId:       tender.TenderId,
+		Action:         action,
+		OrganizationId: tender.OrganizationID,
+	}, TenderEventPayload{
+		TenderId: tender.TenderId,
+		Name:     tender.Name,
+		Status:   tender.Status,
+		Version:  tender.Version,
+	})
+}
+
+// publishBidChange событие о предложении видно его автору и ответственным за организацию тендера
+func publishBidChange(
+	ctx context.Context, changeEventRepo repository.ChangeEventRepository,
+	tenderOrganizationId uuid.UUID, bid *entity.Bid, action, decision string,
+) error {
+	event := entity.ChangeEvent{
+		EntityType:     consts.AuditEntityBid,
+		EntityId:       bid.BidId,
+		Action:         action,
+		OrganizationId: tenderOrganizationId,
+	}
+	if bid.AuthorType == consts.AuthorTypeUser {
+		event.AuthorEmployeeId = uuid.NullUUID{UUID: bid.AuthorId, Valid: true}
+	} else {
+		event.AuthorOrganizationId = uuid.NullUUID{UUID: bid.AuthorId, Valid: true}
+	}
+
+	return publishChange(ctx, changeEventRepo, event, newBidEventPayload(bid, decision))
+}
@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"tenders/internal/application/interfaces"
+	"tenders/internal/domain/entity"
+	"tenders/internal/domain/permission"
+	"tenders/internal/domain/repository"
+	"tenders/internal/interfaces/dto/request"
+	"tenders/internal/utils"
+	"tenders/internal/utils/auth"
+	"time"
+)
+
+type EmployeeService struct {
+	employeeRepo      repository.EmployeeRepository
+	organizationRepo  repository.OrganizationRepository
+	permissionService interfaces.PermissionService
+	unitOfWork        repository.UnitOfWork
+	adminUsername     string
+}
+
+func NewEmployeeService(
+	employeeRepo repository.EmployeeRepository,
+	organizationRepo repository.OrganizationRepository,
+	permissionService interfaces.PermissionService,
+	unitOfWork repository.UnitOfWork,
+	adminUsername string,
+) interfaces.EmployeeService {
+	return &EmployeeService{
+		employeeRepo:      employeeRepo,
+		organizationRepo:  organizationRepo,
+		permissionService: permissionService,
+		unitOfWork:        unitOfWork,
+		adminUsername:     adminUsername,
+	}
+}
+
+// Create заводит сотрудника, это может сделать администратор или владелец любой действующей организации
+func (s *EmployeeService) Create(
+	ctx context.Context, employeeRequest *request.EmployeeRequest,
+) (*entity.Employee, error) {
+	_, err := s.permissionService.RequireAnywhere(ctx, permission.OrganizationManage)
+	if err != nil {
+		return nil, err
+	}
+
+	employee, err := employeeRequest.MapToEmployee()
+	if err != nil {
+		return nil, err
+	}
+
+	if employeeRequest.Password != "" {
+		if employee.PasswordHash, err = hashPassword(employeeRequest.Password); err != nil {
+			return nil, err
+		}
+	}
+
+	employee.Id = uuid.New()
+	employee.CreatedAt = time.Now()
+	employee.UpdatedAt = employee.CreatedAt
+
+	if err = s.employeeRepo.Create(ctx, employee); err != nil {
+		return nil, err
+	}
+	return employee, nil
+}
+
+func (s *EmployeeService) Edit(
+	ctx context.Context, employeeId uuid.UUID, editRequest *request.EditEmployeeRequest,
+) (*entity.Employee, error) {
+	employee, err := s.findManagedEmployee(ctx, employeeId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !employee.IsActive {
+		return nil, utils.EmployeeInactiveError
+	}
+
+	if err = editRequest.UpdateEmployee(employee); err != nil {
+		return nil, err
+	}
+
+	// Пароль меняет только сам сотрудник или администратор
+	if editRequest.Password != nil {
+		requester, err := auth.CurrentEmployee(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if requester.Id != employee.Id && !isAdmin(requester, s.adminUsername) {
+			return nil, utils.UnauthorizedAccessError
+		}
+
+		if employee.PasswordHash, err = hashPassword(*editRequest.Password); err != nil {
+			return nil, err
+		}
+	}
+
+	employee.UpdatedAt = time.Now()
+	if err = s.employeeRepo.Update(ctx, employee); err != nil {
+		return nil, err
+	}
+	return employee, nil
+}
+
+// Deactivate запрещает сотруднику вход и действия от имени всех его организаций, поэтому деактивировать его
+// может только он сам или администратор. Владелец организации вместо этого снимает сотрудника с ответственных.
+// Последнего действующего владельца организации деактивировать нельзя, повторная деактивация ничего не меняет
+func (s *EmployeeService) Deactivate(ctx context.Context, employeeId uuid.UUID) (*entity.Employee, error) {
+	requester, err := auth.CurrentEmployee(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if requester.Id != employeeId && !isAdmin(requester, s.adminUsername) {
+		return nil, utils.UnauthorizedAccessError
+	}
+
+	var employee *entity.Employee
+	err = s.unitOfWork.WithinTx(ctx, func(repos *repository.TxRepositories) error {
+		employee, err = repos.Employee.FindById(ctx, employeeId)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return utils.EmployeeNotExistsError
+			}
+			return err
+		}
+
+		if !employee.IsActive {
+			return nil
+		}
+
+		organizations, err := repos.Organization.FindAllByEmployeeId(ctx, employeeId)
+		if err != nil {
+			return err
+		}
+		for _, organization := range organizations {
+			// Блокировка не дает одновременно снять или деактивировать другого владельца той же организации
+			if _, err = repos.Organization.FindByIdForUpdate(ctx, organization.Id); err != nil {
+				return err
+			}
+
+			role, err := repos.Organization.FindRole(ctx, organization.Id, employeeId)
+			if err != nil {
+				return err
+			}
+			if err = checkLastOwner(ctx, repos, organization.Id, employeeId, role); err != nil {
+				return err
+			}
+		}
+
+		employee.IsActive = false
+		employee.UpdatedAt = time.Now()
+		return repos.Employee.Update(ctx, employee)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return employee, nil
+}
+
+// EnsureAdmin создает администратора при первом запуске, пароль существующего администратора не меняется
+func (s *EmployeeService) EnsureAdmin(ctx context.Context, password string) error {
+	if s.adminUsername == "" {
+		return nil
+	}
+
+	_, err := s.employeeRepo.FindByUsername(ctx, s.adminUsername)
+	if err == nil || !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	if password == "" {
+		return fmt.Errorf("admin %q does not exist and no password is set to create it", s.adminUsername)
+	}
+
+	passwordHash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	return s.employeeRepo.Create(ctx, &entity.Employee{
+		Id:           uuid.New(),
+		Username:     s.adminUsername,
+		PasswordHash: passwordHash,
+		IsActive:     true,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	})
+}
+
+// findManagedEmployee ищет сотрудника, которым может управлять текущий пользователь: себя самого,
+// ответственных за действующую организацию, в которой у него есть разрешение organization.manage,
+// а администратор любого сотрудника
+func (s *EmployeeService) findManagedEmployee(ctx context.Context, employeeId uuid.UUID) (*entity.Employee, error) {
+	requester, err := auth.CurrentEmployee(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	employee, err := s.employeeRepo.FindById(ctx, employeeId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.EmployeeNotExistsError
+		}
+		return nil, err
+	}
+
+	if requester.Id == employee.Id || isAdmin(requester, s.adminUsername) {
+		return employee, nil
+	}
+
+	shared, err := s.organizationRepo.ShareActiveOrganization(
+		ctx, requester.Id, employee.Id, permission.Roles(permission.OrganizationManage),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !shared {
+		return nil, utils.PermissionDeniedError.WithPermission(string(permission.OrganizationManage))
+	}
+	return employee, nil
+}
+
+// isAdmin проверяет, что сотрудник является администратором, заданным в конфигурации
+func isAdmin(employee *entity.Employee, adminUsername string) bool {
+	return adminUsername != "" && employee.Username == adminUsername
+}
+
+func hashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
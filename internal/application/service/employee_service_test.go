@@ -0,0 +1,124 @@
+package service
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"tenders/internal/domain/entity"
+	"tenders/internal/utils"
+	"testing"
+)
+
+func TestEmployeeServiceDeactivate(t *testing.T) {
+	tests := []struct {
+		name string
+		// setup возвращает того, кто деактивирует, и того, кого деактивируют
+		setup      func(organizationRepo *fakeOrganizationRepo) (requester, target *entity.Employee)
+		wantErr    error
+		wantActive bool
+	}{
+		{
+			name: "owner of another organization",
+			setup: func(organizationRepo *fakeOrganizationRepo) (*entity.Employee, *entity.Employee) {
+				shared, other := uuid.New(), uuid.New()
+				owner := testEmployee(organizationRepo, shared, entity.RoleOwner)
+				otherOwner := testEmployee(organizationRepo, other, entity.RoleOwner)
+				testEmployee(organizationRepo, other, entity.RoleOwner)
+				organizationRepo.assign(shared, otherOwner.Id, entity.RoleViewer)
+				return owner, otherOwner
+			},
+			wantErr:    utils.UnauthorizedAccessError,
+			wantActive: true,
+		},
+		{
+			name: "self with another owner",
+			setup: func(organizationRepo *fakeOrganizationRepo) (*entity.Employee, *entity.Employee) {
+				organizationId := uuid.New()
+				owner := testEmployee(organizationRepo, organizationId, entity.RoleOwner)
+				testEmployee(organizationRepo, organizationId, entity.RoleOwner)
+				return owner, owner
+			},
+		},
+		{
+			name: "self as last owner",
+			setup: func(organizationRepo *fakeOrganizationRepo) (*entity.Employee, *entity.Employee) {
+				owner := testEmployee(organizationRepo, uuid.New(), entity.RoleOwner)
+				return owner, owner
+			},
+			wantErr:    utils.LastOwnerError,
+			wantActive: true,
+		},
+		{
+			name: "self as last active owner",
+			setup: func(organizationRepo *fakeOrganizationRepo) (*entity.Employee, *entity.Employee) {
+				organizationId := uuid.New()
+				owner := testEmployee(organizationRepo, organizationId, entity.RoleOwner)
+				testEmployee(organizationRepo, organizationId, entity.RoleOwner).IsActive = false
+				return owner, owner
+			},
+			wantErr:    utils.LastOwnerError,
+			wantActive: true,
+		},
+		{
+			name: "admin",
+			setup: func(organizationRepo *fakeOrganizationRepo) (*entity.Employee, *entity.Employee) {
+				organizationId := uuid.New()
+				testEmployee(organizationRepo, organizationId, entity.RoleOwner)
+				return testAdmin(), testEmployee(organizationRepo, organizationId, entity.RoleViewer)
+			},
+		},
+		{
+			name: "admin and last owner",
+			setup: func(organizationRepo *fakeOrganizationRepo) (*entity.Employee, *entity.Employee) {
+				return testAdmin(), testEmployee(organizationRepo, uuid.New(), entity.RoleOwner)
+			},
+			wantErr:    utils.LastOwnerError,
+			wantActive: true,
+		},
+		{
+			name: "already inactive",
+			setup: func(organizationRepo *fakeOrganizationRepo) (*entity.Employee, *entity.Employee) {
+				target := testEmployee(organizationRepo, uuid.New(), entity.RoleOwner)
+				target.IsActive = false
+				return testAdmin(), target
+			},
+		},
+		{
+			name: "not existing",
+			setup: func(organizationRepo *fakeOrganizationRepo) (*entity.Employee, *entity.Employee) {
+				return testAdmin(), &entity.Employee{Id: uuid.New()}
+			},
+			wantErr: utils.EmployeeNotExistsError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			organizationRepo := newFakeOrganizationRepo()
+			requester, target := tt.setup(organizationRepo)
+			employeeService := NewEmployeeService(
+				organizationRepo.employees, organizationRepo,
+				NewPermissionService(organizationRepo, testAdminUsername),
+				&fakeUnitOfWork{repos: organizationRepo.txRepositories()}, testAdminUsername,
+			)
+
+			employee, err := employeeService.Deactivate(withEmployee(requester), target.Id)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Deactivate err = %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("Deactivate: %v", err)
+			} else if employee.IsActive {
+				t.Errorf("Deactivate returned active employee")
+			}
+
+			if stored, ok := organizationRepo.employees.employees[target.Id]; ok && stored.IsActive != tt.wantActive {
+				t.Errorf("stored IsActive = %v, want %v", stored.IsActive, tt.wantActive)
+			}
+		})
+	}
+}
+
+func testAdmin() *entity.Employee {
+	return &entity.Employee{Id: uuid.New(), Username: testAdminUsername, IsActive: true}
+}
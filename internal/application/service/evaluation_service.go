@@ -0,0 +1,271 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"tenders/internal/application/interfaces"
+	"tenders/internal/domain/entity"
+	"tenders/internal/domain/permission"
+	"tenders/internal/domain/repository"
+	"tenders/internal/utils"
+	"tenders/internal/utils/auth"
+	"tenders/internal/utils/common/custom_types"
+	"tenders/internal/utils/consts"
+	"time"
+)
+
+// rankedBidStatuses статусы предложений, попадающих в рейтинг тендера
+var rankedBidStatuses = []string{consts.BidPublished, consts.BidApproved, consts.BidRejected}
+
+type EvaluationService struct {
+	tenderRepo        repository.TenderRepository
+	bidRepo           repository.BidRepository
+	evaluationRepo    repository.EvaluationRepository
+	permissionService interfaces.PermissionService
+	unitOfWork        repository.UnitOfWork
+}
+
+func NewEvaluationService(
+	tenderRepo repository.TenderRepository,
+	bidRepo repository.BidRepository,
+	evaluationRepo repository.EvaluationRepository,
+	permissionService interfaces.PermissionService,
+	unitOfWork repository.UnitOfWork,
+) interfaces.EvaluationService {
+	return &EvaluationService{
+		tenderRepo:        tenderRepo,
+		bidRepo:           bidRepo,
+		evaluationRepo:    evaluationRepo,
+		permissionService: permissionService,
+		unitOfWork:        unitOfWork,
+	}
+}
+
+// SetCriteria заменяет критерии тендера. Старые критерии удаляются до проверки оценок: удаление ждет транзакции
+// оценщиков, заблокировавших критерии, и после него проверка видит уже сохраненные ими оценки
+func (s *EvaluationService) SetCriteria(
+	ctx context.Context, tenderId uuid.UUID, criteria []entity.TenderCriterion,
+) ([]entity.TenderCriterion, error) {
+	employee, err := auth.CurrentEmployee(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.unitOfWork.WithinTx(ctx, func(repos *repository.TxRepositories) error {
+		tender, err := repos.Tender.FindByTenderId(ctx, tenderId)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return utils.TenderNotExistsError
+			}
+			return err
+		}
+
+		if _, err = s.permissionService.Require(ctx, tender.OrganizationID, permission.TenderManage); err != nil {
+			return err
+		}
+
+		if tender.Status == consts.TenderClosed {
+			return utils.CriteriaLockedError
+		}
+
+		if err = repos.Evaluation.ReplaceCriteria(ctx, tenderId, criteria); err != nil {
+			return err
+		}
+
+		scored, err := repos.Evaluation.ExistsScoresByTenderId(ctx, tenderId)
+		if err != nil {
+			return err
+		}
+		if scored {
+			return utils.CriteriaLockedError
+		}
+
+		return recordAudit(ctx, repos.Audit, employee, entity.AuditEvent{
+			OrganizationId: tender.OrganizationID,
+			EntityType:     consts.AuditEntityTender,
+			EntityId:       tender.TenderId,
+			Action:         consts.AuditActionCriteria,
+			VersionAfter:   tender.Version,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.evaluationRepo.FindCriteriaByTenderId(ctx, tenderId)
+}
+
+// FindCriteria возвращает критерии тендера с теми же правилами видимости, что и сам тендер:
+// критерии опубликованного тендера доступны всем, остальные только с разрешением tender.view
+func (s *EvaluationService) FindCriteria(ctx context.Context, tenderId uuid.UUID) ([]entity.TenderCriterion, error) {
+	tender, err := s.findTender(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+
+	if tender.Status != consts.TenderPublished {
+		if _, err = s.permissionService.Require(ctx, tender.OrganizationID, permission.TenderView); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.evaluationRepo.FindCriteriaByTenderId(ctx, tenderId)
+}
+
+func (s *EvaluationService) SubmitScores(
+	ctx context.Context, bidId uuid.UUID, scores []entity.CriterionScore,
+) (*entity.BidEvaluation, error) {
+	employee, err := auth.CurrentEmployee(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var evaluation *entity.BidEvaluation
+	err = s.unitOfWork.WithinTx(ctx, func(repos *repository.TxRepositories) error {
+		bid, err := repos.Bid.FindByBidId(ctx, bidId)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return utils.BidNotExistsError
+			}
+			return err
+		}
+
+		tender, err := repos.Tender.FindByTenderId(ctx, bid.TenderId)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return utils.TenderNotExistsError
+			}
+			return err
+		}
+
+		if _, err = s.permissionService.Require(ctx, tender.OrganizationID, permission.BidEvaluate); err != nil {
+			return err
+		}
+
+		if bid.Status != consts.BidPublished {
+			return utils.BidNotPublishedError
+		}
+
+		if tender.DecisionExpired(time.Now()) {
+			return utils.DecisionClosedError
+		}
+
+		criteria, err := repos.Evaluation.LockCriteriaByTenderId(ctx, tender.TenderId)
+		if err != nil {
+			return err
+		}
+		if len(criteria) == 0 {
+			return utils.CriteriaNotDefinedError
+		}
+
+		if !coversCriteria(criteria, scores) {
+			return utils.NewValidationError([]string{"scores"})
+		}
+
+		createdAt := custom_types.RFC3339Time(time.Now())
+		bidScores := make([]entity.BidScore, 0, len(scores))
+		for _, score := range scores {
+			bidScores = append(bidScores, entity.BidScore{
+				BidId:      bid.BidId,
+				EmployeeId: employee.Id,
+				Criterion:  score.Criterion,
+				Score:      score.Score,
+				CreatedAt:  createdAt,
+			})
+		}
+		if err = repos.Evaluation.SaveScores(ctx, bidScores); err != nil {
+			return err
+		}
+
+		err = recordAudit(ctx, repos.Audit, employee, entity.AuditEvent{
+			OrganizationId: tender.OrganizationID,
+			EntityType:     consts.AuditEntityBid,
+			EntityId:       bid.BidId,
+			Action:         consts.AuditActionScore,
+			VersionAfter:   bid.Version,
+		})
+		if err != nil {
+			return err
+		}
+
+		evaluation = &entity.BidEvaluation{
+			EvaluatorId:       employee.Id,
+			EvaluatorUsername: employee.Username,
+			Scores:            scores,
+			Total:             entity.WeightedTotal(criteria, scores),
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return evaluation, nil
+}
+
+// coversCriteria проверяет, что оценки выставлены ровно по критериям тендера
+func coversCriteria(criteria []entity.TenderCriterion, scores []entity.CriterionScore) bool {
+	if len(criteria) != len(scores) {
+		return false
+	}
+
+	defined := make(map[entity.Criterion]bool, len(criteria))
+	for _, criterion := range criteria {
+		defined[criterion.Criterion] = true
+	}
+	for _, score := range scores {
+		if !defined[score.Criterion] {
+			return false
+		}
+	}
+	return true
+}
+
+// Ranking возвращает рейтинг опубликованных и рассмотренных предложений тендера
+func (s *EvaluationService) Ranking(ctx context.Context, tenderId uuid.UUID) ([]entity.BidRanking, error) {
+	if _, err := auth.CurrentEmployee(ctx); err != nil {
+		return nil, err
+	}
+
+	tender, err := s.findTender(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = s.permissionService.Require(ctx, tender.OrganizationID, permission.BidView); err != nil {
+		return nil, err
+	}
+
+	criteria, err := s.evaluationRepo.FindCriteriaByTenderId(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if len(criteria) == 0 {
+		return nil, utils.CriteriaNotDefinedError
+	}
+
+	bids, err := s.bidRepo.FindAllByTenderIdAndFilter(ctx, tenderId, repository.BidFilter{Statuses: rankedBidStatuses})
+	if err != nil {
+		return nil, err
+	}
+
+	scores, err := s.evaluationRepo.FindScoresByTenderId(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.RankBids(criteria, bids, scores), nil
+}
+
+func (s *EvaluationService) findTender(ctx context.Context, tenderId uuid.UUID) (*entity.Tender, error) {
+	tender, err := s.tenderRepo.FindByTenderId(ctx, tenderId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.TenderNotExistsError
+		}
+		return nil, err
+	}
+	return tender, nil
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"tenders/internal/domain/entity"
+	"testing"
+)
+
+func TestCoversCriteria(t *testing.T) {
+	criteria := []entity.TenderCriterion{
+		{Criterion: entity.CriterionPrice, Weight: 60},
+		{Criterion: entity.CriterionQuality, Weight: 40},
+	}
+	// scored оценки 5 по перечисленным критериям
+	scored := func(criteria ...entity.Criterion) []entity.CriterionScore {
+		scores := make([]entity.CriterionScore, 0, len(criteria))
+		for _, criterion := range criteria {
+			scores = append(scores, entity.CriterionScore{Criterion: criterion, Score: 5})
+		}
+		return scores
+	}
+
+	tests := []struct {
+		name     string
+		criteria []entity.TenderCriterion
+		scores   []entity.CriterionScore
+		want     bool
+	}{
+		{
+			name:     "exact",
+			criteria: criteria,
+			scores:   scored(entity.CriterionPrice, entity.CriterionQuality),
+			want:     true,
+		},
+		{
+			name:     "another order",
+			criteria: criteria,
+			scores:   scored(entity.CriterionQuality, entity.CriterionPrice),
+			want:     true,
+		},
+		{
+			name:     "missing criterion",
+			criteria: criteria,
+			scores:   scored(entity.CriterionPrice),
+		},
+		{
+			name:     "extra criterion",
+			criteria: criteria,
+			scores:   scored(entity.CriterionPrice, entity.CriterionQuality, entity.CriterionExperience),
+		},
+		{
+			name:     "criterion outside tender",
+			criteria: criteria,
+			scores:   scored(entity.CriterionPrice, entity.CriterionExperience),
+		},
+		{
+			name:   "tender without criteria",
+			scores: scored(entity.CriterionPrice),
+		},
+		{name: "nothing to cover", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := coversCriteria(tt.criteria, tt.scores); got != tt.want {
+				t.Errorf("coversCriteria() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"tenders/internal/application/interfaces"
+	"tenders/internal/domain/entity"
+	"tenders/internal/domain/permission"
+	"tenders/internal/domain/repository"
+	"tenders/internal/utils/auth"
+)
+
+type EventStreamService struct {
+	organizationRepo  repository.OrganizationRepository
+	changeEventRepo   repository.ChangeEventRepository
+	notifier          repository.ChangeNotifier
+	permissionService interfaces.PermissionService
+}
+
+func NewEventStreamService(
+	organizationRepo repository.OrganizationRepository,
+	changeEventRepo repository.ChangeEventRepository,
+	notifier repository.ChangeNotifier,
+	permissionService interfaces.PermissionService,
+) interfaces.EventStreamService {
+	return &EventStreamService{
+		organizationRepo:  organizationRepo,
+		changeEventRepo:   changeEventRepo,
+		notifier:          notifier,
+		permissionService: permissionService,
+	}
+}
+
+// VisibilityFilter определяет, какие события видны текущему пользователю: события его предложений
+// и события организаций, за которые он отвечает, или только выбранной в запросе. События тендеров,
+// предложений и отзывов видны только в организациях, где роль разрешает tender.view, bid.view и review.view
+func (s *EventStreamService) VisibilityFilter(ctx context.Context) (repository.ChangeEventFilter, error) {
+	employee, err := auth.CurrentEmployee(ctx)
+	if err != nil {
+		return repository.ChangeEventFilter{}, err
+	}
+
+	organizationIds, err := employeeOrganizationIds(ctx, s.organizationRepo, employee.Id)
+	if err != nil {
+		return repository.ChangeEventFilter{}, err
+	}
+
+	filter := repository.ChangeEventFilter{EmployeeId: employee.Id}
+	if filter.TenderOrganizationIds, err = permittedOrganizationIds(
+		ctx, s.permissionService, organizationIds, permission.TenderView,
+	); err != nil {
+		return repository.ChangeEventFilter{}, err
+	}
+	if filter.BidOrganizationIds, err = permittedOrganizationIds(
+		ctx, s.permissionService, organizationIds, permission.BidView,
+	); err != nil {
+		return repository.ChangeEventFilter{}, err
+	}
+	if filter.ReviewOrganizationIds, err = permittedOrganizationIds(
+		ctx, s.permissionService, organizationIds, permission.ReviewView,
+	); err != nil {
+		return repository.ChangeEventFilter{}, err
+	}
+	return filter, nil
+}
+
+func (s *EventStreamService) LastEventId(ctx context.Context) (int64, error) {
+	return s.changeEventRepo.LastId(ctx)
+}
+
+func (s *EventStreamService) FindAfter(
+	ctx context.Context, filter repository.ChangeEventFilter, afterId int64, limit int,
+) ([]entity.ChangeEvent, error) {
+	return s.changeEventRepo.FindAfter(ctx, filter, afterId, limit)
+}
+
+func (s *EventStreamService) Subscribe() (<-chan struct{}, func()) {
+	return s.notifier.Subscribe()
+}
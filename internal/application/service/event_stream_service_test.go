@@ -0,0 +1,50 @@
+package service
+
+import (
+	"github.com/google/uuid"
+	"reflect"
+	"tenders/internal/domain/entity"
+	"testing"
+)
+
+func TestEventStreamVisibilityFilterByRole(t *testing.T) {
+	tests := []struct {
+		role        entity.ResponsibleRole
+		wantReviews bool
+	}{
+		{entity.RoleOwner, true},
+		{entity.RoleTenderManager, true},
+		{entity.RoleEvaluator, true},
+		{entity.RoleViewer, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.role), func(t *testing.T) {
+			organizationRepo := newFakeOrganizationRepo()
+			organizationId := uuid.New()
+			employee := testEmployee(organizationRepo, organizationId, tt.role)
+			streamService := NewEventStreamService(
+				organizationRepo, nil, nil, NewPermissionService(organizationRepo, testAdminUsername),
+			)
+
+			filter, err := streamService.VisibilityFilter(withEmployee(employee))
+			if err != nil {
+				t.Fatalf("VisibilityFilter: %v", err)
+			}
+
+			organizationIds := []uuid.UUID{organizationId}
+			if filter.EmployeeId != employee.Id {
+				t.Errorf("EmployeeId = %s, want %s", filter.EmployeeId, employee.Id)
+			}
+			if !reflect.DeepEqual(filter.TenderOrganizationIds, organizationIds) {
+				t.Errorf("TenderOrganizationIds = %v, want %v", filter.TenderOrganizationIds, organizationIds)
+			}
+			if !reflect.DeepEqual(filter.BidOrganizationIds, organizationIds) {
+				t.Errorf("BidOrganizationIds = %v, want %v", filter.BidOrganizationIds, organizationIds)
+			}
+			if got := len(filter.ReviewOrganizationIds) == 1; got != tt.wantReviews {
+				t.Errorf("ReviewOrganizationIds = %v, want visible %v", filter.ReviewOrganizationIds, tt.wantReviews)
+			}
+		})
+	}
+}
@@ -0,0 +1,360 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"sort"
+	"tenders/internal/domain/entity"
+	"tenders/internal/domain/repository"
+	"tenders/internal/utils"
+	"tenders/internal/utils/auth"
+	"tenders/internal/utils/common/custom_types"
+	"tenders/internal/utils/consts"
+	"time"
+)
+
+const testAdminUsername = "admin"
+
+// fakeUnitOfWork выполняет fn без транзакции на тех же репозиториях
+type fakeUnitOfWork struct {
+	repos *repository.TxRepositories
+}
+
+func (u *fakeUnitOfWork) WithinTx(_ context.Context, fn func(repos *repository.TxRepositories) error) error {
+	return fn(u.repos)
+}
+
+// fakeEmployeeRepo хранит сотрудников в памяти. Методы, которые тесту не нужны,
+// не реализованы и паникуют через встроенный nil-интерфейс
+type fakeEmployeeRepo struct {
+	repository.EmployeeRepository
+	employees map[uuid.UUID]*entity.Employee
+}
+
+func (r *fakeEmployeeRepo) FindById(_ context.Context, id uuid.UUID) (*entity.Employee, error) {
+	employee, ok := r.employees[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	found := *employee
+	return &found, nil
+}
+
+func (r *fakeEmployeeRepo) Update(_ context.Context, employee *entity.Employee) error {
+	updated := *employee
+	r.employees[employee.Id] = &updated
+	return nil
+}
+
+// fakeOrganizationRepo хранит роли ответственных в памяти, деактивированные сотрудники из employees
+// не считаются в CountResponsibles, как и в базе
+type fakeOrganizationRepo struct {
+	repository.OrganizationRepository
+	roles     map[uuid.UUID]map[uuid.UUID]entity.ResponsibleRole
+	employees *fakeEmployeeRepo
+}
+
+func newFakeOrganizationRepo() *fakeOrganizationRepo {
+	return &fakeOrganizationRepo{
+		roles:     map[uuid.UUID]map[uuid.UUID]entity.ResponsibleRole{},
+		employees: &fakeEmployeeRepo{employees: map[uuid.UUID]*entity.Employee{}},
+	}
+}
+
+// txRepositories репозитории для fakeUnitOfWork, остальные заполняет тест
+func (r *fakeOrganizationRepo) txRepositories() *repository.TxRepositories {
+	return &repository.TxRepositories{Organization: r, Employee: r.employees}
+}
+
+func (r *fakeOrganizationRepo) assign(organizationId, employeeId uuid.UUID, role entity.ResponsibleRole) {
+	if r.roles[organizationId] == nil {
+		r.roles[organizationId] = map[uuid.UUID]entity.ResponsibleRole{}
+	}
+	r.roles[organizationId][employeeId] = role
+}
+
+func (r *fakeOrganizationRepo) FindAllByEmployeeId(
+	_ context.Context, employeeId uuid.UUID,
+) ([]entity.Organization, error) {
+	organizations := []entity.Organization{}
+	for organizationId, responsibles := range r.roles {
+		if _, ok := responsibles[employeeId]; ok {
+			organizations = append(organizations, entity.Organization{Id: organizationId, IsActive: true})
+		}
+	}
+	sort.Slice(organizations, func(i, j int) bool {
+		return organizations[i].Id.String() < organizations[j].Id.String()
+	})
+	return organizations, nil
+}
+
+func (r *fakeOrganizationRepo) FindByIdForUpdate(_ context.Context, id uuid.UUID) (*entity.Organization, error) {
+	if _, ok := r.roles[id]; !ok {
+		return nil, sql.ErrNoRows
+	}
+	return &entity.Organization{Id: id, IsActive: true}, nil
+}
+
+func (r *fakeOrganizationRepo) CountResponsibles(
+	_ context.Context, organizationId uuid.UUID, roles []entity.ResponsibleRole,
+) (int, error) {
+	count := 0
+	for employeeId, role := range r.roles[organizationId] {
+		if employee, ok := r.employees.employees[employeeId]; ok && !employee.IsActive {
+			continue
+		}
+		if hasResponsibleRole(roles, role) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *fakeOrganizationRepo) RemoveResponsible(_ context.Context, organizationId, employeeId uuid.UUID) error {
+	if _, ok := r.roles[organizationId][employeeId]; !ok {
+		return sql.ErrNoRows
+	}
+	delete(r.roles[organizationId], employeeId)
+	return nil
+}
+
+func (r *fakeOrganizationRepo) FindRole(
+	_ context.Context, organizationId, employeeId uuid.UUID,
+) (entity.ResponsibleRole, error) {
+	role, ok := r.roles[organizationId][employeeId]
+	if !ok {
+		return "", sql.ErrNoRows
+	}
+	return role, nil
+}
+
+func (r *fakeOrganizationRepo) HasRole(
+	_ context.Context, employeeId uuid.UUID, roles []entity.ResponsibleRole,
+) (bool, error) {
+	for _, responsibles := range r.roles {
+		if role, ok := responsibles[employeeId]; ok && hasResponsibleRole(roles, role) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func hasResponsibleRole(roles []entity.ResponsibleRole, role entity.ResponsibleRole) bool {
+	for _, candidate := range roles {
+		if candidate == role {
+			return true
+		}
+	}
+	return false
+}
+
+// testEmployee сотрудник с ролью role в организации organizationId
+func testEmployee(
+	organizationRepo *fakeOrganizationRepo, organizationId uuid.UUID, role entity.ResponsibleRole,
+) *entity.Employee {
+	employee := &entity.Employee{Id: uuid.New(), Username: "user-" + string(role), IsActive: true}
+	organizationRepo.employees.employees[employee.Id] = employee
+	organizationRepo.assign(organizationId, employee.Id, role)
+	return employee
+}
+
+func withEmployee(employee *entity.Employee) context.Context {
+	return auth.WithEmployee(context.Background(), employee)
+}
+
+// fakeTenderRepo хранит текущие версии тендеров и их историю. Create, как и в базе, заменяет текущую
+// версию только следующей по номеру, иначе возвращает VersionConflictError
+type fakeTenderRepo struct {
+	repository.TenderRepository
+	tenders map[uuid.UUID]*entity.Tender
+	history []entity.Tender
+}
+
+func newFakeTenderRepo(tenders ...*entity.Tender) *fakeTenderRepo {
+	r := &fakeTenderRepo{tenders: map[uuid.UUID]*entity.Tender{}}
+	for _, tender := range tenders {
+		stored := *tender
+		r.tenders[tender.TenderId] = &stored
+		r.history = append(r.history, stored)
+	}
+	return r
+}
+
+func (r *fakeTenderRepo) Create(_ context.Context, tender *entity.Tender) (*entity.Tender, error) {
+	if current, ok := r.tenders[tender.TenderId]; ok && current.Version != tender.Version-1 {
+		return nil, utils.VersionConflictError
+	}
+	stored := *tender
+	r.tenders[tender.TenderId] = &stored
+	r.history = append(r.history, stored)
+	return tender, nil
+}
+
+func (r *fakeTenderRepo) FindByTenderId(_ context.Context, id uuid.UUID) (*entity.Tender, error) {
+	tender, ok := r.tenders[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	found := *tender
+	return &found, nil
+}
+
+func (r *fakeTenderRepo) FindByTenderIdAndVersion(
+	_ context.Context, tenderId uuid.UUID, version int,
+) (*entity.Tender, error) {
+	for _, tender := range r.history {
+		if tender.TenderId == tenderId && tender.Version == version {
+			return &tender, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (r *fakeTenderRepo) FindLatestVersionByTenderId(_ context.Context, tenderId uuid.UUID) (int, error) {
+	tender, ok := r.tenders[tenderId]
+	if !ok {
+		return 0, sql.ErrNoRows
+	}
+	return tender.Version, nil
+}
+
+func (r *fakeTenderRepo) FindAllExpiredPublished(_ context.Context, now time.Time, limit int) ([]entity.Tender, error) {
+	expired := []entity.Tender{}
+	for _, tender := range r.tenders {
+		deadline := tender.ClosingDeadline()
+		if tender.Status == consts.TenderPublished && deadline != nil && !now.Before(deadline.ConvertToTime()) {
+			expired = append(expired, *tender)
+		}
+	}
+	if len(expired) > limit {
+		expired = expired[:limit]
+	}
+	return expired, nil
+}
+
+// fakeBidRepo хранит предложения и их историю. Update, как и в базе, обновляет предложение, только если
+// его версия равна previousVersion. beforeUpdate позволяет изменить предложение между чтением и записью
+type fakeBidRepo struct {
+	repository.BidRepository
+	bids         map[uuid.UUID]*entity.Bid
+	history      []entity.Bid
+	beforeUpdate func()
+}
+
+func newFakeBidRepo(bids ...*entity.Bid) *fakeBidRepo {
+	r := &fakeBidRepo{bids: map[uuid.UUID]*entity.Bid{}}
+	for _, bid := range bids {
+		stored := *bid
+		r.bids[bid.BidId] = &stored
+	}
+	return r
+}
+
+func (r *fakeBidRepo) Create(_ context.Context, bid *entity.Bid) (*entity.Bid, error) {
+	stored := *bid
+	r.bids[bid.BidId] = &stored
+	return bid, nil
+}
+
+func (r *fakeBidRepo) FindByBidId(_ context.Context, bidId uuid.UUID) (*entity.Bid, error) {
+	bid, ok := r.bids[bidId]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	found := *bid
+	return &found, nil
+}
+
+func (r *fakeBidRepo) LockByBidId(ctx context.Context, bidId uuid.UUID) (*entity.Bid, error) {
+	return r.FindByBidId(ctx, bidId)
+}
+
+func (r *fakeBidRepo) SaveHistoricalVersion(_ context.Context, bid *entity.Bid) error {
+	r.history = append(r.history, *bid)
+	return nil
+}
+
+func (r *fakeBidRepo) Update(_ context.Context, bid *entity.Bid, previousVersion int) error {
+	if r.beforeUpdate != nil {
+		r.beforeUpdate()
+	}
+	current, ok := r.bids[bid.BidId]
+	if !ok || current.Version != previousVersion {
+		return utils.VersionConflictError
+	}
+	stored := *bid
+	r.bids[bid.BidId] = &stored
+	return nil
+}
+
+func (r *fakeBidRepo) FindVersionInHistory(_ context.Context, bidId uuid.UUID, version int) (*entity.Bid, error) {
+	for _, bid := range r.history {
+		if bid.BidId == bidId && bid.Version == version {
+			return &bid, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+// fakeBidDecisionRepo хранит последнее решение каждого сотрудника, как upsert в базе
+type fakeBidDecisionRepo struct {
+	repository.BidDecisionRepository
+	decisions map[uuid.UUID]map[uuid.UUID]string
+}
+
+func (r *fakeBidDecisionRepo) Save(_ context.Context, decision *entity.BidDecision) error {
+	if r.decisions == nil {
+		r.decisions = map[uuid.UUID]map[uuid.UUID]string{}
+	}
+	if r.decisions[decision.BidId] == nil {
+		r.decisions[decision.BidId] = map[uuid.UUID]string{}
+	}
+	r.decisions[decision.BidId][decision.EmployeeId] = decision.Decision
+	return nil
+}
+
+func (r *fakeBidDecisionRepo) CountByBidIdAndDecision(
+	_ context.Context, bidId uuid.UUID, decision string,
+) (int, error) {
+	count := 0
+	for _, saved := range r.decisions[bidId] {
+		if saved == decision {
+			count++
+		}
+	}
+	return count, nil
+}
+
+type fakeOutboxRepo struct {
+	saved []entity.OutboxEvent
+}
+
+func (r *fakeOutboxRepo) Save(_ context.Context, event *entity.OutboxEvent) error {
+	r.saved = append(r.saved, *event)
+	return nil
+}
+
+func (r *fakeOutboxRepo) eventTypes() []string {
+	eventTypes := []string{}
+	for _, event := range r.saved {
+		eventTypes = append(eventTypes, event.EventType)
+	}
+	return eventTypes
+}
+
+type fakeChangeEventRepo struct {
+	repository.ChangeEventRepository
+	saved []entity.ChangeEvent
+}
+
+func (r *fakeChangeEventRepo) Save(_ context.Context, event *entity.ChangeEvent) error {
+	r.saved = append(r.saved, *event)
+	return nil
+}
+
+// deadline срок, смещенный от текущего времени на offset
+func deadline(offset time.Duration) *custom_types.RFC3339Time {
+	value := custom_types.RFC3339Time(time.Now().Add(offset))
+	return &value
+}
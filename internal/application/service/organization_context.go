@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"tenders/internal/application/interfaces"
+	"tenders/internal/domain/permission"
+	"tenders/internal/domain/repository"
+	"tenders/internal/utils"
+	"tenders/internal/utils/auth"
+)
+
+// employeeOrganizationIds возвращает действующие организации, за которые отвечает сотрудник.
+// Если в запросе выбрана организация, остается только она, а чужая организация запрещена
+func employeeOrganizationIds(
+	ctx context.Context, organizationRepo repository.OrganizationRepository, employeeId uuid.UUID,
+) ([]uuid.UUID, error) {
+	organizations, err := organizationRepo.FindAllByEmployeeId(ctx, employeeId)
+	if err != nil {
+		return nil, err
+	}
+
+	selected, chosen := auth.OrganizationFromContext(ctx)
+	organizationIds := make([]uuid.UUID, 0, len(organizations))
+	for _, organization := range organizations {
+		if !chosen || organization.Id == selected {
+			organizationIds = append(organizationIds, organization.Id)
+		}
+	}
+
+	if chosen && len(organizationIds) == 0 {
+		return nil, utils.UnauthorizedAccessError
+	}
+	return organizationIds, nil
+}
+
+// currentOrganizationId возвращает организацию, от имени которой действует сотрудник: выбранную в запросе
+// или единственную, за которую он отвечает. Если организаций несколько, выбор обязателен
+func currentOrganizationId(
+	ctx context.Context, organizationRepo repository.OrganizationRepository, employeeId uuid.UUID,
+) (uuid.UUID, error) {
+	organizationIds, err := employeeOrganizationIds(ctx, organizationRepo, employeeId)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	switch len(organizationIds) {
+	case 0:
+		return uuid.Nil, utils.UnauthorizedAccessError
+	case 1:
+		return organizationIds[0], nil
+	default:
+		return uuid.Nil, utils.OrganizationRequiredError
+	}
+}
+
+// permittedOrganizationIds оставляет из organizationIds организации, в которых роль текущего пользователя
+// дает разрешение required
+func permittedOrganizationIds(
+	ctx context.Context, permissionService interfaces.PermissionService,
+	organizationIds []uuid.UUID, required permission.Permission,
+) ([]uuid.UUID, error) {
+	permitted := make([]uuid.UUID, 0, len(organizationIds))
+	for _, organizationId := range organizationIds {
+		granted, err := permissionService.Has(ctx, organizationId, required)
+		if err != nil {
+			return nil, err
+		}
+		if granted {
+			permitted = append(permitted, organizationId)
+		}
+	}
+	return permitted, nil
+}
@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"tenders/internal/application/interfaces"
+	"tenders/internal/domain/entity"
+	"tenders/internal/domain/permission"
+	"tenders/internal/domain/repository"
+	"tenders/internal/interfaces/dto/request"
+	"tenders/internal/utils"
+	"tenders/internal/utils/auth"
+	"time"
+)
+
+type OrganizationService struct {
+	organizationRepo  repository.OrganizationRepository
+	employeeRepo      repository.EmployeeRepository
+	permissionService interfaces.PermissionService
+	unitOfWork        repository.UnitOfWork
+}
+
+func NewOrganizationService(
+	organizationRepo repository.OrganizationRepository,
+	employeeRepo repository.EmployeeRepository,
+	permissionService interfaces.PermissionService,
+	unitOfWork repository.UnitOfWork,
+) interfaces.OrganizationService {
+	return &OrganizationService{
+		organizationRepo:  organizationRepo,
+		employeeRepo:      employeeRepo,
+		permissionService: permissionService,
+		unitOfWork:        unitOfWork,
+	}
+}
+
+// Create регистрирует организацию, создатель становится ее владельцем
+func (s *OrganizationService) Create(
+	ctx context.Context, organizationRequest *request.OrganizationRequest,
+) (*entity.Organization, error) {
+	requester, err := auth.CurrentEmployee(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	organization, err := organizationRequest.MapToOrganization()
+	if err != nil {
+		return nil, err
+	}
+
+	organization.Id = uuid.New()
+	organization.CreatedAt = time.Now()
+	organization.UpdatedAt = organization.CreatedAt
+
+	err = s.unitOfWork.WithinTx(ctx, func(repos *repository.TxRepositories) error {
+		if err := repos.Organization.Create(ctx, organization); err != nil {
+			return err
+		}
+		return repos.Organization.AddResponsible(ctx, organization.Id, requester.Id, entity.RoleOwner)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return organization, nil
+}
+
+func (s *OrganizationService) Edit(
+	ctx context.Context, organizationId uuid.UUID, editRequest *request.EditOrganizationRequest,
+) (*entity.Organization, error) {
+	var organization *entity.Organization
+	err := s.unitOfWork.WithinTx(ctx, func(repos *repository.TxRepositories) error {
+		var err error
+		organization, err = s.lockActiveOrganization(ctx, repos, organizationId, permission.OrganizationManage)
+		if err != nil {
+			return err
+		}
+
+		if err = editRequest.UpdateOrganization(organization); err != nil {
+			return err
+		}
+
+		organization.UpdatedAt = time.Now()
+		return repos.Organization.Update(ctx, organization)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return organization, nil
+}
+
+// Deactivate снимает с ответственных право действовать от имени организации, роли в ней больше ничего не разрешают
+func (s *OrganizationService) Deactivate(
+	ctx context.Context, organizationId uuid.UUID,
+) (*entity.Organization, error) {
+	var organization *entity.Organization
+	err := s.unitOfWork.WithinTx(ctx, func(repos *repository.TxRepositories) error {
+		var err error
+		organization, err = s.lockActiveOrganization(ctx, repos, organizationId, permission.OrganizationManage)
+		if err != nil {
+			return err
+		}
+
+		organization.IsActive = false
+		organization.UpdatedAt = time.Now()
+		return repos.Organization.Update(ctx, organization)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return organization, nil
+}
+
+func (s *OrganizationService) FindResponsibles(
+	ctx context.Context, organizationId uuid.UUID,
+) ([]entity.Responsible, error) {
+	if _, err := s.organizationRepo.FindById(ctx, organizationId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.OrganizationNotExistsError
+		}
+		return nil, err
+	}
+
+	if _, err := s.permissionService.Require(ctx, organizationId, permission.OrganizationView); err != nil {
+		return nil, err
+	}
+
+	return s.employeeRepo.FindAllByOrganizationId(ctx, organizationId)
+}
+
+// AddResponsible назначает действующего сотрудника ответственным с ролью или меняет роль уже назначенного
+// и возвращает новый список ответственных
+func (s *OrganizationService) AddResponsible(
+	ctx context.Context, organizationId, employeeId uuid.UUID, role entity.ResponsibleRole,
+) ([]entity.Responsible, error) {
+	var responsibles []entity.Responsible
+	err := s.unitOfWork.WithinTx(ctx, func(repos *repository.TxRepositories) error {
+		_, err := s.lockActiveOrganization(ctx, repos, organizationId, permission.OrganizationManage)
+		if err != nil {
+			return err
+		}
+
+		employee, err := repos.Employee.FindById(ctx, employeeId)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return utils.EmployeeNotExistsError
+			}
+			return err
+		}
+		if !employee.IsActive {
+			return utils.EmployeeInactiveError
+		}
+
+		currentRole, err := repos.Organization.FindRole(ctx, organizationId, employeeId)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		if currentRole != role {
+			if err = checkLastOwner(ctx, repos, organizationId, employeeId, currentRole); err != nil {
+				return err
+			}
+		}
+
+		if err = repos.Organization.AddResponsible(ctx, organizationId, employeeId, role); err != nil {
+			return err
+		}
+
+		responsibles, err = repos.Employee.FindAllByOrganizationId(ctx, organizationId)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return responsibles, nil
+}
+
+// RemoveResponsible снимает сотрудника с ответственных, у организации должен остаться хотя бы один владелец
+func (s *OrganizationService) RemoveResponsible(ctx context.Context, organizationId, employeeId uuid.UUID) error {
+	return s.unitOfWork.WithinTx(ctx, func(repos *repository.TxRepositories) error {
+		_, err := s.lockActiveOrganization(ctx, repos, organizationId, permission.OrganizationManage)
+		if err != nil {
+			return err
+		}
+
+		role, err := repos.Organization.FindRole(ctx, organizationId, employeeId)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return utils.ResponsibleNotExistsError
+			}
+			return err
+		}
+
+		if err = checkLastOwner(ctx, repos, organizationId, employeeId, role); err != nil {
+			return err
+		}
+
+		return repos.Organization.RemoveResponsible(ctx, organizationId, employeeId)
+	})
+}
+
+// lockActiveOrganization блокирует организацию до конца транзакции, чтобы изменения ответственных не пересекались,
+// и проверяет разрешение текущего пользователя. Деактивированную организацию изменить нельзя
+func (s *OrganizationService) lockActiveOrganization(
+	ctx context.Context, repos *repository.TxRepositories, organizationId uuid.UUID, required permission.Permission,
+) (*entity.Organization, error) {
+	organization, err := repos.Organization.FindByIdForUpdate(ctx, organizationId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.OrganizationNotExistsError
+		}
+		return nil, err
+	}
+
+	if !organization.IsActive {
+		return nil, utils.OrganizationInactiveError
+	}
+
+	if _, err = s.permissionService.Require(ctx, organizationId, required); err != nil {
+		return nil, err
+	}
+	return organization, nil
+}
+
+// checkLastOwner не дает оставить организацию без действующего владельца, когда сотрудник employeeId с ролью role
+// теряет ее. Деактивированные владельцы не считаются, поэтому снять деактивированного владельца можно всегда
+func checkLastOwner(
+	ctx context.Context, repos *repository.TxRepositories,
+	organizationId, employeeId uuid.UUID, role entity.ResponsibleRole,
+) error {
+	if role != entity.RoleOwner {
+		return nil
+	}
+
+	employee, err := repos.Employee.FindById(ctx, employeeId)
+	if err != nil {
+		return err
+	}
+	if !employee.IsActive {
+		return nil
+	}
+
+	// CountResponsibles считает только действующих сотрудников, среди них есть и employeeId
+	owners, err := repos.Organization.CountResponsibles(ctx, organizationId, []entity.ResponsibleRole{entity.RoleOwner})
+	if err != nil {
+		return err
+	}
+	if owners <= 1 {
+		return utils.LastOwnerError
+	}
+	return nil
+}
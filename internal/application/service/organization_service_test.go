@@ -0,0 +1,62 @@
+package service
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"tenders/internal/domain/entity"
+	"tenders/internal/utils"
+	"testing"
+)
+
+func TestOrganizationServiceRemoveResponsibleKeepsActiveOwner(t *testing.T) {
+	tests := []struct {
+		name         string
+		targetRole   entity.ResponsibleRole
+		targetActive bool
+		otherOwners  []bool // действует ли каждый из остальных владельцев
+		wantErr      error
+	}{
+		{name: "one of two owners", targetRole: entity.RoleOwner, targetActive: true, otherOwners: []bool{true}},
+		{name: "last owner", targetRole: entity.RoleOwner, targetActive: true, wantErr: utils.LastOwnerError},
+		{
+			name: "last active owner", targetRole: entity.RoleOwner, targetActive: true,
+			otherOwners: []bool{false}, wantErr: utils.LastOwnerError,
+		},
+		{name: "inactive owner", targetRole: entity.RoleOwner, targetActive: false, otherOwners: []bool{true}},
+		{name: "viewer", targetRole: entity.RoleViewer, targetActive: true, otherOwners: []bool{true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			organizationRepo := newFakeOrganizationRepo()
+			organizationId := uuid.New()
+			target := testEmployee(organizationRepo, organizationId, tt.targetRole)
+			target.IsActive = tt.targetActive
+			for _, active := range tt.otherOwners {
+				testEmployee(organizationRepo, organizationId, entity.RoleOwner).IsActive = active
+			}
+			organizationService := NewOrganizationService(
+				organizationRepo, organizationRepo.employees,
+				NewPermissionService(organizationRepo, testAdminUsername),
+				&fakeUnitOfWork{repos: organizationRepo.txRepositories()},
+			)
+
+			err := organizationService.RemoveResponsible(withEmployee(testAdmin()), organizationId, target.Id)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("RemoveResponsible err = %v, want %v", err, tt.wantErr)
+				}
+				if _, ok := organizationRepo.roles[organizationId][target.Id]; !ok {
+					t.Errorf("responsible was removed")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RemoveResponsible: %v", err)
+			}
+			if _, ok := organizationRepo.roles[organizationId][target.Id]; ok {
+				t.Errorf("responsible was not removed")
+			}
+		})
+	}
+}
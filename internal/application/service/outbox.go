@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"tenders/internal/domain/entity"
+	"tenders/internal/domain/repository"
+	"tenders/internal/utils/common/custom_types"
+	"tenders/internal/utils/consts"
+	"time"
+)
+
+// TenderEventPayload данные событий tender.published и tender.closed
+type TenderEventPayload struct {
+	TenderId uuid.UUID `json:"tenderId"`
+	Name     string    `json:"name"`
+	Status   string    `json:"status"`
+	Version  int       `json:"version"`
+}
+
+// BidEventPayload данные событий bid.submitted и bid.decision, Decision заполняется только для решений
+type BidEventPayload struct {
+	BidId    uuid.UUID `json:"bidId"`
+	TenderId uuid.UUID `json:"tenderId"`
+	Name     string    `json:"name"`
+	Status   string    `json:"status"`
+	Version  int       `json:"version"`
+	Decision string    `json:"decision,omitempty"`
+}
+
+// enqueueOutbox сохраняет событие для вебхуков организации, вызывается в транзакции изменения,
+// чтобы событие не терялось и не отправлялось при откате
+func enqueueOutbox(
+	ctx context.Context, outboxRepo repository.OutboxRepository, organizationId uuid.UUID, eventType string, data any,
+) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	return outboxRepo.Save(ctx, &entity.OutboxEvent{
+		Id:             uuid.New(),
+		OrganizationId: organizationId,
+		EventType:      eventType,
+		Payload:        payload,
+		CreatedAt:      custom_types.RFC3339Time(time.Now()),
+	})
+}
+
+// enqueueTenderStatusEvent добавляет событие, если tender был опубликован или закрыт
+func enqueueTenderStatusEvent(ctx context.Context, outboxRepo repository.OutboxRepository, tender *entity.Tender) error {
+	var eventType string
+	switch tender.Status {
+	case consts.TenderPublished:
+		eventType = consts.EventTenderPublished
+	case consts.TenderClosed:
+		eventType = consts.EventTenderClosed
+	default:
+		return nil
+	}
+
+	return enqueueOutbox(ctx, outboxRepo, tender.OrganizationID, eventType, TenderEventPayload{
+		TenderId: tender.TenderId,
+		Name:     tender.Name,
+		Status:   tender.Status,
+		Version:  tender.Version,
+	})
+}
+
+func newBidEventPayload(bid *entity.Bid, decision string) BidEventPayload {
+	return BidEventPayload{
+		BidId:    bid.BidId,
+		TenderId: bid.TenderId,
+		Name:     bid.Name,
+		Status:   bid.Status,
+		Version:  bid.Version,
+		Decision: decision,
+	}
+}
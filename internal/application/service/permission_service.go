@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"tenders/internal/application/interfaces"
+	"tenders/internal/domain/entity"
+	"tenders/internal/domain/permission"
+	"tenders/internal/domain/repository"
+	"tenders/internal/utils"
+	"tenders/internal/utils/auth"
+)
+
+// adminPermissions разрешения администратора в любой организации, тендерами и предложениями он не управляет
+var adminPermissions = map[permission.Permission]bool{
+	permission.OrganizationView:   true,
+	permission.OrganizationManage: true,
+}
+
+type PermissionService struct {
+	organizationRepo repository.OrganizationRepository
+	adminUsername    string
+}
+
+func NewPermissionService(
+	organizationRepo repository.OrganizationRepository, adminUsername string,
+) interfaces.PermissionService {
+	return &PermissionService{
+		organizationRepo: organizationRepo,
+		adminUsername:    adminUsername,
+	}
+}
+
+func (s *PermissionService) Require(
+	ctx context.Context, organizationId uuid.UUID, required permission.Permission,
+) (*entity.Employee, error) {
+	employee, err := auth.CurrentEmployee(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	granted, err := s.granted(ctx, employee, organizationId, required)
+	if err != nil {
+		return nil, err
+	}
+	if !granted {
+		return nil, utils.PermissionDeniedError.WithPermission(string(required))
+	}
+	return employee, nil
+}
+
+func (s *PermissionService) Has(
+	ctx context.Context, organizationId uuid.UUID, required permission.Permission,
+) (bool, error) {
+	employee, authenticated := auth.EmployeeFromContext(ctx)
+	if !authenticated {
+		return false, nil
+	}
+	return s.granted(ctx, employee, organizationId, required)
+}
+
+func (s *PermissionService) RequireAnywhere(
+	ctx context.Context, required permission.Permission,
+) (*entity.Employee, error) {
+	employee, err := auth.CurrentEmployee(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if isAdmin(employee, s.adminUsername) && adminPermissions[required] {
+		return employee, nil
+	}
+
+	granted, err := s.organizationRepo.HasRole(ctx, employee.Id, permission.Roles(required))
+	if err != nil {
+		return nil, err
+	}
+	if !granted {
+		return nil, utils.PermissionDeniedError.WithPermission(string(required))
+	}
+	return employee, nil
+}
+
+func (s *PermissionService) granted(
+	ctx context.Context, employee *entity.Employee, organizationId uuid.UUID, required permission.Permission,
+) (bool, error) {
+	if isAdmin(employee, s.adminUsername) && adminPermissions[required] {
+		return true, nil
+	}
+
+	role, err := s.organizationRepo.FindRole(ctx, organizationId, employee.Id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	return permission.Granted(role, required), nil
+}
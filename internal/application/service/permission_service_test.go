@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"tenders/internal/domain/entity"
+	"tenders/internal/domain/permission"
+	"tenders/internal/utils"
+	"testing"
+)
+
+func TestPermissionServiceRequire(t *testing.T) {
+	organizationRepo := newFakeOrganizationRepo()
+	organizationId, otherOrganizationId := uuid.New(), uuid.New()
+	owner := testEmployee(organizationRepo, organizationId, entity.RoleOwner)
+	evaluator := testEmployee(organizationRepo, organizationId, entity.RoleEvaluator)
+	viewer := testEmployee(organizationRepo, organizationId, entity.RoleViewer)
+	admin := &entity.Employee{Id: uuid.New(), Username: testAdminUsername}
+	denied := utils.PermissionDeniedError
+
+	tests := []struct {
+		name           string
+		ctx            context.Context
+		organizationId uuid.UUID
+		required       permission.Permission
+		wantErr        error
+	}{
+		{"owner manages tenders", withEmployee(owner), organizationId, permission.TenderManage, nil},
+		{"owner in other organization", withEmployee(owner), otherOrganizationId, permission.TenderView, denied},
+		{"evaluator decides", withEmployee(evaluator), organizationId, permission.BidDecide, nil},
+		{"evaluator manages tenders", withEmployee(evaluator), organizationId, permission.TenderManage, denied},
+		{"viewer views bids", withEmployee(viewer), organizationId, permission.BidView, nil},
+		{"viewer views reviews", withEmployee(viewer), organizationId, permission.ReviewView, denied},
+		{"viewer manages webhooks", withEmployee(viewer), organizationId, permission.WebhookManage, denied},
+		{"viewer views audit", withEmployee(viewer), organizationId, permission.AuditView, denied},
+		{"admin manages organization", withEmployee(admin), otherOrganizationId, permission.OrganizationManage, nil},
+		{"admin manages tenders", withEmployee(admin), organizationId, permission.TenderManage, denied},
+		{"anonymous", context.Background(), organizationId, permission.TenderView, utils.UserNotExistsError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			permissionService := NewPermissionService(organizationRepo, testAdminUsername)
+			_, err := permissionService.Require(tt.ctx, tt.organizationId, tt.required)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("Require: %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Require err = %v, want %v", err, tt.wantErr)
+			}
+
+			var appErr *utils.Error
+			if errors.Is(tt.wantErr, utils.PermissionDeniedError) && errors.As(err, &appErr) &&
+				appErr.Permission != string(tt.required) {
+				t.Errorf("Permission = %q, want %q", appErr.Permission, tt.required)
+			}
+		})
+	}
+}
+
+func TestPermissionServiceRequireAnywhere(t *testing.T) {
+	organizationRepo := newFakeOrganizationRepo()
+	owner := testEmployee(organizationRepo, uuid.New(), entity.RoleOwner)
+	viewer := testEmployee(organizationRepo, uuid.New(), entity.RoleViewer)
+
+	tests := []struct {
+		name     string
+		employee *entity.Employee
+		required permission.Permission
+		wantErr  error
+	}{
+		{"owner", owner, permission.OrganizationManage, nil},
+		{"viewer", viewer, permission.OrganizationView, nil},
+		{"viewer without permission", viewer, permission.OrganizationManage, utils.PermissionDeniedError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			permissionService := NewPermissionService(organizationRepo, testAdminUsername)
+			_, err := permissionService.RequireAnywhere(withEmployee(tt.employee), tt.required)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("RequireAnywhere: %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RequireAnywhere err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,90 +1,117 @@
 package service
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"github.com/google/uuid"
 	"tenders/internal/application/interfaces"
 	"tenders/internal/domain/entity"
+	"tenders/internal/domain/permission"
 	"tenders/internal/domain/repository"
 	"tenders/internal/utils"
+	"tenders/internal/utils/auth"
 	"tenders/internal/utils/common/custom_types"
+	"tenders/internal/utils/consts"
 	"time"
 )
 
 type ReviewService struct {
-	bidRepo          repository.BidRepository
-	employeeRepo     repository.EmployeeRepository
-	organizationRepo repository.OrganizationRepository
-	tenderRepo       repository.TenderRepository
-	reviewRepo       repository.ReviewRepository
+	bidRepo           repository.BidRepository
+	employeeRepo      repository.EmployeeRepository
+	tenderRepo        repository.TenderRepository
+	reviewRepo        repository.ReviewRepository
+	permissionService interfaces.PermissionService
+	unitOfWork        repository.UnitOfWork
 }
 
 func NewReviewService(
 	employeeRepo repository.EmployeeRepository,
-	organizationRepo repository.OrganizationRepository,
 	bidRepo repository.BidRepository,
 	tenderRepo repository.TenderRepository,
 	reviewRepo repository.ReviewRepository,
+	permissionService interfaces.PermissionService,
+	unitOfWork repository.UnitOfWork,
 ) interfaces.ReviewService {
 	return &ReviewService{
-		organizationRepo: organizationRepo,
-		bidRepo:          bidRepo,
-		tenderRepo:       tenderRepo,
-		employeeRepo:     employeeRepo,
-		reviewRepo:       reviewRepo,
+		bidRepo:           bidRepo,
+		tenderRepo:        tenderRepo,
+		employeeRepo:      employeeRepo,
+		reviewRepo:        reviewRepo,
+		permissionService: permissionService,
+		unitOfWork:        unitOfWork,
 	}
 }
 
-func (s *ReviewService) SubmitFeedback(bidId uuid.UUID, username string, feedback string) (*entity.Bid, error) {
-	bid, err := s.bidRepo.FindByBidId(bidId)
+func (s *ReviewService) SubmitFeedback(ctx context.Context, bidId uuid.UUID, feedback string) (*entity.Bid, error) {
+	employee, err := auth.CurrentEmployee(ctx)
 	if err != nil {
-		if errors.Is(err, utils.BidNotExistsError) {
-			return nil, utils.BidNotExistsError
-		}
 		return nil, err
 	}
 
-	employeeId, err := s.employeeRepo.FindEmployeeIdByUsername(username)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, utils.UserNotExistsError
+	var bid *entity.Bid
+	err = s.unitOfWork.WithinTx(ctx, func(repos *repository.TxRepositories) error {
+		bid, err = repos.Bid.FindByBidId(ctx, bidId)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return utils.BidNotExistsError
+			}
+			return err
 		}
-		return nil, err
-	}
 
-	if hasAccess, _ := s.tenderRepo.CheckEmployeeAccessToTender(employeeId, bid.TenderId); !hasAccess {
-		return nil, utils.UnauthorizedAccessError
-	}
+		tender, err := repos.Tender.FindByTenderId(ctx, bid.TenderId)
+		if err != nil {
+			return err
+		}
 
-	review := &entity.Review{
-		Id:          uuid.New(),
-		BidId:       bidId,
-		Description: feedback,
-		CreatedAt:   custom_types.RFC3339Time(time.Now()),
-	}
+		if _, err = s.permissionService.Require(ctx, tender.OrganizationID, permission.ReviewWrite); err != nil {
+			return err
+		}
+
+		review := &entity.Review{
+			Id:          uuid.New(),
+			BidId:       bidId,
+			Description: feedback,
+			CreatedAt:   custom_types.RFC3339Time(time.Now()),
+		}
+
+		if _, err = repos.Review.Create(ctx, review); err != nil {
+			return err
+		}
 
-	if _, err = s.reviewRepo.Create(review); err != nil {
+		err = recordAudit(ctx, repos.Audit, employee, entity.AuditEvent{
+			OrganizationId: tender.OrganizationID,
+			EntityType:     consts.AuditEntityReview,
+			EntityId:       review.Id,
+			Action:         consts.AuditActionFeedback,
+		})
+		if err != nil {
+			return err
+		}
+
+		// Отзыв об авторе предложения виден только ответственным за тендер
+		return publishChange(ctx, repos.ChangeEvent, entity.ChangeEvent{
+			EntityType:     consts.AuditEntityReview,
+			EntityId:       review.Id,
+			Action:         consts.AuditActionFeedback,
+			OrganizationId: tender.OrganizationID,
+		}, ReviewEventPayload{ReviewId: review.Id, BidId: bidId, Description: review.Description})
+	})
+	if err != nil {
 		return nil, err
 	}
+
 	return bid, nil
 }
 
-func (s *ReviewService) specifyEmployeeVerificationError(username string, err error) error {
-	if errors.Is(err, sql.ErrNoRows) {
-		_, userNotFoundErr := s.employeeRepo.FindEmployeeIdByUsername(username)
-		if userNotFoundErr != nil {
-			return utils.UserNotExistsError
-		}
-		return utils.UnauthorizedAccessError
+func (s *ReviewService) FindAllReviewsByBidAuthor(
+	ctx context.Context, tenderId uuid.UUID, authorUsername string, page repository.Page,
+) (*repository.PageResult[entity.Review], error) {
+	if _, err := auth.CurrentEmployee(ctx); err != nil {
+		return nil, err
 	}
-	return err
-}
 
-func (s *ReviewService) FindAllReviewsByBidAuthor(
-	tenderId uuid.UUID, authorUsername string, requesterUsername string, limit, offset int,
-) ([]entity.Review, error) {
-	authorId, err := s.employeeRepo.FindEmployeeIdByUsername(authorUsername)
+	authorId, err := s.employeeRepo.FindEmployeeIdByUsername(ctx, authorUsername)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, utils.UserNotExistsError
@@ -92,7 +119,7 @@ func (s *ReviewService) FindAllReviewsByBidAuthor(
 		return nil, err
 	}
 
-	tender, err := s.tenderRepo.FindByTenderId(tenderId)
+	tender, err := s.tenderRepo.FindByTenderId(ctx, tenderId)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, utils.TenderNotExistsError
@@ -100,7 +127,7 @@ func (s *ReviewService) FindAllReviewsByBidAuthor(
 		return nil, err
 	}
 
-	_, err = s.bidRepo.FindByAuthorAndTender(authorId, tenderId)
+	_, err = s.bidRepo.FindByAuthorAndTender(ctx, authorId, tenderId)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, utils.BidForTenderNotExistsError
@@ -108,16 +135,9 @@ func (s *ReviewService) FindAllReviewsByBidAuthor(
 		return nil, err
 	}
 
-	_, err = s.employeeRepo.FindEmployeeIdByUsernameIfResponsibleForOrg(requesterUsername, tender.OrganizationID)
-	if err != nil {
-		err = s.specifyEmployeeVerificationError(requesterUsername, err)
-		return nil, err
-	}
-
-	reviews, err := s.reviewRepo.FindAllByBidAuthor(authorId, limit, offset)
-	if err != nil {
+	if _, err = s.permissionService.Require(ctx, tender.OrganizationID, permission.ReviewView); err != nil {
 		return nil, err
 	}
 
-	return reviews, nil
+	return s.reviewRepo.FindAllByBidAuthor(ctx, authorId, page)
 }
@@ -1,101 +1,150 @@
 package service
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"github.com/google/uuid"
 	"tenders/internal/application/interfaces"
 	"tenders/internal/domain/entity"
+	"tenders/internal/domain/permission"
 	"tenders/internal/domain/repository"
+	"tenders/internal/domain/statemachine"
 	"tenders/internal/interfaces/dto/request"
 	"tenders/internal/utils"
+	"tenders/internal/utils/auth"
 	"tenders/internal/utils/common/custom_types"
 	"tenders/internal/utils/consts"
+	"tenders/internal/utils/diff"
 	"time"
 )
 
 type TenderService struct {
-	tenderRepo       repository.TenderRepository
-	employeeRepo     repository.EmployeeRepository
-	organizationRepo repository.OrganizationRepository
+	tenderRepo        repository.TenderRepository
+	organizationRepo  repository.OrganizationRepository
+	permissionService interfaces.PermissionService
+	unitOfWork        repository.UnitOfWork
 }
 
 func NewTenderService(
 	tenderRepo repository.TenderRepository,
-	employeeRepo repository.EmployeeRepository,
 	organizationRepo repository.OrganizationRepository,
+	permissionService interfaces.PermissionService,
+	unitOfWork repository.UnitOfWork,
 ) interfaces.TenderService {
 	return &TenderService{
-		tenderRepo:       tenderRepo,
-		employeeRepo:     employeeRepo,
-		organizationRepo: organizationRepo,
+		tenderRepo:        tenderRepo,
+		organizationRepo:  organizationRepo,
+		permissionService: permissionService,
+		unitOfWork:        unitOfWork,
 	}
 }
 
-func (s *TenderService) FindAllPublished(serviceTypes []string, limit, offset int) ([]entity.Tender, error) {
-	return s.tenderRepo.FindAllPublished(serviceTypes, limit, offset)
+func (s *TenderService) FindAllPublished(
+	ctx context.Context, filter repository.TenderFilter, page repository.Page,
+) (*repository.PageResult[entity.Tender], error) {
+	return s.tenderRepo.FindAllPublished(ctx, filter, page)
 }
 
-func (s *TenderService) FindAllAvailableByEmployeeUsername(username string, limit, offset int) ([]entity.Tender, error) {
-	employeeId, err := s.employeeRepo.FindEmployeeIdByUsername(username)
+func (s *TenderService) SearchPublished(
+	ctx context.Context, filter repository.TenderFilter, page repository.Page,
+) (*repository.PageResult[entity.TenderSearchResult], error) {
+	return s.tenderRepo.SearchPublished(ctx, filter, page)
+}
+
+func (s *TenderService) FindAllAvailableByEmployee(
+	ctx context.Context, filter repository.TenderFilter, page repository.Page,
+) (*repository.PageResult[entity.Tender], error) {
+	employee, err := auth.CurrentEmployee(ctx)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, utils.UserNotExistsError
-		}
 		return nil, err
 	}
 
-	organization, err := s.organizationRepo.FindByEmployeeId(employeeId)
+	organizationIds, err := employeeOrganizationIds(ctx, s.organizationRepo, employee.Id)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return []entity.Tender{}, nil
-		}
 		return nil, err
 	}
-
-	return s.tenderRepo.FindAllAvailableByOrganizationId(organization.Id, limit, offset)
-}
-
-func (s *TenderService) specifyEmployeeVerificationError(username string, err error) error {
-	if errors.Is(err, sql.ErrNoRows) {
-		_, userNotFoundErr := s.employeeRepo.FindEmployeeIdByUsername(username)
-		if userNotFoundErr != nil {
-			return utils.UserNotExistsError
-		}
-		return utils.UnauthorizedAccessError
+	if len(organizationIds) == 0 {
+		return repository.EmptyPageResult[entity.Tender](page), nil
 	}
-	return err
+
+	return s.tenderRepo.FindAllAvailableByOrganizationIds(ctx, organizationIds, filter, page)
 }
 
-func (s *TenderService) updateTenderWithVersionIncr(tender *entity.Tender) (*entity.Tender, error) {
+func (s *TenderService) updateTenderWithVersionIncr(
+	ctx context.Context, tenderRepo repository.TenderRepository, actor *entity.Employee, tender *entity.Tender,
+) (*entity.Tender, error) {
 	tender.Version = tender.Version + 1
-	return s.tenderRepo.Create(tender)
+	setVersionAuthor(tender, actor)
+	return tenderRepo.Create(ctx, tender)
 }
 
-func (s *TenderService) updateTenderFromOldVersion(tender *entity.Tender) (*entity.Tender, error) {
-	latestVersion, err := s.tenderRepo.FindLatestVersionByTenderId(tender.TenderId)
+func (s *TenderService) updateTenderFromOldVersion(
+	ctx context.Context, tenderRepo repository.TenderRepository, actor *entity.Employee, tender *entity.Tender,
+	expectedVersion int,
+) (*entity.Tender, error) {
+	latestVersion, err := tenderRepo.FindLatestVersionByTenderId(ctx, tender.TenderId)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, utils.TenderNotExistsError
 		}
 		return nil, err
 	}
+	if err = checkExpectedVersion(expectedVersion, latestVersion); err != nil {
+		return nil, err
+	}
 	tender.Version = latestVersion + 1
-	return s.tenderRepo.Create(tender)
+	setVersionAuthor(tender, actor)
+	return tenderRepo.Create(ctx, tender)
 }
 
-func (s *TenderService) Create(tenderRequest *request.TenderRequest) (*entity.Tender, error) {
+// setVersionAuthor указывает автором и временем новой версии тендера в истории текущее действие, а не предыдущую
+// версию. Версии, созданные системой без сотрудника, остаются без автора. Сохраненный тендер возвращается
+// с автором и временем создания самого тендера, как в FindByTenderId
+func setVersionAuthor(tender *entity.Tender, actor *entity.Employee) {
+	tender.CreatorID = uuid.Nil
+	if actor != nil {
+		tender.CreatorID = actor.Id
+	}
+	tender.CreatedAt = custom_types.RFC3339Time(time.Now())
+}
+
+// recordTenderChange записывает в журнал создание версии tender и публикует его в ленту изменений,
+// предыдущей считается версия на единицу меньше
+func (s *TenderService) recordTenderChange(
+	ctx context.Context, repos *repository.TxRepositories, actor *entity.Employee, tender *entity.Tender, action string,
+) error {
+	err := recordAudit(ctx, repos.Audit, actor, entity.AuditEvent{
+		OrganizationId: tender.OrganizationID,
+		EntityType:     consts.AuditEntityTender,
+		EntityId:       tender.TenderId,
+		Action:         action,
+		VersionBefore:  tender.Version - 1,
+		VersionAfter:   tender.Version,
+	})
+	if err != nil {
+		return err
+	}
+	return publishTenderChange(ctx, repos.ChangeEvent, tender, action)
+}
+
+func (s *TenderService) Create(ctx context.Context, tenderRequest *request.TenderRequest) (*entity.Tender, error) {
 	tender, err := tenderRequest.MapToTender()
 	if err != nil {
 		return nil, err
 	}
 
-	employeeId, err := s.employeeRepo.FindEmployeeIdByUsernameIfResponsibleForOrg(
-		tenderRequest.CreatorUsername,
-		tenderRequest.OrganizationID,
-	)
+	employee, err := auth.CurrentEmployee(ctx)
 	if err != nil {
-		err = s.specifyEmployeeVerificationError(tenderRequest.CreatorUsername, err)
+		return nil, err
+	}
+
+	// creatorUsername оставлен для обратной совместимости и должен совпадать с текущим пользователем
+	if tenderRequest.CreatorUsername != "" && tenderRequest.CreatorUsername != employee.Username {
+		return nil, utils.UnauthorizedAccessError
+	}
+
+	if _, err = s.permissionService.Require(ctx, tenderRequest.OrganizationID, permission.TenderManage); err != nil {
 		return nil, err
 	}
 
@@ -103,69 +152,178 @@ func (s *TenderService) Create(tenderRequest *request.TenderRequest) (*entity.Te
 		tender.TenderId = uuid.New()
 		tender.Version = 1
 		tender.Status = consts.TenderCreated
-		tender.CreatorID = employeeId
+		tender.CreatorID = employee.Id
 		tender.CreatedAt = custom_types.RFC3339Time(time.Now())
 	}
 
-	return s.tenderRepo.Create(tender)
+	var createdTender *entity.Tender
+	err = s.unitOfWork.WithinTx(ctx, func(repos *repository.TxRepositories) error {
+		createdTender, err = repos.Tender.Create(ctx, tender)
+		if err != nil {
+			return err
+		}
+		return s.recordTenderChange(ctx, repos, employee, createdTender, consts.AuditActionCreate)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return createdTender, nil
 }
 
-func (s *TenderService) GetStatusByTenderId(tenderId uuid.UUID, username string) (string, error) {
-	tender, err := s.tenderRepo.FindByTenderId(tenderId)
+// FindVisibleByTenderId возвращает тендер, если он доступен текущему пользователю:
+// анонимным пользователям доступны только опубликованные тендеры, остальные только с разрешением tender.view
+func (s *TenderService) FindVisibleByTenderId(ctx context.Context, tenderId uuid.UUID) (*entity.Tender, error) {
+	tender, err := s.FindByTenderId(ctx, tenderId)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return "", utils.TenderNotExistsError
-		}
-		return "", err
+		return nil, err
 	}
 
-	if username == "" {
+	if _, authenticated := auth.EmployeeFromContext(ctx); !authenticated {
 		if tender.Status != consts.TenderPublished {
-			return "", utils.UnauthorizedAccessError
+			return nil, utils.UnauthorizedAccessError
 		}
-		return tender.Status, nil
+		return tender, nil
+	}
+
+	if _, err = s.permissionService.Require(ctx, tender.OrganizationID, permission.TenderView); err != nil {
+		return nil, err
 	}
 
-	_, err = s.employeeRepo.FindEmployeeIdByUsernameIfResponsibleForOrg(username, tender.OrganizationID)
+	return tender, nil
+}
+
+// FindDetailsByTenderId возвращает текущую версию тендера с теми же правилами видимости, что и списки тендеров:
+// опубликованный тендер доступен всем, остальные только с разрешением tender.view
+func (s *TenderService) FindDetailsByTenderId(ctx context.Context, tenderId uuid.UUID) (*entity.Tender, error) {
+	tender, err := s.FindByTenderId(ctx, tenderId)
 	if err != nil {
-		err = s.specifyEmployeeVerificationError(username, err)
-		return "", err
+		return nil, err
+	}
+
+	if tender.Status == consts.TenderPublished {
+		return tender, nil
 	}
 
-	return tender.Status, nil
+	if _, err = s.permissionService.Require(ctx, tender.OrganizationID, permission.TenderView); err != nil {
+		return nil, err
+	}
+
+	return tender, nil
 }
 
-func (s *TenderService) VerifyUserResponsibleForOrg(username string, organizationId uuid.UUID) (uuid.UUID, error) {
-	return s.employeeRepo.FindEmployeeIdByUsernameIfResponsibleForOrg(username, organizationId)
+// FindAllVersions возвращает историю версий тендера, она доступна только с разрешением tender.view
+func (s *TenderService) FindAllVersions(
+	ctx context.Context, tenderId uuid.UUID, limit, offset int,
+) ([]entity.TenderVersion, error) {
+	tender, err := s.FindByTenderId(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = s.permissionService.Require(ctx, tender.OrganizationID, permission.TenderView); err != nil {
+		return nil, err
+	}
+
+	return s.tenderRepo.FindAllVersionsByTenderId(ctx, tenderId, limit, offset)
 }
 
-func (s *TenderService) GetTenderVersion(tenderId uuid.UUID, version int) (*entity.Tender, error) {
-	return s.tenderRepo.FindByTenderIdAndVersion(tenderId, version)
+func (s *TenderService) FindVersion(ctx context.Context, tenderId uuid.UUID, version int) (*entity.TenderVersion, error) {
+	tender, err := s.FindByTenderId(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = s.permissionService.Require(ctx, tender.OrganizationID, permission.TenderView); err != nil {
+		return nil, err
+	}
+
+	tenderVersion, err := s.tenderRepo.FindVersionByTenderIdAndVersion(ctx, tenderId, version)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.TenderVersionNotExistsError
+		}
+		return nil, err
+	}
+	return tenderVersion, nil
 }
 
-func (s *TenderService) UpdateStatus(tenderId uuid.UUID, status, username string) (*entity.Tender, error) {
-	tender, err := s.tenderRepo.FindByTenderId(tenderId)
+// DiffVersions возвращает поля тендера, изменившиеся между версиями from и to
+func (s *TenderService) DiffVersions(ctx context.Context, tenderId uuid.UUID, from, to int) ([]diff.FieldChange, error) {
+	tender, err := s.FindByTenderId(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = s.permissionService.Require(ctx, tender.OrganizationID, permission.TenderView); err != nil {
+		return nil, err
+	}
+
+	fromTender, err := s.tenderRepo.FindByTenderIdAndVersion(ctx, tenderId, from)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, utils.TenderNotExistsError
+			return nil, utils.VersionNotExistsError
 		}
 		return nil, err
 	}
 
-	_, err = s.employeeRepo.FindEmployeeIdByUsernameIfResponsibleForOrg(username, tender.OrganizationID)
+	toTender, err := s.tenderRepo.FindByTenderIdAndVersion(ctx, tenderId, to)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			_, userNotFoundErr := s.employeeRepo.FindEmployeeIdByUsername(username)
-			if userNotFoundErr != nil {
-				return nil, utils.UserNotExistsError
-			}
-			return nil, utils.UnauthorizedAccessError
+			return nil, utils.VersionNotExistsError
 		}
 		return nil, err
 	}
 
-	tender.Status = status
-	updatedTender, err := s.updateTenderWithVersionIncr(tender)
+	return diff.Fields(*fromTender, *toTender), nil
+}
+
+func (s *TenderService) UpdateStatus(
+	ctx context.Context, tenderId uuid.UUID, status string, expectedVersion int,
+) (*entity.Tender, error) {
+	employee, err := auth.CurrentEmployee(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedTender *entity.Tender
+	err = s.unitOfWork.WithinTx(ctx, func(repos *repository.TxRepositories) error {
+		tender, err := repos.Tender.FindByTenderId(ctx, tenderId)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return utils.TenderNotExistsError
+			}
+			return err
+		}
+
+		if _, err = s.permissionService.Require(ctx, tender.OrganizationID, permission.TenderManage); err != nil {
+			return err
+		}
+
+		if err = checkExpectedVersion(expectedVersion, tender.Version); err != nil {
+			return err
+		}
+
+		// Повторная установка текущего статуса ничего не меняет и новую версию не создает
+		if tender.Status == status {
+			updatedTender = tender
+			return nil
+		}
+
+		if !statemachine.Tender.Can(tender.Status, status, statemachine.ActorResponsible) {
+			return utils.StatusTransitionError.WithFields("status")
+		}
+
+		tender.Status = status
+		updatedTender, err = s.updateTenderWithVersionIncr(ctx, repos.Tender, employee, tender)
+		if err != nil {
+			return err
+		}
+		if err = s.recordTenderChange(ctx, repos, employee, updatedTender, consts.AuditActionStatusChange); err != nil {
+			return err
+		}
+		return enqueueTenderStatusEvent(ctx, repos.Outbox, updatedTender)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -173,8 +331,8 @@ func (s *TenderService) UpdateStatus(tenderId uuid.UUID, status, username string
 	return updatedTender, nil
 }
 
-func (s *TenderService) FindByTenderId(tenderId uuid.UUID) (*entity.Tender, error) {
-	tender, err := s.tenderRepo.FindByTenderId(tenderId)
+func (s *TenderService) FindByTenderId(ctx context.Context, tenderId uuid.UUID) (*entity.Tender, error) {
+	tender, err := s.tenderRepo.FindByTenderId(ctx, tenderId)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, utils.TenderNotExistsError
@@ -184,40 +342,163 @@ func (s *TenderService) FindByTenderId(tenderId uuid.UUID) (*entity.Tender, erro
 	return tender, nil
 }
 
-func (s *TenderService) EditTender(tenderId uuid.UUID, username string, updateRequest *request.EditTenderRequest) (*entity.Tender, error) {
-	tender, err := s.tenderRepo.FindByTenderId(tenderId)
+func (s *TenderService) EditTender(
+	ctx context.Context, tenderId uuid.UUID, updateRequest *request.EditTenderRequest, expectedVersion int,
+) (*entity.Tender, error) {
+	employee, err := auth.CurrentEmployee(ctx)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, utils.TenderNotExistsError
+		return nil, err
+	}
+
+	var updatedTender *entity.Tender
+	err = s.unitOfWork.WithinTx(ctx, func(repos *repository.TxRepositories) error {
+		tender, err := repos.Tender.FindByTenderId(ctx, tenderId)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return utils.TenderNotExistsError
+			}
+			return err
+		}
+
+		if _, err = s.permissionService.Require(ctx, tender.OrganizationID, permission.TenderManage); err != nil {
+			return err
+		}
+
+		if err = checkExpectedVersion(expectedVersion, tender.Version); err != nil {
+			return err
+		}
+
+		if statemachine.Tender.Final(tender.Status) {
+			return utils.StatusTransitionError
+		}
+
+		if err = updateRequest.UpdateTender(tender); err != nil {
+			return err
+		}
+
+		updatedTender, err = s.updateTenderWithVersionIncr(ctx, repos.Tender, employee, tender)
+		if err != nil {
+			return err
 		}
+		return s.recordTenderChange(ctx, repos, employee, updatedTender, consts.AuditActionEdit)
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	_, err = s.employeeRepo.FindEmployeeIdByUsernameIfResponsibleForOrg(username, tender.OrganizationID)
+	return updatedTender, nil
+}
+
+func (s *TenderService) RollbackTender(
+	ctx context.Context, tenderId uuid.UUID, version int, expectedVersion int,
+) (*entity.Tender, error) {
+	employee, err := auth.CurrentEmployee(ctx)
 	if err != nil {
-		return nil, s.specifyEmployeeVerificationError(username, err)
+		return nil, err
 	}
 
-	if err = updateRequest.UpdateTender(tender); err != nil {
+	var updatedTender *entity.Tender
+	err = s.unitOfWork.WithinTx(ctx, func(repos *repository.TxRepositories) error {
+		tender, err := repos.Tender.FindByTenderIdAndVersion(ctx, tenderId, version)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return utils.TenderVersionNotExistsError
+			}
+			return err
+		}
+
+		if _, err = s.permissionService.Require(ctx, tender.OrganizationID, permission.TenderManage); err != nil {
+			return err
+		}
+
+		// Откат не должен обходить жизненный цикл, например открывать закрытый тендер
+		currentTender, err := repos.Tender.FindByTenderId(ctx, tenderId)
+		if err != nil {
+			return err
+		}
+		if !statemachine.Tender.CanRestore(currentTender.Status, tender.Status, statemachine.ActorResponsible) {
+			return utils.StatusTransitionError
+		}
+
+		updatedTender, err = s.updateTenderFromOldVersion(ctx, repos.Tender, employee, tender, expectedVersion)
+		if err != nil {
+			return err
+		}
+		return s.recordTenderChange(ctx, repos, employee, updatedTender, consts.AuditActionRollback)
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	return s.updateTenderWithVersionIncr(tender)
+	return updatedTender, nil
 }
 
-func (s *TenderService) RollbackTender(tenderId uuid.UUID, version int, username string) (*entity.Tender, error) {
-	tender, err := s.GetTenderVersion(tenderId, version)
+// CloseExpired закрывает опубликованные тендеры, у которых наступил срок закрытия, создавая новую версию
+// так же, как UpdateStatus. Изменение записывается в журнал без сотрудника. Тендер, уже измененный
+// параллельно (например, другим экземпляром приложения), пропускается
+func (s *TenderService) CloseExpired(ctx context.Context, limit int) (int, error) {
+	expired, err := s.tenderRepo.FindAllExpiredPublished(ctx, time.Now(), limit)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, utils.TenderNotExistsError
+		return 0, err
+	}
+
+	closed := 0
+	for _, candidate := range expired {
+		err = s.unitOfWork.WithinTx(ctx, func(repos *repository.TxRepositories) error {
+			tender, err := repos.Tender.FindByTenderId(ctx, candidate.TenderId)
+			if err != nil {
+				return err
+			}
+			if tender.Version != candidate.Version {
+				return utils.VersionConflictError
+			}
+			if !statemachine.Tender.Can(tender.Status, consts.TenderClosed, statemachine.ActorSystem) {
+				return utils.StatusTransitionError
+			}
+
+			tender.Status = consts.TenderClosed
+			if _, err = s.updateTenderWithVersionIncr(ctx, repos.Tender, nil, tender); err != nil {
+				return err
+			}
+			if err = s.recordTenderChange(ctx, repos, nil, tender, consts.AuditActionStatusChange); err != nil {
+				return err
+			}
+			return enqueueTenderStatusEvent(ctx, repos.Outbox, tender)
+		})
+		if errors.Is(err, utils.VersionConflictError) || errors.Is(err, utils.StatusTransitionError) {
+			continue
 		}
-		return nil, err
+		if err != nil {
+			return closed, err
+		}
+		closed++
 	}
 
-	_, err = s.VerifyUserResponsibleForOrg(username, tender.OrganizationID)
+	return closed, nil
+}
+
+// AvailableTransitions возвращает переходы, доступные текущему пользователю. Тендер виден по тем же правилам,
+// что и в FindDetailsByTenderId, переходы доступны только с разрешением tender.manage
+func (s *TenderService) AvailableTransitions(
+	ctx context.Context, tenderId uuid.UUID,
+) (*entity.Tender, []statemachine.Transition, error) {
+	tender, err := s.FindByTenderId(ctx, tenderId)
 	if err != nil {
-		return nil, s.specifyEmployeeVerificationError(username, err)
+		return nil, nil, err
 	}
 
-	return s.updateTenderFromOldVersion(tender)
+	canManage, err := s.permissionService.Has(ctx, tender.OrganizationID, permission.TenderManage)
+	if err != nil {
+		return nil, nil, err
+	}
+	if canManage {
+		return tender, statemachine.Tender.Available(tender.Status, statemachine.ActorResponsible), nil
+	}
+
+	if tender.Status != consts.TenderPublished {
+		if _, err = s.permissionService.Require(ctx, tender.OrganizationID, permission.TenderView); err != nil {
+			return nil, nil, err
+		}
+	}
+	return tender, []statemachine.Transition{}, nil
 }
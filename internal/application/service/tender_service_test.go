@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"tenders/internal/application/interfaces"
+	"tenders/internal/domain/entity"
+	"tenders/internal/interfaces/dto/request"
+	"tenders/internal/utils"
+	"tenders/internal/utils/consts"
+	"testing"
+	"time"
+)
+
+// tenderFixture опубликованный тендер организации, которым управляет manager
+type tenderFixture struct {
+	tenderRepo *fakeTenderRepo
+	outboxRepo *fakeOutboxRepo
+	tenderId   uuid.UUID
+	manager    *entity.Employee
+	service    interfaces.TenderService
+}
+
+func newTenderFixture(tender entity.Tender) *tenderFixture {
+	organizationRepo := newFakeOrganizationRepo()
+	tender.OrganizationID = uuid.New()
+	if tender.TenderId == uuid.Nil {
+		tender.TenderId = uuid.New()
+	}
+
+	f := &tenderFixture{
+		tenderRepo: newFakeTenderRepo(&tender),
+		outboxRepo: &fakeOutboxRepo{},
+		tenderId:   tender.TenderId,
+		manager:    testEmployee(organizationRepo, tender.OrganizationID, entity.RoleTenderManager),
+	}
+
+	repos := organizationRepo.txRepositories()
+	repos.Tender = f.tenderRepo
+	repos.Outbox = f.outboxRepo
+	repos.Audit = &fakeAuditRepo{}
+	repos.ChangeEvent = &fakeChangeEventRepo{}
+
+	f.service = NewTenderService(
+		f.tenderRepo, organizationRepo, NewPermissionService(organizationRepo, testAdminUsername),
+		&fakeUnitOfWork{repos: repos},
+	)
+	return f
+}
+
+func (f *tenderFixture) tender() entity.Tender {
+	return *f.tenderRepo.tenders[f.tenderId]
+}
+
+func TestTenderServiceExpectedVersion(t *testing.T) {
+	operations := []struct {
+		name string
+		run  func(f *tenderFixture, expectedVersion int) (*entity.Tender, error)
+	}{
+		{"edit", func(f *tenderFixture, expectedVersion int) (*entity.Tender, error) {
+			editRequest := &request.EditTenderRequest{Name: "renamed"}
+			return f.service.EditTender(withEmployee(f.manager), f.tenderId, editRequest, expectedVersion)
+		}},
+		{"status", func(f *tenderFixture, expectedVersion int) (*entity.Tender, error) {
+			return f.service.UpdateStatus(withEmployee(f.manager), f.tenderId, consts.TenderClosed, expectedVersion)
+		}},
+		{"rollback", func(f *tenderFixture, expectedVersion int) (*entity.Tender, error) {
+			return f.service.RollbackTender(withEmployee(f.manager), f.tenderId, 1, expectedVersion)
+		}},
+	}
+	cases := []struct {
+		name            string
+		expectedVersion int
+		wantErr         error
+	}{
+		{name: "current", expectedVersion: 2},
+		{name: "without If-Match", expectedVersion: 0},
+		{name: "stale", expectedVersion: 1, wantErr: utils.VersionConflictError},
+		{name: "ahead", expectedVersion: 3, wantErr: utils.VersionConflictError},
+	}
+
+	for _, operation := range operations {
+		for _, tt := range cases {
+			t.Run(operation.name+"/"+tt.name, func(t *testing.T) {
+				f := newTenderFixture(entity.Tender{Name: "tender", Status: consts.TenderPublished, Version: 1})
+				second := f.tender()
+				second.Version = 2
+				if _, err := f.tenderRepo.Create(context.Background(), &second); err != nil {
+					t.Fatal(err)
+				}
+
+				tender, err := operation.run(f, tt.expectedVersion)
+				if tt.wantErr != nil {
+					if !errors.Is(err, tt.wantErr) {
+						t.Fatalf("err = %v, want %v", err, tt.wantErr)
+					}
+					if stored := f.tender(); stored.Version != 2 || len(f.tenderRepo.history) != 2 {
+						history := len(f.tenderRepo.history)
+						t.Errorf("tender changed on conflict: v%d, history %d", stored.Version, history)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				if tender.Version != 3 || f.tender().Version != 3 {
+					t.Errorf("version = %d, stored %d, want 3", tender.Version, f.tender().Version)
+				}
+			})
+		}
+	}
+}
+
+func TestTenderServiceCloseExpired(t *testing.T) {
+	tests := []struct {
+		name       string
+		tender     entity.Tender
+		wantStatus string
+	}{
+		{
+			name:       "decision deadline passed",
+			tender:     entity.Tender{Status: consts.TenderPublished, DecisionDeadline: deadline(-time.Minute)},
+			wantStatus: consts.TenderClosed,
+		},
+		{
+			name:       "submission deadline passed without decision deadline",
+			tender:     entity.Tender{Status: consts.TenderPublished, SubmissionDeadline: deadline(-time.Minute)},
+			wantStatus: consts.TenderClosed,
+		},
+		{
+			name: "submission deadline passed before decision deadline",
+			tender: entity.Tender{
+				Status:             consts.TenderPublished,
+				SubmissionDeadline: deadline(-time.Minute),
+				DecisionDeadline:   deadline(time.Hour),
+			},
+			wantStatus: consts.TenderPublished,
+		},
+		{
+			name:       "not published",
+			tender:     entity.Tender{Status: consts.TenderCreated, DecisionDeadline: deadline(-time.Minute)},
+			wantStatus: consts.TenderCreated,
+		},
+		{
+			name:       "without deadlines",
+			tender:     entity.Tender{Status: consts.TenderPublished},
+			wantStatus: consts.TenderPublished,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.tender.Version = 1
+			f := newTenderFixture(tt.tender)
+
+			closed, err := f.service.CloseExpired(context.Background(), 10)
+			if err != nil {
+				t.Fatalf("CloseExpired: %v", err)
+			}
+
+			tender := f.tender()
+			if tender.Status != tt.wantStatus {
+				t.Fatalf("status = %s, want %s", tender.Status, tt.wantStatus)
+			}
+			if tt.wantStatus != consts.TenderClosed {
+				if closed != 0 || tender.Version != 1 || len(f.outboxRepo.saved) != 0 {
+					t.Errorf("closed = %d, v%d, outbox = %v", closed, tender.Version, f.outboxRepo.eventTypes())
+				}
+				return
+			}
+			if closed != 1 || tender.Version != 2 || tender.CreatorID != uuid.Nil {
+				t.Errorf("closed = %d, v%d by %s, want 1, v2 and no creator", closed, tender.Version, tender.CreatorID)
+			}
+			if got := f.outboxRepo.eventTypes(); len(got) != 1 || got[0] != consts.EventTenderClosed {
+				t.Errorf("outbox = %v, want %s", got, consts.EventTenderClosed)
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package service
+
+import "tenders/internal/utils"
+
+// checkExpectedVersion сравнивает версию из If-Match с текущей, 0 означает, что клиент версию не передал
+func checkExpectedVersion(expectedVersion, currentVersion int) error {
+	if expectedVersion != 0 && expectedVersion != currentVersion {
+		return utils.VersionConflictError
+	}
+	return nil
+}
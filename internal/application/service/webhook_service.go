@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"github.com/google/uuid"
+	"tenders/internal/application/interfaces"
+	"tenders/internal/domain/entity"
+	"tenders/internal/domain/permission"
+	"tenders/internal/domain/repository"
+	"tenders/internal/interfaces/dto/request"
+	"tenders/internal/utils"
+	"tenders/internal/utils/auth"
+	"tenders/internal/utils/common/custom_types"
+	"time"
+)
+
+const webhookSecretBytes = 32
+
+type WebhookService struct {
+	organizationRepo  repository.OrganizationRepository
+	webhookRepo       repository.WebhookSubscriptionRepository
+	permissionService interfaces.PermissionService
+}
+
+func NewWebhookService(
+	organizationRepo repository.OrganizationRepository,
+	webhookRepo repository.WebhookSubscriptionRepository,
+	permissionService interfaces.PermissionService,
+) interfaces.WebhookService {
+	return &WebhookService{
+		organizationRepo:  organizationRepo,
+		webhookRepo:       webhookRepo,
+		permissionService: permissionService,
+	}
+}
+
+// Create регистрирует вебхук для организации текущего пользователя, если секрет не передан, он генерируется
+func (s *WebhookService) Create(
+	ctx context.Context, webhookRequest *request.WebhookRequest,
+) (*entity.WebhookSubscription, error) {
+	subscription, err := webhookRequest.MapToWebhookSubscription()
+	if err != nil {
+		return nil, err
+	}
+
+	organizationId, err := s.currentOrganizationId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if subscription.Secret == "" {
+		if subscription.Secret, err = generateWebhookSecret(); err != nil {
+			return nil, err
+		}
+	}
+
+	subscription.Id = uuid.New()
+	subscription.OrganizationId = organizationId
+	subscription.CreatedAt = custom_types.RFC3339Time(time.Now())
+	subscription.UpdatedAt = subscription.CreatedAt
+
+	if err = s.webhookRepo.Create(ctx, subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+func (s *WebhookService) FindAll(ctx context.Context) ([]entity.WebhookSubscription, error) {
+	organizationId, err := s.currentOrganizationId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.webhookRepo.FindAllByOrganizationId(ctx, organizationId)
+}
+
+func (s *WebhookService) FindById(ctx context.Context, webhookId uuid.UUID) (*entity.WebhookSubscription, error) {
+	organizationId, err := s.currentOrganizationId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.findOwnSubscription(ctx, organizationId, webhookId)
+}
+
+func (s *WebhookService) Edit(
+	ctx context.Context, webhookId uuid.UUID, editRequest *request.EditWebhookRequest,
+) (*entity.WebhookSubscription, error) {
+	organizationId, err := s.currentOrganizationId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription, err := s.findOwnSubscription(ctx, organizationId, webhookId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = editRequest.UpdateWebhookSubscription(subscription); err != nil {
+		return nil, err
+	}
+
+	subscription.UpdatedAt = custom_types.RFC3339Time(time.Now())
+	if err = s.webhookRepo.Update(ctx, subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+func (s *WebhookService) Delete(ctx context.Context, webhookId uuid.UUID) error {
+	organizationId, err := s.currentOrganizationId(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, err = s.findOwnSubscription(ctx, organizationId, webhookId); err != nil {
+		return err
+	}
+
+	return s.webhookRepo.Delete(ctx, webhookId)
+}
+
+// currentOrganizationId возвращает организацию, от имени которой действует текущий пользователь,
+// если его роль в ней разрешает управлять вебхуками
+func (s *WebhookService) currentOrganizationId(ctx context.Context) (uuid.UUID, error) {
+	employee, err := auth.CurrentEmployee(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	organizationId, err := currentOrganizationId(ctx, s.organizationRepo, employee.Id)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if _, err = s.permissionService.Require(ctx, organizationId, permission.WebhookManage); err != nil {
+		return uuid.Nil, err
+	}
+	return organizationId, nil
+}
+
+// findOwnSubscription ищет подписку организации, чужие подписки считаются несуществующими
+func (s *WebhookService) findOwnSubscription(
+	ctx context.Context, organizationId, webhookId uuid.UUID,
+) (*entity.WebhookSubscription, error) {
+	subscription, err := s.webhookRepo.FindById(ctx, webhookId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.WebhookNotExistsError
+		}
+		return nil, err
+	}
+
+	if subscription.OrganizationId != organizationId {
+		return nil, utils.WebhookNotExistsError
+	}
+	return subscription, nil
+}
+
+func generateWebhookSecret() (string, error) {
+	secret := make([]byte, webhookSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"tenders/internal/domain/entity"
+	"tenders/internal/domain/repository"
+	"tenders/internal/interfaces/dto/request"
+	"tenders/internal/utils"
+	"testing"
+)
+
+type fakeWebhookRepo struct {
+	repository.WebhookSubscriptionRepository
+	created []*entity.WebhookSubscription
+}
+
+func (r *fakeWebhookRepo) Create(_ context.Context, subscription *entity.WebhookSubscription) error {
+	r.created = append(r.created, subscription)
+	return nil
+}
+
+func TestWebhookServiceCreateRequiresWebhookManage(t *testing.T) {
+	tests := []struct {
+		role    entity.ResponsibleRole
+		wantErr error
+	}{
+		{entity.RoleOwner, nil},
+		{entity.RoleTenderManager, utils.PermissionDeniedError},
+		{entity.RoleEvaluator, utils.PermissionDeniedError},
+		{entity.RoleViewer, utils.PermissionDeniedError},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.role), func(t *testing.T) {
+			organizationRepo, webhookRepo := newFakeOrganizationRepo(), &fakeWebhookRepo{}
+			organizationId := uuid.New()
+			employee := testEmployee(organizationRepo, organizationId, tt.role)
+			webhookService := NewWebhookService(
+				organizationRepo, webhookRepo, NewPermissionService(organizationRepo, testAdminUsername),
+			)
+
+			subscription, err := webhookService.Create(withEmployee(employee), &request.WebhookRequest{
+				Url: "https://example.com/hook",
+			})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Create err = %v, want %v", err, tt.wantErr)
+				}
+				if len(webhookRepo.created) != 0 {
+					t.Errorf("subscription was saved for %s", tt.role)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Create: %v", err)
+			}
+			if subscription.OrganizationId != organizationId || len(webhookRepo.created) != 1 {
+				t.Errorf("subscription = %+v, want saved for organization %s", subscription, organizationId)
+			}
+		})
+	}
+}
+
+func TestWebhookServiceDeleteRequiresWebhookManage(t *testing.T) {
+	organizationRepo := newFakeOrganizationRepo()
+	viewer := testEmployee(organizationRepo, uuid.New(), entity.RoleViewer)
+	webhookService := NewWebhookService(
+		organizationRepo, &fakeWebhookRepo{}, NewPermissionService(organizationRepo, testAdminUsername),
+	)
+
+	if err := webhookService.Delete(withEmployee(viewer), uuid.New()); !errors.Is(err, utils.PermissionDeniedError) {
+		t.Errorf("Delete err = %v, want %v", err, utils.PermissionDeniedError)
+	}
+}
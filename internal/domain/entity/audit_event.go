@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"tenders/internal/utils/common/custom_types"
+	"tenders/internal/utils/consts"
+)
+
+// AuditEvent запись журнала изменений, нулевая версия означает, что версии до или после изменения нет,
+// пустой ActorId означает изменение, выполненное приложением (например, закрытие тендера по сроку)
+type AuditEvent struct {
+	Id             uuid.UUID                `json:"id"`
+	ActorId        uuid.NullUUID            `json:"actorId"`
+	OrganizationId uuid.UUID                `json:"organizationId"`
+	EntityType     string                   `json:"entityType"`
+	EntityId       uuid.UUID                `json:"entityId"`
+	Action         string                   `json:"action"`
+	VersionBefore  int                      `json:"versionBefore,omitempty"`
+	VersionAfter   int                      `json:"versionAfter,omitempty"`
+	RequestId      string                   `json:"requestId,omitempty"`
+	CreatedAt      custom_types.RFC3339Time `json:"createdAt"`
+}
+
+var ValidAuditEntityTypes = map[string]bool{
+	consts.AuditEntityTender: true,
+	consts.AuditEntityBid:    true,
+	consts.AuditEntityReview: true,
+}
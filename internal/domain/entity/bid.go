@@ -7,20 +7,36 @@ import (
 )
 
 type Bid struct {
-	BidId         uuid.UUID                `json:"id"`
-	Name          string                   `json:"name"`
-	Description   string                   `json:"-"`
-	TenderId      uuid.UUID                `json:"-"`
-	TenderVersion int                      `json:"-"`
-	Status        string                   `json:"status"`
-	AuthorType    string                   `json:"authorType"`
-	AuthorId      uuid.UUID                `json:"authorId"`
-	Version       int                      `json:"version"`
-	CreatedAt     custom_types.RFC3339Time `json:"createdAt"`
+	BidId         uuid.UUID `json:"id"`
+	Name          string    `json:"name" diff:"name"`
+	Description   string    `json:"-" diff:"description"`
+	TenderId      uuid.UUID `json:"-" diff:"tenderId"`
+	TenderVersion int       `json:"-" diff:"tenderVersion"`
+	Status        string    `json:"status" diff:"status"`
+	AuthorType    string    `json:"authorType" diff:"authorType"`
+	AuthorId      uuid.UUID `json:"authorId" diff:"authorId"`
+	// Amount и Currency цена предложения, у предложений, созданных до появления цены, не заданы
+	Amount   *custom_types.Decimal `json:"amount,omitempty" diff:"amount"`
+	Currency string                `json:"currency,omitempty" diff:"currency"`
+	// DeliveryDays срок поставки в днях, необязательный
+	DeliveryDays *int                     `json:"deliveryDays,omitempty" diff:"deliveryDays"`
+	Version      int                      `json:"version"`
+	CreatedAt    custom_types.RFC3339Time `json:"createdAt"`
+}
+
+// PriceStats сводка цен предложений тендера в одной валюте
+type PriceStats struct {
+	Currency string               `json:"currency"`
+	Count    int                  `json:"count"`
+	Min      custom_types.Decimal `json:"min"`
+	Median   custom_types.Decimal `json:"median"`
+	Max      custom_types.Decimal `json:"max"`
 }
 
 var ValidBidStatuses = map[string]bool{
 	consts.BidCreated:   true,
 	consts.BidPublished: true,
 	consts.BidCanceled:  true,
+	consts.BidApproved:  true,
+	consts.BidRejected:  true,
 }
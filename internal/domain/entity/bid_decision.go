@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"tenders/internal/utils/common/custom_types"
+)
+
+type BidDecision struct {
+	Id         uuid.UUID                `json:"id"`
+	BidId      uuid.UUID                `json:"bidId"`
+	EmployeeId uuid.UUID                `json:"employeeId"`
+	Decision   string                   `json:"decision"`
+	CreatedAt  custom_types.RFC3339Time `json:"createdAt"`
+}
+
+// BidDecisionSummary количество решений каждого типа по предложению
+type BidDecisionSummary struct {
+	Approved int `json:"approved"`
+	Rejected int `json:"rejected"`
+}
@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"tenders/internal/utils/common/custom_types"
+)
+
+// ChangeEvent событие ленты изменений, Data снимок сущности после изменения.
+// Поля видимости клиенту не отдаются
+type ChangeEvent struct {
+	Id                   int64                    `json:"id"`
+	EntityType           string                   `json:"entityType"`
+	EntityId             uuid.UUID                `json:"entityId"`
+	Action               string                   `json:"action"`
+	OrganizationId       uuid.UUID                `json:"-"`
+	AuthorEmployeeId     uuid.NullUUID            `json:"-"`
+	AuthorOrganizationId uuid.NullUUID            `json:"-"`
+	Data                 json.RawMessage          `json:"data"`
+	CreatedAt            custom_types.RFC3339Time `json:"createdAt"`
+}
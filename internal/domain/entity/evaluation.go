@@ -0,0 +1,146 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"math"
+	"sort"
+	"tenders/internal/utils/common/custom_types"
+)
+
+// Criterion критерий оценки предложений
+type Criterion string
+
+const (
+	CriterionPrice        Criterion = "price"
+	CriterionDeliveryTime Criterion = "delivery_time"
+	CriterionQuality      Criterion = "quality"
+	CriterionExperience   Criterion = "experience"
+)
+
+var ValidCriteria = map[Criterion]bool{
+	CriterionPrice:        true,
+	CriterionDeliveryTime: true,
+	CriterionQuality:      true,
+	CriterionExperience:   true,
+}
+
+const (
+	MinScore = 0
+	MaxScore = 10
+	// CriteriaWeightTotal сумма весов критериев тендера, веса задаются в процентах
+	CriteriaWeightTotal = 100
+)
+
+// TenderCriterion критерий оценки предложений тендера и его вес
+type TenderCriterion struct {
+	Criterion Criterion `json:"criterion"`
+	Weight    int       `json:"weight"`
+}
+
+// CriterionScore оценка по одному критерию
+type CriterionScore struct {
+	Criterion Criterion `json:"criterion"`
+	Score     int       `json:"score"`
+}
+
+// BidScore оценка предложения сотрудником по одному критерию
+type BidScore struct {
+	BidId            uuid.UUID
+	EmployeeId       uuid.UUID
+	EmployeeUsername string
+	Criterion        Criterion
+	Score            int
+	CreatedAt        custom_types.RFC3339Time
+}
+
+// BidEvaluation оценки предложения одним сотрудником и их взвешенная сумма
+type BidEvaluation struct {
+	EvaluatorId       uuid.UUID        `json:"evaluatorId"`
+	EvaluatorUsername string           `json:"evaluatorUsername"`
+	Scores            []CriterionScore `json:"scores"`
+	Total             float64          `json:"total"`
+}
+
+// BidRanking место предложения в рейтинге тендера. У предложений без оценок нет ни места, ни итога
+type BidRanking struct {
+	Rank        int
+	Bid         Bid
+	Score       *float64
+	Evaluations []BidEvaluation
+}
+
+// WeightedTotal взвешенная сумма оценок по критериям тендера, от MinScore до MaxScore с точностью до сотых
+func WeightedTotal(criteria []TenderCriterion, scores []CriterionScore) float64 {
+	byCriterion := make(map[Criterion]int, len(scores))
+	for _, score := range scores {
+		byCriterion[score.Criterion] = score.Score
+	}
+
+	weighted := 0
+	for _, criterion := range criteria {
+		weighted += criterion.Weight * byCriterion[criterion.Criterion]
+	}
+	return roundScore(float64(weighted) / CriteriaWeightTotal)
+}
+
+// RankBids считает итог каждого предложения как среднее взвешенных сумм его оценщиков и упорядочивает
+// предложения по убыванию итога. Предложения с одинаковым итогом делят место, оцененные идут перед неоцененными
+func RankBids(criteria []TenderCriterion, bids []Bid, scores []BidScore) []BidRanking {
+	evaluations := make(map[uuid.UUID][]BidEvaluation, len(bids))
+	for _, score := range scores {
+		bidEvaluations := evaluations[score.BidId]
+		last := len(bidEvaluations) - 1
+		if last < 0 || bidEvaluations[last].EvaluatorId != score.EmployeeId {
+			bidEvaluations = append(bidEvaluations, BidEvaluation{
+				EvaluatorId:       score.EmployeeId,
+				EvaluatorUsername: score.EmployeeUsername,
+			})
+			last++
+		}
+		bidEvaluations[last].Scores = append(bidEvaluations[last].Scores, CriterionScore{
+			Criterion: score.Criterion,
+			Score:     score.Score,
+		})
+		evaluations[score.BidId] = bidEvaluations
+	}
+
+	rankings := make([]BidRanking, 0, len(bids))
+	for _, bid := range bids {
+		ranking := BidRanking{Bid: bid, Evaluations: evaluations[bid.BidId]}
+		if len(ranking.Evaluations) > 0 {
+			sum := 0.0
+			for i := range ranking.Evaluations {
+				ranking.Evaluations[i].Total = WeightedTotal(criteria, ranking.Evaluations[i].Scores)
+				sum += ranking.Evaluations[i].Total
+			}
+			score := roundScore(sum / float64(len(ranking.Evaluations)))
+			ranking.Score = &score
+		} else {
+			ranking.Evaluations = []BidEvaluation{}
+		}
+		rankings = append(rankings, ranking)
+	}
+
+	sort.SliceStable(rankings, func(i, j int) bool {
+		first, second := rankings[i].Score, rankings[j].Score
+		if first == nil || second == nil {
+			return first != nil && second == nil
+		}
+		return *first > *second
+	})
+
+	for i := range rankings {
+		if rankings[i].Score == nil {
+			break
+		}
+		rankings[i].Rank = i + 1
+		if i > 0 && *rankings[i].Score == *rankings[i-1].Score {
+			rankings[i].Rank = rankings[i-1].Rank
+		}
+	}
+	return rankings
+}
+
+func roundScore(score float64) float64 {
+	return math.Round(score*100) / 100
+}
@@ -0,0 +1,201 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"reflect"
+	"testing"
+)
+
+func TestWeightedTotal(t *testing.T) {
+	priceAndQuality := []TenderCriterion{{CriterionPrice, 60}, {CriterionQuality, 40}}
+
+	tests := []struct {
+		name     string
+		criteria []TenderCriterion
+		scores   []CriterionScore
+		want     float64
+	}{
+		{
+			name:     "maximum",
+			criteria: priceAndQuality,
+			scores:   []CriterionScore{{CriterionPrice, MaxScore}, {CriterionQuality, MaxScore}},
+			want:     MaxScore,
+		},
+		{
+			name:     "minimum",
+			criteria: priceAndQuality,
+			scores:   []CriterionScore{{CriterionPrice, MinScore}, {CriterionQuality, MinScore}},
+			want:     MinScore,
+		},
+		{
+			name:     "weighted",
+			criteria: priceAndQuality,
+			scores:   []CriterionScore{{CriterionPrice, 8}, {CriterionQuality, 5}},
+			want:     6.8,
+		},
+		{
+			name:     "score order does not matter",
+			criteria: priceAndQuality,
+			scores:   []CriterionScore{{CriterionQuality, 5}, {CriterionPrice, 8}},
+			want:     6.8,
+		},
+		{
+			name: "hundredths",
+			criteria: []TenderCriterion{
+				{CriterionPrice, 33}, {CriterionQuality, 33}, {CriterionExperience, 34},
+			},
+			scores: []CriterionScore{{CriterionPrice, 7}, {CriterionQuality, 8}, {CriterionExperience, 9}},
+			want:   8.01,
+		},
+		{
+			name:     "missing score counts as zero",
+			criteria: priceAndQuality,
+			scores:   []CriterionScore{{CriterionPrice, 10}},
+			want:     6,
+		},
+		{
+			name:     "score outside criteria is ignored",
+			criteria: []TenderCriterion{{CriterionPrice, 100}},
+			scores:   []CriterionScore{{CriterionPrice, 4}, {CriterionQuality, 10}},
+			want:     4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WeightedTotal(tt.criteria, tt.scores); got != tt.want {
+				t.Errorf("WeightedTotal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRankBids(t *testing.T) {
+	criteria := []TenderCriterion{{CriterionPrice, 50}, {CriterionQuality, 50}}
+	first, second, third := uuid.New(), uuid.New(), uuid.New()
+	bids := []Bid{{BidId: uuid.New()}, {BidId: uuid.New()}, {BidId: uuid.New()}, {BidId: uuid.New()}}
+
+	// evaluate оценки одного оценщика по обоим критериям в порядке выборки из базы
+	evaluate := func(bid Bid, evaluator uuid.UUID, price, quality int) []BidScore {
+		return []BidScore{
+			{BidId: bid.BidId, EmployeeId: evaluator, Criterion: CriterionPrice, Score: price},
+			{BidId: bid.BidId, EmployeeId: evaluator, Criterion: CriterionQuality, Score: quality},
+		}
+	}
+	join := func(groups ...[]BidScore) []BidScore {
+		var scores []BidScore
+		for _, group := range groups {
+			scores = append(scores, group...)
+		}
+		return scores
+	}
+
+	type place struct {
+		bid         int
+		rank        int
+		score       float64 // -1 для предложения без оценок
+		evaluations int
+	}
+
+	tests := []struct {
+		name   string
+		scores []BidScore
+		want   []place
+	}{
+		{
+			name:   "no scores keeps order",
+			scores: nil,
+			want:   []place{{0, 0, -1, 0}, {1, 0, -1, 0}, {2, 0, -1, 0}, {3, 0, -1, 0}},
+		},
+		{
+			name: "descending score",
+			scores: join(
+				evaluate(bids[0], first, 2, 2), evaluate(bids[1], first, 9, 9),
+				evaluate(bids[2], first, 5, 6), evaluate(bids[3], first, 0, 0),
+			),
+			want: []place{{1, 1, 9, 1}, {2, 2, 5.5, 1}, {0, 3, 2, 1}, {3, 4, 0, 1}},
+		},
+		{
+			name: "ties share a place",
+			scores: join(
+				evaluate(bids[0], first, 6, 8), evaluate(bids[1], first, 8, 6),
+				evaluate(bids[2], first, 3, 3), evaluate(bids[3], first, 9, 9),
+			),
+			want: []place{{3, 1, 9, 1}, {0, 2, 7, 1}, {1, 2, 7, 1}, {2, 4, 3, 1}},
+		},
+		{
+			name:   "scored before unscored",
+			scores: join(evaluate(bids[2], first, 0, 0), evaluate(bids[3], first, 1, 0)),
+			want:   []place{{3, 1, 0.5, 1}, {2, 2, 0, 1}, {0, 0, -1, 0}, {1, 0, -1, 0}},
+		},
+		{
+			name: "average of evaluators",
+			scores: join(
+				evaluate(bids[0], first, 10, 10), evaluate(bids[0], second, 10, 10), evaluate(bids[0], third, 9, 9),
+				evaluate(bids[1], first, 10, 10), evaluate(bids[1], second, 9, 10),
+			),
+			want: []place{{1, 1, 9.75, 2}, {0, 2, 9.67, 3}, {2, 0, -1, 0}, {3, 0, -1, 0}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rankings := RankBids(criteria, bids, tt.scores)
+			got := make([]place, 0, len(rankings))
+			for _, ranking := range rankings {
+				bid := -1
+				for i := range bids {
+					if bids[i].BidId == ranking.Bid.BidId {
+						bid = i
+					}
+				}
+				score := -1.0
+				if ranking.Score != nil {
+					score = *ranking.Score
+				}
+				if ranking.Evaluations == nil {
+					t.Errorf("bid %d: Evaluations is nil, want empty slice", bid)
+				}
+				got = append(got, place{bid, ranking.Rank, score, len(ranking.Evaluations)})
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RankBids() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRankBidsEvaluations(t *testing.T) {
+	criteria := []TenderCriterion{{CriterionPrice, 70}, {CriterionDeliveryTime, 30}}
+	bid, evaluator, other := Bid{BidId: uuid.New()}, uuid.New(), uuid.New()
+	score := func(employeeId uuid.UUID, username string, criterion Criterion, value int) BidScore {
+		return BidScore{
+			BidId: bid.BidId, EmployeeId: employeeId, EmployeeUsername: username, Criterion: criterion, Score: value,
+		}
+	}
+	scores := []BidScore{
+		score(evaluator, "user1", CriterionPrice, 10),
+		score(evaluator, "user1", CriterionDeliveryTime, 5),
+		score(other, "user2", CriterionPrice, 4),
+		score(other, "user2", CriterionDeliveryTime, 4),
+	}
+
+	rankings := RankBids(criteria, []Bid{bid}, scores)
+	want := []BidEvaluation{
+		{
+			EvaluatorId: evaluator, EvaluatorUsername: "user1", Total: 8.5,
+			Scores: []CriterionScore{{CriterionPrice, 10}, {CriterionDeliveryTime, 5}},
+		},
+		{
+			EvaluatorId: other, EvaluatorUsername: "user2", Total: 4,
+			Scores: []CriterionScore{{CriterionPrice, 4}, {CriterionDeliveryTime, 4}},
+		},
+	}
+	if len(rankings) != 1 || !reflect.DeepEqual(rankings[0].Evaluations, want) {
+		t.Fatalf("Evaluations = %+v, want %+v", rankings, want)
+	}
+	if rankings[0].Score == nil || *rankings[0].Score != 6.25 || rankings[0].Rank != 1 {
+		t.Errorf("Score = %v, Rank = %d, want 6.25 and 1", rankings[0].Score, rankings[0].Rank)
+	}
+}
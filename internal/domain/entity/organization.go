@@ -13,11 +13,18 @@ const (
 	JSC OrganizationType = "JSC"
 )
 
+var ValidOrganizationTypes = map[string]bool{
+	string(IE):  true,
+	string(LLC): true,
+	string(JSC): true,
+}
+
 type Organization struct {
 	Id          uuid.UUID        `json:"id"`
 	Name        string           `json:"name"`
 	Description string           `json:"description"`
 	Type        OrganizationType `json:"type"`
+	IsActive    bool             `json:"is_active"`
 	CreatedAt   time.Time        `json:"created_at"`
 	UpdatedAt   time.Time        `json:"updated_at"`
 }
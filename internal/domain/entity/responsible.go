@@ -0,0 +1,23 @@
+package entity
+
+type ResponsibleRole string
+
+const (
+	RoleOwner         ResponsibleRole = "owner"
+	RoleTenderManager ResponsibleRole = "tender_manager"
+	RoleEvaluator     ResponsibleRole = "evaluator"
+	RoleViewer        ResponsibleRole = "viewer"
+)
+
+var ValidResponsibleRoles = map[string]bool{
+	string(RoleOwner):         true,
+	string(RoleTenderManager): true,
+	string(RoleEvaluator):     true,
+	string(RoleViewer):        true,
+}
+
+// Responsible сотрудник, ответственный за организацию, и его роль в ней
+type Responsible struct {
+	Employee
+	Role ResponsibleRole `json:"role"`
+}
@@ -4,21 +4,61 @@ import (
 	"github.com/google/uuid"
 	"tenders/internal/utils/common/custom_types"
 	"tenders/internal/utils/consts"
+	"time"
 )
 
 type Tender struct {
-	Id             uuid.UUID                `json:"-"`
-	TenderId       uuid.UUID                `json:"id"`
-	Name           string                   `json:"name"`
-	Description    string                   `json:"description"`
-	ServiceType    string                   `json:"service_type"`
-	Status         string                   `json:"status"`
-	Version        int                      `json:"version"`
-	OrganizationID uuid.UUID                `json:"-"`
-	CreatorID      uuid.UUID                `json:"-"`
-	CreatedAt      custom_types.RFC3339Time `json:"created_at"`
+	Id             uuid.UUID `json:"-"`
+	TenderId       uuid.UUID `json:"id"`
+	Name           string    `json:"name" diff:"name"`
+	Description    string    `json:"description" diff:"description"`
+	ServiceType    string    `json:"service_type" diff:"service_type"`
+	Status         string    `json:"status" diff:"status"`
+	Version        int       `json:"version"`
+	OrganizationID uuid.UUID `json:"-" diff:"organization_id"`
+	CreatorID      uuid.UUID `json:"-"`
+	// SubmissionDeadline после него предложения не принимаются и не редактируются
+	SubmissionDeadline *custom_types.RFC3339Time `json:"submission_deadline,omitempty" diff:"submission_deadline"`
+	// DecisionDeadline после него решения по предложениям не принимаются, а тендер закрывается автоматически
+	DecisionDeadline *custom_types.RFC3339Time `json:"decision_deadline,omitempty" diff:"decision_deadline"`
+	// Budget потолок цены предложений в валюте Currency. Currency без Budget только задает валюту предложений
+	Budget    *custom_types.Decimal    `json:"budget,omitempty" diff:"budget"`
+	Currency  string                   `json:"currency,omitempty" diff:"currency"`
+	CreatedAt custom_types.RFC3339Time `json:"created_at"`
 } // По хорошему надо было добавить UpdatedAt, но т.к. он нигде не отдается - решил не добавлять
 
+// ClosingDeadline срок, после которого опубликованный тендер закрывается: решения, а если он не задан, прием предложений
+func (t *Tender) ClosingDeadline() *custom_types.RFC3339Time {
+	if t.DecisionDeadline != nil {
+		return t.DecisionDeadline
+	}
+	return t.SubmissionDeadline
+}
+
+// SubmissionExpired true, если срок приема предложений задан и наступил к now
+func (t *Tender) SubmissionExpired(now time.Time) bool {
+	return deadlinePassed(t.SubmissionDeadline, now)
+}
+
+// DecisionExpired true, если срок принятия решений задан и наступил к now
+func (t *Tender) DecisionExpired(now time.Time) bool {
+	return deadlinePassed(t.DecisionDeadline, now)
+}
+
+// AcceptsCurrency true, если валюта тендера не задана или совпадает с currency
+func (t *Tender) AcceptsCurrency(currency string) bool {
+	return t.Currency == "" || t.Currency == currency
+}
+
+// ExceedsBudget true, если бюджет задан и сумма amount в валюте тендера его превышает
+func (t *Tender) ExceedsBudget(amount custom_types.Decimal) bool {
+	return t.Budget != nil && amount.Cmp(*t.Budget) > 0
+}
+
+func deadlinePassed(deadline *custom_types.RFC3339Time, now time.Time) bool {
+	return deadline != nil && !now.Before(deadline.ConvertToTime())
+}
+
 var ValidServiceTypes = map[string]bool{
 	consts.Construction: true,
 	consts.Delivery:     true,
@@ -0,0 +1,14 @@
+package entity
+
+// TenderSearchResult тендер, найденный полнотекстовым поиском, с релевантностью и подсвеченными совпадениями
+type TenderSearchResult struct {
+	Tender
+	Rank      float64         `json:"rank"`
+	Highlight TenderHighlight `json:"highlight"`
+}
+
+// TenderHighlight фрагменты полей, совпадения в которых обрамлены тегами <mark></mark>
+type TenderHighlight struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
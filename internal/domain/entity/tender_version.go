@@ -0,0 +1,7 @@
+package entity
+
+// TenderVersion версия тендера вместе с автором, который ее создал
+type TenderVersion struct {
+	Tender
+	CreatorUsername string `json:"creator_username"`
+}
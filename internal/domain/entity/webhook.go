@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"tenders/internal/utils/common/custom_types"
+	"tenders/internal/utils/consts"
+)
+
+// OutboxEvent событие для внешних систем, Payload уже сериализован в JSON
+type OutboxEvent struct {
+	Id             uuid.UUID
+	OrganizationId uuid.UUID
+	EventType      string
+	Payload        json.RawMessage
+	CreatedAt      custom_types.RFC3339Time
+}
+
+// WebhookSubscription подписка организации на события, пустой EventTypes означает все события
+type WebhookSubscription struct {
+	Id             uuid.UUID                `json:"id"`
+	OrganizationId uuid.UUID                `json:"organizationId"`
+	Url            string                   `json:"url"`
+	Secret         string                   `json:"-"`
+	EventTypes     []string                 `json:"eventTypes"`
+	Active         bool                     `json:"active"`
+	CreatedAt      custom_types.RFC3339Time `json:"createdAt"`
+	UpdatedAt      custom_types.RFC3339Time `json:"updatedAt"`
+}
+
+// WebhookDelivery попытка доставить событие подписчику вместе с данными, нужными для отправки
+type WebhookDelivery struct {
+	Id        uuid.UUID
+	EventId   uuid.UUID
+	EventType string
+	Payload   json.RawMessage
+	Url       string
+	Secret    string
+	Attempts  int
+}
+
+var ValidWebhookEventTypes = map[string]bool{
+	consts.EventTenderPublished: true,
+	consts.EventTenderClosed:    true,
+	consts.EventBidSubmitted:    true,
+	consts.EventBidDecision:     true,
+}
@@ -0,0 +1,66 @@
+package permission
+
+import "tenders/internal/domain/entity"
+
+// Permission действие, которое роль ответственного разрешает выполнять от имени организации
+type Permission string
+
+const (
+	// TenderView просмотр неопубликованных тендеров организации, их версий и изменений
+	TenderView Permission = "tender.view"
+	// TenderManage создание, редактирование, смена статуса и откат тендеров
+	TenderManage Permission = "tender.manage"
+	// BidView просмотр предложений организации и предложений к ее тендерам
+	BidView Permission = "bid.view"
+	// BidSubmit создание, редактирование, смена статуса и откат предложений от имени организации
+	BidSubmit Permission = "bid.submit"
+	// BidDecide решения по предложениям к тендерам организации
+	BidDecide Permission = "bid.decide"
+	// BidEvaluate оценка предложений к тендерам организации по критериям
+	BidEvaluate Permission = "bid.evaluate"
+	// ReviewView просмотр отзывов об авторах предложений
+	ReviewView Permission = "review.view"
+	// ReviewWrite отзывы на предложения к тендерам организации
+	ReviewWrite Permission = "review.write"
+	// OrganizationView просмотр ответственных за организацию
+	OrganizationView Permission = "organization.view"
+	// OrganizationManage изменение и деактивация организации, назначение ответственных и их ролей
+	OrganizationManage Permission = "organization.manage"
+	// WebhookManage регистрация, изменение и удаление вебхуков организации
+	WebhookManage Permission = "webhook.manage"
+	// AuditView просмотр журнала изменений организации
+	AuditView Permission = "audit.view"
+)
+
+var rolePermissions = map[entity.ResponsibleRole][]Permission{
+	entity.RoleOwner: {
+		TenderView, TenderManage, BidView, BidSubmit, BidDecide, BidEvaluate, ReviewView, ReviewWrite,
+		OrganizationView, OrganizationManage, WebhookManage, AuditView,
+	},
+	entity.RoleTenderManager: {TenderView, TenderManage, BidView, BidSubmit, ReviewView, OrganizationView, AuditView},
+	entity.RoleEvaluator:     {TenderView, BidView, BidDecide, BidEvaluate, ReviewView, ReviewWrite, OrganizationView},
+	entity.RoleViewer:        {TenderView, BidView, OrganizationView},
+}
+
+// Granted проверяет, что роль дает разрешение
+func Granted(role entity.ResponsibleRole, permission Permission) bool {
+	for _, granted := range rolePermissions[role] {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// Roles возвращает роли, которые дают разрешение
+func Roles(permission Permission) []entity.ResponsibleRole {
+	var roles []entity.ResponsibleRole
+	for _, role := range []entity.ResponsibleRole{
+		entity.RoleOwner, entity.RoleTenderManager, entity.RoleEvaluator, entity.RoleViewer,
+	} {
+		if Granted(role, permission) {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
@@ -0,0 +1,69 @@
+package permission
+
+import (
+	"reflect"
+	"tenders/internal/domain/entity"
+	"testing"
+)
+
+func TestGranted(t *testing.T) {
+	all := []Permission{
+		TenderView, TenderManage, BidView, BidSubmit, BidDecide, BidEvaluate, ReviewView, ReviewWrite,
+		OrganizationView, OrganizationManage, WebhookManage, AuditView,
+	}
+
+	tests := []struct {
+		role    entity.ResponsibleRole
+		granted []Permission
+	}{
+		{entity.RoleOwner, all},
+		{
+			entity.RoleTenderManager,
+			[]Permission{TenderView, TenderManage, BidView, BidSubmit, ReviewView, OrganizationView, AuditView},
+		},
+		{
+			entity.RoleEvaluator,
+			[]Permission{TenderView, BidView, BidDecide, BidEvaluate, ReviewView, ReviewWrite, OrganizationView},
+		},
+		{entity.RoleViewer, []Permission{TenderView, BidView, OrganizationView}},
+		{"unknown", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.role), func(t *testing.T) {
+			want := map[Permission]bool{}
+			for _, permission := range tt.granted {
+				want[permission] = true
+			}
+			for _, permission := range all {
+				if got := Granted(tt.role, permission); got != want[permission] {
+					t.Errorf("Granted(%q, %q) = %v, want %v", tt.role, permission, got, want[permission])
+				}
+			}
+		})
+	}
+}
+
+func TestRoles(t *testing.T) {
+	tests := []struct {
+		permission Permission
+		want       []entity.ResponsibleRole
+	}{
+		{TenderView, []entity.ResponsibleRole{
+			entity.RoleOwner, entity.RoleTenderManager, entity.RoleEvaluator, entity.RoleViewer,
+		}},
+		{BidDecide, []entity.ResponsibleRole{entity.RoleOwner, entity.RoleEvaluator}},
+		{ReviewView, []entity.ResponsibleRole{entity.RoleOwner, entity.RoleTenderManager, entity.RoleEvaluator}},
+		{AuditView, []entity.ResponsibleRole{entity.RoleOwner, entity.RoleTenderManager}},
+		{WebhookManage, []entity.ResponsibleRole{entity.RoleOwner}},
+		{"unknown", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.permission), func(t *testing.T) {
+			if got := Roles(tt.permission); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Roles(%q) = %v, want %v", tt.permission, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"tenders/internal/domain/entity"
+	"time"
+)
+
+// AuditFilter условия выборки журнала, пустые значения не ограничивают выборку
+type AuditFilter struct {
+	OrganizationIds []uuid.UUID
+	EntityType      string
+	EntityId        uuid.UUID
+	ActorId         uuid.UUID
+	From            time.Time
+	To              time.Time
+}
+
+type AuditRepository interface {
+	Save(ctx context.Context, event *entity.AuditEvent) error
+	FindAll(ctx context.Context, filter AuditFilter, limit, offset int) ([]entity.AuditEvent, error)
+}
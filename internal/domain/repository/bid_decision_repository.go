@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"tenders/internal/domain/entity"
+)
+
+type BidDecisionRepository interface {
+	Save(ctx context.Context, decision *entity.BidDecision) error
+	CountByBidIdAndDecision(ctx context.Context, bidId uuid.UUID, decision string) (int, error)
+	FindAllByBidId(ctx context.Context, bidId uuid.UUID) ([]entity.BidDecision, error)
+	SummarizeByBidIds(ctx context.Context, bidIds []uuid.UUID) (map[uuid.UUID]entity.BidDecisionSummary, error)
+}
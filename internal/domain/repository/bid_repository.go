@@ -1,21 +1,38 @@
 package repository
 
 import (
-	"database/sql"
+	"context"
 	"github.com/google/uuid"
 	"tenders/internal/domain/entity"
+	"time"
 )
 
+// BidFilter условия выборки списков предложений, пустые значения не ограничивают выборку
+type BidFilter struct {
+	Statuses    []string
+	AuthorTypes []string
+	// CreatedFrom и CreatedTo ограничивают время создания: [CreatedFrom, CreatedTo)
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+}
+
 type BidRepository interface {
-	BeginTransaction() (*sql.Tx, error)
-	Create(bid *entity.Bid) (*entity.Bid, error)
-	FindAllByEmployeeIdAndOrgId(employeeId, orgId uuid.UUID, limit, offset int) ([]entity.Bid, error)
-	FindAllByTenderId(tenderId uuid.UUID, limit, offset int) ([]entity.Bid, error)
-	FindByBidId(bidId uuid.UUID) (*entity.Bid, error)
-	FindAllByOrganizationForEmployee(employeeId uuid.UUID) ([]entity.Bid, error)
-	SaveHistoricalVersionTx(tx *sql.Tx, bid *entity.Bid) error
-	UpdateBidTx(tx *sql.Tx, bid *entity.Bid) error
-	FindByBidIdTx(tx *sql.Tx, bidId uuid.UUID) (*entity.Bid, error)
-	FindVersionInHistoryTx(tx *sql.Tx, bidId uuid.UUID, version int) (*entity.Bid, error)
-	FindByAuthorAndTender(authorId uuid.UUID, tenderId uuid.UUID) (*entity.Bid, error)
+	Create(ctx context.Context, bid *entity.Bid) (*entity.Bid, error)
+	// FindAllByEmployeeIdAndOrgIds находит предложения сотрудника и организаций orgIds
+	FindAllByEmployeeIdAndOrgIds(
+		ctx context.Context, employeeId uuid.UUID, orgIds []uuid.UUID, filter BidFilter, page Page,
+	) (*PageResult[entity.Bid], error)
+	FindAllByTenderId(ctx context.Context, tenderId uuid.UUID, filter BidFilter, page Page) (*PageResult[entity.Bid], error)
+	// FindAllByTenderIdAndFilter находит все предложения тендера, подходящие под filter, без разбиения на страницы
+	FindAllByTenderIdAndFilter(ctx context.Context, tenderId uuid.UUID, filter BidFilter) ([]entity.Bid, error)
+	// FindPriceStatsByTenderId возвращает минимум, медиану и максимум цен предложений тендера по валютам
+	FindPriceStatsByTenderId(ctx context.Context, tenderId uuid.UUID, filter BidFilter) ([]entity.PriceStats, error)
+	FindByBidId(ctx context.Context, bidId uuid.UUID) (*entity.Bid, error)
+	// LockByBidId возвращает предложение и блокирует его строку до конца транзакции
+	LockByBidId(ctx context.Context, bidId uuid.UUID) (*entity.Bid, error)
+	FindAllByOrganizationForEmployee(ctx context.Context, employeeId uuid.UUID) ([]entity.Bid, error)
+	SaveHistoricalVersion(ctx context.Context, bid *entity.Bid) error
+	Update(ctx context.Context, bid *entity.Bid, previousVersion int) error
+	FindVersionInHistory(ctx context.Context, bidId uuid.UUID, version int) (*entity.Bid, error)
+	FindByAuthorAndTender(ctx context.Context, authorId uuid.UUID, tenderId uuid.UUID) (*entity.Bid, error)
 }
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"tenders/internal/domain/entity"
+)
+
+// ChangeEventFilter кому видны события: сотруднику как автору предложений и ответственному за организации.
+// Для каждого типа сущности перечислены организации, в которых роль сотрудника разрешает ее просмотр
+type ChangeEventFilter struct {
+	EmployeeId            uuid.UUID
+	TenderOrganizationIds []uuid.UUID
+	BidOrganizationIds    []uuid.UUID
+	ReviewOrganizationIds []uuid.UUID
+}
+
+type ChangeEventRepository interface {
+	// Save сохраняет событие и уведомляет подписчиков после фиксации транзакции
+	Save(ctx context.Context, event *entity.ChangeEvent) error
+	FindAfter(ctx context.Context, filter ChangeEventFilter, afterId int64, limit int) ([]entity.ChangeEvent, error)
+	LastId(ctx context.Context) (int64, error)
+}
+
+// ChangeNotifier будит подписчиков, когда в ленте могли появиться новые события
+type ChangeNotifier interface {
+	// Subscribe возвращает канал пробуждений и функцию отписки
+	Subscribe() (<-chan struct{}, func())
+}
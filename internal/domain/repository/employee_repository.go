@@ -1,12 +1,17 @@
 package repository
 
 import (
+	"context"
 	"github.com/google/uuid"
 	"tenders/internal/domain/entity"
 )
 
 type EmployeeRepository interface {
-	FindEmployeeIdByUsernameIfResponsibleForOrg(username string, organizationId uuid.UUID) (uuid.UUID, error)
-	FindEmployeeIdByUsername(username string) (uuid.UUID, error)
-	FindById(id uuid.UUID) (*entity.Employee, error)
+	Create(ctx context.Context, employee *entity.Employee) error
+	Update(ctx context.Context, employee *entity.Employee) error
+	FindEmployeeIdByUsername(ctx context.Context, username string) (uuid.UUID, error)
+	FindById(ctx context.Context, id uuid.UUID) (*entity.Employee, error)
+	FindByUsername(ctx context.Context, username string) (*entity.Employee, error)
+	// FindAllByOrganizationId возвращает ответственных за организацию с их ролями
+	FindAllByOrganizationId(ctx context.Context, organizationId uuid.UUID) ([]entity.Responsible, error)
 }
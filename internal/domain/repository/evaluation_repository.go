@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"tenders/internal/domain/entity"
+)
+
+type EvaluationRepository interface {
+	// ReplaceCriteria заменяет критерии тендера
+	ReplaceCriteria(ctx context.Context, tenderId uuid.UUID, criteria []entity.TenderCriterion) error
+	FindCriteriaByTenderId(ctx context.Context, tenderId uuid.UUID) ([]entity.TenderCriterion, error)
+	// LockCriteriaByTenderId возвращает критерии тендера и не дает заменить их до конца транзакции
+	LockCriteriaByTenderId(ctx context.Context, tenderId uuid.UUID) ([]entity.TenderCriterion, error)
+	ExistsScoresByTenderId(ctx context.Context, tenderId uuid.UUID) (bool, error)
+	// SaveScores сохраняет оценки, повторная оценка того же сотрудника по критерию перезаписывает предыдущую
+	SaveScores(ctx context.Context, scores []entity.BidScore) error
+	// FindScoresByTenderId возвращает оценки предложений тендера, сгруппированные по предложению и оценщику
+	FindScoresByTenderId(ctx context.Context, tenderId uuid.UUID) ([]entity.BidScore, error)
+}
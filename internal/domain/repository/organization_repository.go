@@ -1,11 +1,32 @@
 package repository
 
 import (
+	"context"
 	"github.com/google/uuid"
 	"tenders/internal/domain/entity"
 )
 
 type OrganizationRepository interface {
-	FindById(id uuid.UUID) (*entity.Organization, error)
-	FindByEmployeeId(employeeId uuid.UUID) (*entity.Organization, error)
+	Create(ctx context.Context, organization *entity.Organization) error
+	Update(ctx context.Context, organization *entity.Organization) error
+	FindById(ctx context.Context, id uuid.UUID) (*entity.Organization, error)
+	// FindByIdForUpdate блокирует организацию до конца транзакции, чтобы изменения ответственных не пересекались
+	FindByIdForUpdate(ctx context.Context, id uuid.UUID) (*entity.Organization, error)
+	// FindAllByEmployeeId возвращает действующие организации, за которые отвечает сотрудник
+	FindAllByEmployeeId(ctx context.Context, employeeId uuid.UUID) ([]entity.Organization, error)
+	// CountResponsibles считает действующих сотрудников, отвечающих за организацию с одной из ролей roles
+	CountResponsibles(ctx context.Context, organizationId uuid.UUID, roles []entity.ResponsibleRole) (int, error)
+	// FindRole возвращает роль сотрудника в действующей организации или sql.ErrNoRows, если он за нее не отвечает
+	FindRole(ctx context.Context, organizationId, employeeId uuid.UUID) (entity.ResponsibleRole, error)
+	// HasRole проверяет, что у сотрудника есть одна из ролей roles хотя бы в одной действующей организации
+	HasRole(ctx context.Context, employeeId uuid.UUID, roles []entity.ResponsibleRole) (bool, error)
+	// ShareActiveOrganization проверяет, что оба сотрудника отвечают за одну и ту же действующую организацию,
+	// а у первого в ней одна из ролей managerRoles
+	ShareActiveOrganization(
+		ctx context.Context, managerId, employeeId uuid.UUID, managerRoles []entity.ResponsibleRole,
+	) (bool, error)
+	// AddResponsible назначает ответственного с ролью, для уже назначенного меняет роль
+	AddResponsible(ctx context.Context, organizationId, employeeId uuid.UUID, role entity.ResponsibleRole) error
+	// RemoveResponsible возвращает sql.ErrNoRows, если сотрудник не был ответственным
+	RemoveResponsible(ctx context.Context, organizationId, employeeId uuid.UUID) error
 }
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"tenders/internal/domain/entity"
+	"time"
+)
+
+type OutboxRepository interface {
+	Save(ctx context.Context, event *entity.OutboxEvent) error
+}
+
+type WebhookSubscriptionRepository interface {
+	Create(ctx context.Context, subscription *entity.WebhookSubscription) error
+	Update(ctx context.Context, subscription *entity.WebhookSubscription) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	FindById(ctx context.Context, id uuid.UUID) (*entity.WebhookSubscription, error)
+	FindAllByOrganizationId(ctx context.Context, organizationId uuid.UUID) ([]entity.WebhookSubscription, error)
+}
+
+type WebhookDeliveryRepository interface {
+	// FanOutEvents создает доставки по еще не разосланным событиям для всех подходящих подписок
+	FanOutEvents(ctx context.Context, limit int) (int, error)
+	// ClaimDue забирает доставки, время попытки которых наступило, и откладывает их на lease,
+	// чтобы другие экземпляры приложения не взяли их одновременно
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]entity.WebhookDelivery, error)
+	MarkDelivered(ctx context.Context, id uuid.UUID) error
+	// MarkFailed откладывает следующую попытку на retryAfter или, если deadLetter, прекращает доставку
+	MarkFailed(ctx context.Context, id uuid.UUID, attempts int, retryAfter time.Duration, lastError string, deadLetter bool) error
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"strconv"
+	"tenders/internal/domain/entity"
+	"tenders/internal/utils/common/custom_types"
+	"time"
+)
+
+// Поля, по которым можно сортировать списки тендеров и предложений
+const (
+	SortByName      = "name"
+	SortByCreatedAt = "created_at"
+	SortByVersion   = "version"
+	SortByStatus    = "status"
+	// SortByPrice только для списков предложений: предложения группируются по валюте и внутри валюты идут по цене,
+	// предложения без цены идут после всех валют
+	SortByPrice = "price"
+)
+
+// PriceCursorKeyUnset ключ курсора для предложения без цены
+const PriceCursorKeyUnset = "Infinity"
+
+// PriceCursorGroupUnset группа курсора для предложения без цены, она больше любого кода валюты
+const PriceCursorGroupUnset = "ZZZZ"
+
+var ValidSortFields = map[string]bool{
+	SortByName:      true,
+	SortByCreatedAt: true,
+	SortByVersion:   true,
+	SortByStatus:    true,
+}
+
+var ValidBidSortFields = map[string]bool{
+	SortByName:      true,
+	SortByCreatedAt: true,
+	SortByVersion:   true,
+	SortByStatus:    true,
+	SortByPrice:     true,
+}
+
+// CursorTimeLayout формат времени создания в курсоре, время хранится в UTC с точностью до микросекунд
+const CursorTimeLayout = "2006-01-02 15:04:05.999999"
+
+// Sort сортировка списка, пустое Field означает порядок списка по умолчанию
+type Sort struct {
+	Field string
+	Desc  bool
+}
+
+// String возвращает сортировку в виде параметра sort, например created_at:desc
+func (s Sort) String() string {
+	if s.Field == "" {
+		return ""
+	}
+	if s.Desc {
+		return s.Field + ":desc"
+	}
+	return s.Field + ":asc"
+}
+
+// ValidCursorKey проверяет, что значение курсора можно сравнить с полем сортировки
+func (s Sort) ValidCursorKey(key string) bool {
+	switch s.Field {
+	case SortByCreatedAt:
+		_, err := time.Parse(CursorTimeLayout, key)
+		return err == nil
+	case SortByVersion:
+		_, err := strconv.Atoi(key)
+		return err == nil
+	case SortByPrice:
+		_, err := custom_types.ParseDecimal(key)
+		return err == nil || key == PriceCursorKeyUnset
+	default:
+		return true
+	}
+}
+
+// ValidCursorGroup проверяет группу курсора: она есть только при сортировке по цене и равна коду валюты
+func (s Sort) ValidCursorGroup(group string) bool {
+	if s.Field != SortByPrice {
+		return group == ""
+	}
+	return entity.ValidCurrencies[group] || group == PriceCursorGroupUnset
+}
+
+// Page параметры страницы списка. Если задан After, выборка продолжается после него и Offset не учитывается
+type Page struct {
+	Limit  int
+	Offset int
+	After  *Cursor
+	Sort   Sort
+	// WithTotal запрашивает общее число записей списка без учета limit, offset и курсора
+	WithTotal bool
+}
+
+// Cursor ключ сортировки последней записи страницы. Id разрешает совпадения Key, Rank задан только в поиске,
+// Group только при сортировке по цене (валюта), Sort сортировка, для которой выдан курсор
+type Cursor struct {
+	Sort  string    `json:"s,omitempty"`
+	Rank  float64   `json:"r,omitempty"`
+	Group string    `json:"g,omitempty"`
+	Key   string    `json:"k"`
+	Id    uuid.UUID `json:"i"`
+}
+
+// PageResult страница списка: Next задан, если после страницы могут быть записи, Total если его запросили
+type PageResult[T any] struct {
+	Items []T
+	Next  *Cursor
+	Total *int
+}
+
+// EmptyPageResult пустая страница, общее число записей равно нулю, если его запросили
+func EmptyPageResult[T any](page Page) *PageResult[T] {
+	result := &PageResult[T]{Items: []T{}}
+	if page.WithTotal {
+		total := 0
+		result.Total = &total
+	}
+	return result
+}
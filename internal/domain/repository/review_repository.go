@@ -1,11 +1,12 @@
 package repository
 
 import (
+	"context"
 	"github.com/google/uuid"
 	"tenders/internal/domain/entity"
 )
 
 type ReviewRepository interface {
-	Create(*entity.Review) (*entity.Review, error)
-	FindAllByBidAuthor(authorId uuid.UUID, limit, offset int) ([]entity.Review, error)
+	Create(ctx context.Context, review *entity.Review) (*entity.Review, error)
+	FindAllByBidAuthor(ctx context.Context, authorId uuid.UUID, page Page) (*PageResult[entity.Review], error)
 }
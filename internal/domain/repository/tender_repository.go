@@ -1,16 +1,37 @@
 package repository
 
 import (
+	"context"
 	"github.com/google/uuid"
 	"tenders/internal/domain/entity"
+	"time"
 )
 
+// TenderFilter условия выборки тендеров, пустые значения не ограничивают выборку
+type TenderFilter struct {
+	ServiceTypes   []string
+	Statuses       []string
+	OrganizationId uuid.UUID
+	// CreatedFrom и CreatedTo ограничивают время создания тендера: [CreatedFrom, CreatedTo)
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	// Query поисковый запрос, поддерживает синтаксис websearch: "точная фраза", OR, -исключение
+	Query string
+}
+
 type TenderRepository interface {
-	Create(tender *entity.Tender) (*entity.Tender, error)
-	FindAllAvailableByOrganizationId(id uuid.UUID, limit, offset int) ([]entity.Tender, error)
-	FindAllPublished(serviceTypes []string, limit, offset int) ([]entity.Tender, error)
-	FindByTenderId(id uuid.UUID) (*entity.Tender, error)
-	FindByTenderIdAndVersion(tenderId uuid.UUID, version int) (*entity.Tender, error)
-	FindLatestVersionByTenderId(tenderId uuid.UUID) (int, error)
-	CheckEmployeeAccessToTender(employeeId uuid.UUID, tenderId uuid.UUID) (bool, error)
+	Create(ctx context.Context, tender *entity.Tender) (*entity.Tender, error)
+	FindAllAvailableByOrganizationIds(
+		ctx context.Context, ids []uuid.UUID, filter TenderFilter, page Page,
+	) (*PageResult[entity.Tender], error)
+	FindAllPublished(ctx context.Context, filter TenderFilter, page Page) (*PageResult[entity.Tender], error)
+	// SearchPublished ищет опубликованные тендеры по filter.Query и сортирует их по релевантности
+	SearchPublished(ctx context.Context, filter TenderFilter, page Page) (*PageResult[entity.TenderSearchResult], error)
+	FindByTenderId(ctx context.Context, id uuid.UUID) (*entity.Tender, error)
+	FindByTenderIdAndVersion(ctx context.Context, tenderId uuid.UUID, version int) (*entity.Tender, error)
+	FindAllVersionsByTenderId(ctx context.Context, tenderId uuid.UUID, limit, offset int) ([]entity.TenderVersion, error)
+	FindVersionByTenderIdAndVersion(ctx context.Context, tenderId uuid.UUID, version int) (*entity.TenderVersion, error)
+	FindLatestVersionByTenderId(ctx context.Context, tenderId uuid.UUID) (int, error)
+	// FindAllExpiredPublished находит опубликованные тендеры, срок закрытия которых наступил к now
+	FindAllExpiredPublished(ctx context.Context, now time.Time, limit int) ([]entity.Tender, error)
 }
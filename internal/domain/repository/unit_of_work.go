@@ -0,0 +1,22 @@
+package repository
+
+import "context"
+
+// TxRepositories репозитории, выполняющие запросы в одной общей транзакции
+type TxRepositories struct {
+	Tender       TenderRepository
+	Employee     EmployeeRepository
+	Organization OrganizationRepository
+	Bid          BidRepository
+	Review       ReviewRepository
+	BidDecision  BidDecisionRepository
+	Evaluation   EvaluationRepository
+	Audit        AuditRepository
+	Outbox       OutboxRepository
+	ChangeEvent  ChangeEventRepository
+}
+
+type UnitOfWork interface {
+	// WithinTx выполняет fn в транзакции, которая фиксируется, если fn вернула nil, и откатывается иначе
+	WithinTx(ctx context.Context, fn func(repos *TxRepositories) error) error
+}
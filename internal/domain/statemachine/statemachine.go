@@ -0,0 +1,101 @@
+package statemachine
+
+import "tenders/internal/utils/consts"
+
+// Actor роль, от имени которой выполняется переход
+type Actor string
+
+const (
+	// ActorResponsible ответственный за организацию тендера
+	ActorResponsible Actor = "responsible"
+	// ActorBidAuthor автор предложения или ответственный за организацию-автора
+	ActorBidAuthor Actor = "bidAuthor"
+	// ActorDecision решение ответственных за тендер по предложению (submit_decision)
+	ActorDecision Actor = "decision"
+	// ActorSystem само приложение: закрытие тендера по сроку или после одобрения предложения
+	ActorSystem Actor = "system"
+)
+
+// Transition разрешенный переход из статуса From в статус To, выполнить его могут только Actors
+type Transition struct {
+	Action string  `json:"action"`
+	From   string  `json:"-"`
+	To     string  `json:"to"`
+	Actors []Actor `json:"-"`
+}
+
+type Machine struct {
+	transitions []Transition
+}
+
+// Tender Closed конечный статус: закрытый тендер нельзя открыть снова
+var Tender = Machine{transitions: []Transition{
+	{Action: "publish", From: consts.TenderCreated, To: consts.TenderPublished, Actors: []Actor{ActorResponsible}},
+	{Action: "close", From: consts.TenderCreated, To: consts.TenderClosed, Actors: []Actor{ActorResponsible}},
+	{Action: "close", From: consts.TenderPublished, To: consts.TenderClosed, Actors: []Actor{ActorResponsible, ActorSystem}},
+}}
+
+// Bid Canceled, Approved и Rejected конечные статусы, решения принимаются только по опубликованным предложениям
+var Bid = Machine{transitions: []Transition{
+	{Action: "publish", From: consts.BidCreated, To: consts.BidPublished, Actors: []Actor{ActorBidAuthor}},
+	{Action: "cancel", From: consts.BidCreated, To: consts.BidCanceled, Actors: []Actor{ActorBidAuthor}},
+	{Action: "cancel", From: consts.BidPublished, To: consts.BidCanceled, Actors: []Actor{ActorBidAuthor}},
+	{Action: "approve", From: consts.BidPublished, To: consts.BidApproved, Actors: []Actor{ActorDecision}},
+	{Action: "reject", From: consts.BidPublished, To: consts.BidRejected, Actors: []Actor{ActorDecision}},
+}}
+
+// Can проверяет, может ли actor перевести сущность из from в to. Сохранение текущего статуса
+// переходом не считается
+func (m Machine) Can(from, to string, actor Actor) bool {
+	for _, transition := range m.transitions {
+		if transition.From == from && transition.To == to && transition.allows(actor) {
+			return true
+		}
+	}
+	return false
+}
+
+// CanRestore проверяет, может ли actor вернуть сущность из статуса from к версии со статусом to:
+// версия с тем же статусом восстанавливается, если статус не конечный, иначе нужен переход
+func (m Machine) CanRestore(from, to string, actor Actor) bool {
+	if from == to {
+		return !m.Final(from)
+	}
+	return m.Can(from, to, actor)
+}
+
+// Final проверяет, что из статуса нет ни одного перехода: сущность в нем больше не меняется
+func (m Machine) Final(status string) bool {
+	for _, transition := range m.transitions {
+		if transition.From == status {
+			return false
+		}
+	}
+	return true
+}
+
+// Available возвращает переходы из статуса from, доступные хотя бы одной из ролей actors
+func (m Machine) Available(from string, actors ...Actor) []Transition {
+	available := []Transition{}
+	for _, transition := range m.transitions {
+		if transition.From != from {
+			continue
+		}
+		for _, actor := range actors {
+			if transition.allows(actor) {
+				available = append(available, transition)
+				break
+			}
+		}
+	}
+	return available
+}
+
+func (t Transition) allows(actor Actor) bool {
+	for _, allowed := range t.Actors {
+		if allowed == actor {
+			return true
+		}
+	}
+	return false
+}
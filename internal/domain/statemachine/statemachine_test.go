@@ -0,0 +1,139 @@
+package statemachine
+
+import (
+	"tenders/internal/utils/consts"
+	"testing"
+)
+
+func TestCan(t *testing.T) {
+	tests := []struct {
+		name    string
+		machine Machine
+		from    string
+		to      string
+		actor   Actor
+		want    bool
+	}{
+		{"tender publish", Tender, consts.TenderCreated, consts.TenderPublished, ActorResponsible, true},
+		{"tender close created", Tender, consts.TenderCreated, consts.TenderClosed, ActorResponsible, true},
+		{"tender close published", Tender, consts.TenderPublished, consts.TenderClosed, ActorResponsible, true},
+		{"tender close by system", Tender, consts.TenderPublished, consts.TenderClosed, ActorSystem, true},
+		{"tender close created by system", Tender, consts.TenderCreated, consts.TenderClosed, ActorSystem, false},
+		{"tender reopen", Tender, consts.TenderClosed, consts.TenderPublished, ActorResponsible, false},
+		{"tender unpublish", Tender, consts.TenderPublished, consts.TenderCreated, ActorResponsible, false},
+		{"tender same status", Tender, consts.TenderPublished, consts.TenderPublished, ActorResponsible, false},
+		{"tender closed same status", Tender, consts.TenderClosed, consts.TenderClosed, ActorResponsible, false},
+
+		{"bid publish", Bid, consts.BidCreated, consts.BidPublished, ActorBidAuthor, true},
+		{"bid cancel created", Bid, consts.BidCreated, consts.BidCanceled, ActorBidAuthor, true},
+		{"bid cancel published", Bid, consts.BidPublished, consts.BidCanceled, ActorBidAuthor, true},
+		{"bid approve", Bid, consts.BidPublished, consts.BidApproved, ActorDecision, true},
+		{"bid reject", Bid, consts.BidPublished, consts.BidRejected, ActorDecision, true},
+		{"bid approve by author", Bid, consts.BidPublished, consts.BidApproved, ActorBidAuthor, false},
+		{"bid publish by decision", Bid, consts.BidCreated, consts.BidPublished, ActorDecision, false},
+		{"bid approve created", Bid, consts.BidCreated, consts.BidApproved, ActorDecision, false},
+		{"bid restore canceled", Bid, consts.BidCanceled, consts.BidPublished, ActorBidAuthor, false},
+		{"bid cancel approved", Bid, consts.BidApproved, consts.BidCanceled, ActorBidAuthor, false},
+		{"bid same status", Bid, consts.BidPublished, consts.BidPublished, ActorBidAuthor, false},
+		{"bid approved same status", Bid, consts.BidApproved, consts.BidApproved, ActorDecision, false},
+		{"unknown status", Bid, "Unknown", consts.BidPublished, ActorBidAuthor, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.machine.Can(tt.from, tt.to, tt.actor); got != tt.want {
+				t.Errorf("Can(%q, %q, %q) = %v, want %v", tt.from, tt.to, tt.actor, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanRestore(t *testing.T) {
+	tests := []struct {
+		name    string
+		machine Machine
+		from    string
+		to      string
+		actor   Actor
+		want    bool
+	}{
+		{"tender same open status", Tender, consts.TenderCreated, consts.TenderCreated, ActorResponsible, true},
+		{"tender same closed status", Tender, consts.TenderClosed, consts.TenderClosed, ActorResponsible, false},
+		{"tender reopen", Tender, consts.TenderClosed, consts.TenderPublished, ActorResponsible, false},
+		{"tender transition", Tender, consts.TenderCreated, consts.TenderPublished, ActorResponsible, true},
+		{"bid same open status", Bid, consts.BidPublished, consts.BidPublished, ActorBidAuthor, true},
+		{"bid same canceled status", Bid, consts.BidCanceled, consts.BidCanceled, ActorBidAuthor, false},
+		{"bid same approved status", Bid, consts.BidApproved, consts.BidApproved, ActorBidAuthor, false},
+		{"bid restore canceled", Bid, consts.BidCanceled, consts.BidCreated, ActorBidAuthor, false},
+		{"bid transition", Bid, consts.BidCreated, consts.BidCanceled, ActorBidAuthor, true},
+		{"bid transition by other actor", Bid, consts.BidPublished, consts.BidApproved, ActorBidAuthor, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.machine.CanRestore(tt.from, tt.to, tt.actor); got != tt.want {
+				t.Errorf("CanRestore(%q, %q, %q) = %v, want %v", tt.from, tt.to, tt.actor, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFinal(t *testing.T) {
+	tests := []struct {
+		machine Machine
+		status  string
+		want    bool
+	}{
+		{Tender, consts.TenderCreated, false},
+		{Tender, consts.TenderPublished, false},
+		{Tender, consts.TenderClosed, true},
+		{Bid, consts.BidCreated, false},
+		{Bid, consts.BidPublished, false},
+		{Bid, consts.BidCanceled, true},
+		{Bid, consts.BidApproved, true},
+		{Bid, consts.BidRejected, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			if got := tt.machine.Final(tt.status); got != tt.want {
+				t.Errorf("Final(%q) = %v, want %v", tt.status, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAvailable(t *testing.T) {
+	tests := []struct {
+		name    string
+		machine Machine
+		from    string
+		actors  []Actor
+		want    []string
+	}{
+		{"tender created", Tender, consts.TenderCreated, []Actor{ActorResponsible}, []string{"publish", "close"}},
+		{"tender published", Tender, consts.TenderPublished, []Actor{ActorResponsible}, []string{"close"}},
+		{"tender closed", Tender, consts.TenderClosed, []Actor{ActorResponsible}, []string{}},
+		{"bid published author", Bid, consts.BidPublished, []Actor{ActorBidAuthor}, []string{"cancel"}},
+		{"bid published decision", Bid, consts.BidPublished, []Actor{ActorDecision}, []string{"approve", "reject"}},
+		{
+			"bid published both", Bid, consts.BidPublished, []Actor{ActorBidAuthor, ActorDecision},
+			[]string{"cancel", "approve", "reject"},
+		},
+		{"bid no actors", Bid, consts.BidCreated, nil, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.machine.Available(tt.from, tt.actors...)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Available(%q) = %v, want actions %v", tt.from, got, tt.want)
+			}
+			for i, transition := range got {
+				if transition.Action != tt.want[i] || transition.From != tt.from {
+					t.Errorf("Available(%q)[%d] = %+v, want action %q", tt.from, i, transition, tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+const defaultTokenTTL = 24 * time.Hour
+
+type AuthConfig struct {
+	SecretKey []byte
+	TokenTTL  time.Duration
+	// AllowLegacyUsername разрешает идентифицировать пользователя по query-параметру username без токена
+	AllowLegacyUsername bool
+	// AdminUsername администратор, который управляет любыми сотрудниками и организациями.
+	// Если его нет в базе, при запуске он создается с паролем AdminPassword
+	AdminUsername string
+	AdminPassword string
+}
+
+func (c *Config) AuthConfig() *AuthConfig {
+	secretKey := os.Getenv("AUTH_SECRET_KEY")
+	if secretKey == "" {
+		log.Fatalf("AUTH_SECRET_KEY is not set")
+	}
+
+	tokenTTL := defaultTokenTTL
+	if ttlStr := os.Getenv("AUTH_TOKEN_TTL"); ttlStr != "" {
+		ttl, err := time.ParseDuration(ttlStr)
+		if err != nil || ttl <= 0 {
+			log.Fatalf("Invalid AUTH_TOKEN_TTL %q", ttlStr)
+		}
+		tokenTTL = ttl
+	}
+
+	allowLegacy, _ := strconv.ParseBool(os.Getenv("AUTH_ALLOW_LEGACY_USERNAME"))
+
+	return &AuthConfig{
+		SecretKey:           []byte(secretKey),
+		TokenTTL:            tokenTTL,
+		AllowLegacyUsername: allowLegacy,
+		AdminUsername:       os.Getenv("AUTH_ADMIN_USERNAME"),
+		AdminPassword:       os.Getenv("AUTH_ADMIN_PASSWORD"),
+	}
+}
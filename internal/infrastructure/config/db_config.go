@@ -8,10 +8,14 @@ import (
 	"github.com/simukti/sqldb-logger/logadapter/zerologadapter"
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	_ "github.com/lib/pq"
 )
 
+const defaultRequestTimeout = 10 * time.Second
+
 type DatabaseConfig struct {
 	Driver   string
 	Username string
@@ -19,10 +23,18 @@ type DatabaseConfig struct {
 	Host     string
 	Port     string
 	Database string
+	// Seed включает вставку тестовых данных при старте приложения
+	Seed bool
+	// RequestTimeout максимальное время, которое запрос к API может провести в базе
+	RequestTimeout time.Duration
 }
 
 type ParseConfig interface {
 	PostgresConfig() *DatabaseConfig
+	AuthConfig() *AuthConfig
+	LocaleConfig() *LocaleConfig
+	WebhookConfig() *WebhookConfig
+	SchedulerConfig() *SchedulerConfig
 }
 
 type Config struct{}
@@ -32,17 +44,32 @@ func NewConfig() *Config {
 }
 
 func (c *Config) PostgresConfig() *DatabaseConfig {
+	seed, _ := strconv.ParseBool(os.Getenv("POSTGRES_SEED"))
+
+	requestTimeout := defaultRequestTimeout
+	if timeoutStr := os.Getenv("POSTGRES_REQUEST_TIMEOUT"); timeoutStr != "" {
+		timeout, err := time.ParseDuration(timeoutStr)
+		if err != nil || timeout <= 0 {
+			log.Fatalf("Invalid POSTGRES_REQUEST_TIMEOUT %q", timeoutStr)
+		}
+		requestTimeout = timeout
+	}
+
 	return &DatabaseConfig{
 		Username: os.Getenv("POSTGRES_USERNAME"),
 		Password: os.Getenv("POSTGRES_PASSWORD"),
 		Port:     os.Getenv("POSTGRES_PORT"),
 		Database: os.Getenv("POSTGRES_DATABASE"),
 		Host:     os.Getenv("POSTGRES_HOST"),
+		Seed:     seed,
+
+		RequestTimeout: requestTimeout,
 	}
 }
 
-func NewPostgresConn(c *DatabaseConfig) *sql.DB {
-	connStr := fmt.Sprintf(
+// ConnString строка подключения, используется и пулом соединений, и слушателем LISTEN/NOTIFY
+func (c *DatabaseConfig) ConnString() string {
+	return fmt.Sprintf(
 		"postgresql://%s:%s@%s:%s/%s?sslmode=disable",
 		c.Username,
 		c.Password,
@@ -50,6 +77,10 @@ func NewPostgresConn(c *DatabaseConfig) *sql.DB {
 		c.Port,
 		c.Database,
 	)
+}
+
+func NewPostgresConn(c *DatabaseConfig) *sql.DB {
+	connStr := c.ConnString()
 
 	conn, err := sql.Open("postgres", connStr)
 
@@ -0,0 +1,25 @@
+package config
+
+import (
+	"log"
+	"os"
+	"tenders/internal/utils/i18n"
+)
+
+type LocaleConfig struct {
+	// DefaultLanguage язык ответов, если клиент не передал поддерживаемый язык в Accept-Language
+	DefaultLanguage i18n.Language
+}
+
+func (c *Config) LocaleConfig() *LocaleConfig {
+	defaultLanguage := i18n.Russian
+	if languageStr := os.Getenv("DEFAULT_LANGUAGE"); languageStr != "" {
+		language, found := i18n.ParseLanguage(languageStr)
+		if !found {
+			log.Fatalf("Unsupported DEFAULT_LANGUAGE %q", languageStr)
+		}
+		defaultLanguage = language
+	}
+
+	return &LocaleConfig{DefaultLanguage: defaultLanguage}
+}
@@ -0,0 +1,16 @@
+package config
+
+import "time"
+
+const defaultTenderCloseInterval = time.Minute
+
+type SchedulerConfig struct {
+	// TenderCloseInterval как часто проверяются тендеры с истекшим сроком
+	TenderCloseInterval time.Duration
+}
+
+func (c *Config) SchedulerConfig() *SchedulerConfig {
+	return &SchedulerConfig{
+		TenderCloseInterval: parsePositiveDuration("TENDER_CLOSE_INTERVAL", defaultTenderCloseInterval),
+	}
+}
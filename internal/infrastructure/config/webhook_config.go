@@ -0,0 +1,57 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultWebhookDispatchInterval = 5 * time.Second
+	defaultWebhookMaxAttempts      = 8
+	defaultWebhookTimeout          = 10 * time.Second
+)
+
+type WebhookConfig struct {
+	// DispatchInterval как часто диспетчер проверяет новые события и доставки
+	DispatchInterval time.Duration
+	// MaxAttempts после стольких неудачных попыток доставка переводится в DeadLetter
+	MaxAttempts int
+	// Timeout максимальное время ожидания ответа от получателя
+	Timeout time.Duration
+}
+
+func (c *Config) WebhookConfig() *WebhookConfig {
+	return &WebhookConfig{
+		DispatchInterval: parsePositiveDuration("WEBHOOK_DISPATCH_INTERVAL", defaultWebhookDispatchInterval),
+		MaxAttempts:      parsePositiveInt("WEBHOOK_MAX_ATTEMPTS", defaultWebhookMaxAttempts),
+		Timeout:          parsePositiveDuration("WEBHOOK_TIMEOUT", defaultWebhookTimeout),
+	}
+}
+
+func parsePositiveDuration(name string, defaultValue time.Duration) time.Duration {
+	valueStr := os.Getenv(name)
+	if valueStr == "" {
+		return defaultValue
+	}
+
+	value, err := time.ParseDuration(valueStr)
+	if err != nil || value <= 0 {
+		log.Fatalf("Invalid %s %q", name, valueStr)
+	}
+	return value
+}
+
+func parsePositiveInt(name string, defaultValue int) int {
+	valueStr := os.Getenv(name)
+	if valueStr == "" {
+		return defaultValue
+	}
+
+	value, err := strconv.Atoi(valueStr)
+	if err != nil || value <= 0 {
+		log.Fatalf("Invalid %s %q", name, valueStr)
+	}
+	return value
+}
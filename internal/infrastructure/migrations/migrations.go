@@ -0,0 +1,24 @@
+package migrations
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed postgresql/*.sql
+var postgresFS embed.FS
+
+//go:embed seed/*.sql
+var seedFS embed.FS
+
+// Postgres возвращает файлы миграций в формате NNNNNN_name.up.sql / NNNNNN_name.down.sql
+func Postgres() fs.FS {
+	sub, _ := fs.Sub(postgresFS, "postgresql")
+	return sub
+}
+
+// Seed возвращает тестовые данные, которые применяются отдельно от миграций
+func Seed() fs.FS {
+	sub, _ := fs.Sub(seedFS, "seed")
+	return sub
+}
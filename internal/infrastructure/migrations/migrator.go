@@ -0,0 +1,283 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// advisoryLockKey произвольный ключ, под которым экземпляры приложения сериализуют применение миграций
+const advisoryLockKey int64 = 7234019
+
+var migrationFileRegexp = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time
+}
+
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+func NewMigrator(db *sql.DB, files fs.FS) (*Migrator, error) {
+	migrations, err := loadMigrations(files)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+func loadMigrations(files fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(files, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		match := migrationFileRegexp.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		content, err := fs.ReadFile(files, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, found := byVersion[version]
+		if !found {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has conflicting names %q and %q", version, migration.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Up применяет все еще не примененные миграции и возвращает их количество.
+// Каждая миграция выполняется в своей транзакции вместе с записью в schema_migrations, поэтому значение,
+// добавленное в enum через ALTER TYPE ... ADD VALUE, нельзя использовать в той же миграции
+// (Postgres отклонит ее с ошибкой unsafe use of new value): такие изменения выносятся в следующую миграцию
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	applied := 0
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		appliedVersions, err := m.appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, found := appliedVersions[migration.Version]; found {
+				continue
+			}
+
+			err = runInTx(ctx, conn, migration.Up, func(tx *sql.Tx) error {
+				_, err := tx.ExecContext(ctx,
+					`INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`,
+					migration.Version, migration.Name,
+				)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("apply migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			applied++
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down откатывает steps последних примененных миграций
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	if steps < 1 {
+		return 0, errors.New("steps must be positive")
+	}
+
+	rolledBack := 0
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		appliedVersions, err := m.appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && rolledBack < steps; i-- {
+			migration := m.migrations[i]
+			if _, found := appliedVersions[migration.Version]; !found {
+				continue
+			}
+			if migration.Down == "" {
+				return fmt.Errorf("migration %d_%s has no down file", migration.Version, migration.Name)
+			}
+
+			err = runInTx(ctx, conn, migration.Down, func(tx *sql.Tx) error {
+				_, err := tx.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version = $1`, migration.Version)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("rollback migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			rolledBack++
+		}
+		return nil
+	})
+	return rolledBack, err
+}
+
+// Status возвращает все известные миграции, для примененных заполнено AppliedAt
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var statuses []MigrationStatus
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		appliedVersions, err := m.appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+			if appliedAt, found := appliedVersions[migration.Version]; found {
+				status.AppliedAt = &appliedAt
+			}
+			statuses = append(statuses, status)
+		}
+		return nil
+	})
+	return statuses, err
+}
+
+// Seed выполняет все sql файлы с тестовыми данными по алфавиту, файлы должны быть идемпотентными
+func (m *Migrator) Seed(ctx context.Context, files fs.FS) error {
+	names, err := fs.Glob(files, "*.sql")
+	if err != nil {
+		return err
+	}
+	sort.Strings(names)
+
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		for _, name := range names {
+			content, err := fs.ReadFile(files, name)
+			if err != nil {
+				return err
+			}
+			if err = runInTx(ctx, conn, string(content), nil); err != nil {
+				return fmt.Errorf("seed %s: %w", name, err)
+			}
+		}
+		return nil
+	})
+}
+
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	// Advisory lock держится на сессии, поэтому все делается через одно соединение
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err = conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, advisoryLockKey); err != nil {
+		return err
+	}
+	defer conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, advisoryLockKey)
+
+	_, err = conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations
+		(
+			version    BIGINT PRIMARY KEY,
+			name       VARCHAR(255) NOT NULL,
+			applied_at TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	return fn(conn)
+}
+
+func (m *Migrator) appliedVersions(ctx context.Context, conn *sql.Conn) (map[int64]time.Time, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	versions := map[int64]time.Time{}
+	for rows.Next() {
+		var version int64
+		var appliedAt time.Time
+		if err = rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		versions[version] = appliedAt
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+// runInTx выполняет script и after в одной транзакции
+func runInTx(ctx context.Context, conn *sql.Conn, script string, after func(tx *sql.Tx) error) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, script); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if after != nil {
+		if err = after(tx); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
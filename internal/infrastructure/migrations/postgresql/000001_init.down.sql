@@ -1,10 +1,11 @@
--- DROP TABLE IF EXISTS review;
--- DROP TABLE IF EXISTS bid_history;
--- DROP TABLE IF EXISTS bid;
--- DROP TABLE IF EXISTS tender;
+--- Таблицы сотрудников и организаций могли быть созданы до приложения и заполняются вне его,
+--- поэтому откат удаляет только таблицы и типы тендеров и предложений. Повторный up создаст их заново
+DROP TABLE IF EXISTS review;
+DROP TABLE IF EXISTS bid_history;
+DROP TABLE IF EXISTS bid;
+DROP TABLE IF EXISTS tender;
 
-
--- DROP TYPE IF EXISTS tender_status;
--- DROP TYPE IF EXISTS tender_service_type;
--- DROP TYPE IF EXISTS author_type;
--- DROP TYPE IF EXISTS bid_status;
\ No newline at end of file
+DROP TYPE IF EXISTS tender_status;
+DROP TYPE IF EXISTS tender_service_type;
+DROP TYPE IF EXISTS author_type;
+DROP TYPE IF EXISTS bid_status;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS bid_decision;
+DROP TYPE IF EXISTS bid_decision_type;
+
+-- Значения из enum bid_status в Postgres удалить нельзя, поэтому Approved и Rejected остаются
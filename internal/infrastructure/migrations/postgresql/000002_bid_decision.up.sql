@@ -0,0 +1,29 @@
+--- Миграция выполняется в транзакции: новые значения bid_status в ней не используются
+ALTER TYPE bid_status ADD VALUE IF NOT EXISTS 'Approved';
+ALTER TYPE bid_status ADD VALUE IF NOT EXISTS 'Rejected';
+
+DO
+$$
+    BEGIN
+        BEGIN
+            CREATE TYPE bid_decision_type AS ENUM (
+                'Approved',
+                'Rejected'
+                );
+        EXCEPTION
+            WHEN duplicate_object THEN
+                NULL;
+        END;
+    END
+$$;
+
+--- Каждый ответственный организации может оставить только одно решение по предложению
+CREATE TABLE IF NOT EXISTS bid_decision
+(
+    id          UUID PRIMARY KEY           DEFAULT uuid_generate_v4(),
+    bid_id      UUID              NOT NULL REFERENCES bid (bid_id) ON DELETE CASCADE,
+    employee_id UUID              NOT NULL REFERENCES employee (id) ON DELETE CASCADE,
+    decision    bid_decision_type NOT NULL,
+    created_at  TIMESTAMP         NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (bid_id, employee_id)
+);
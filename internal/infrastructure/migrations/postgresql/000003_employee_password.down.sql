@@ -0,0 +1,2 @@
+ALTER TABLE employee
+    DROP COLUMN IF EXISTS password_hash;
@@ -0,0 +1,3 @@
+--- Хэш пароля в формате bcrypt, например crypt('password', gen_salt('bf')) из pgcrypto
+ALTER TABLE employee
+    ADD COLUMN IF NOT EXISTS password_hash VARCHAR(100);
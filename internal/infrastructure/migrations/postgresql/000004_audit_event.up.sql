@@ -0,0 +1,47 @@
+DO
+$$
+    BEGIN
+        BEGIN
+            CREATE TYPE audit_entity_type AS ENUM (
+                'Tender',
+                'Bid',
+                'Review'
+                );
+        EXCEPTION
+            WHEN duplicate_object THEN
+                NULL;
+        END;
+
+        BEGIN
+            CREATE TYPE audit_action AS ENUM (
+                'Create',
+                'Edit',
+                'Rollback',
+                'StatusChange',
+                'Decision',
+                'Feedback'
+                );
+        EXCEPTION
+            WHEN duplicate_object THEN
+                NULL;
+        END;
+    END
+$$;
+
+--- Журнал изменений только дополняется, organization_id организация тендера, к которому относится событие
+CREATE TABLE IF NOT EXISTS audit_event
+(
+    id              UUID PRIMARY KEY           DEFAULT uuid_generate_v4(),
+    actor_id        UUID              NOT NULL REFERENCES employee (id),
+    organization_id UUID              NOT NULL REFERENCES organization (id),
+    entity_type     audit_entity_type NOT NULL,
+    entity_id       UUID              NOT NULL,
+    action          audit_action      NOT NULL,
+    version_before  INT,
+    version_after   INT,
+    request_id      VARCHAR(100),
+    created_at      TIMESTAMP         NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS audit_event_organization_created_at_idx ON audit_event (organization_id, created_at);
+CREATE INDEX IF NOT EXISTS audit_event_entity_idx ON audit_event (entity_type, entity_id);
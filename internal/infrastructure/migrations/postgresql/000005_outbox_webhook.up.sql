@@ -0,0 +1,59 @@
+DO
+$$
+    BEGIN
+        BEGIN
+            CREATE TYPE webhook_delivery_status AS ENUM (
+                'Pending',
+                'Delivered',
+                'DeadLetter'
+                );
+        EXCEPTION
+            WHEN duplicate_object THEN
+                NULL;
+        END;
+    END
+$$;
+
+--- Пустой event_types означает подписку на все события
+CREATE TABLE IF NOT EXISTS webhook_subscription
+(
+    id              UUID PRIMARY KEY        DEFAULT uuid_generate_v4(),
+    organization_id UUID           NOT NULL REFERENCES organization (id) ON DELETE CASCADE,
+    url             VARCHAR(2048)  NOT NULL,
+    secret          VARCHAR(100)   NOT NULL,
+    event_types     VARCHAR(50)[]  NOT NULL DEFAULT '{}',
+    active          BOOLEAN        NOT NULL DEFAULT TRUE,
+    created_at      TIMESTAMP      NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP      NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS webhook_subscription_organization_idx ON webhook_subscription (organization_id);
+
+--- Событие пишется в одной транзакции с изменением, dispatched_at выставляется, когда по нему созданы доставки
+CREATE TABLE IF NOT EXISTS outbox_event
+(
+    id              UUID PRIMARY KEY     DEFAULT uuid_generate_v4(),
+    organization_id UUID        NOT NULL REFERENCES organization (id) ON DELETE CASCADE,
+    event_type      VARCHAR(50) NOT NULL,
+    payload         JSONB       NOT NULL,
+    created_at      TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    dispatched_at   TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS outbox_event_not_dispatched_idx ON outbox_event (created_at) WHERE dispatched_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS webhook_delivery
+(
+    id              UUID PRIMARY KEY                 DEFAULT uuid_generate_v4(),
+    event_id        UUID                    NOT NULL REFERENCES outbox_event (id) ON DELETE CASCADE,
+    subscription_id UUID                    NOT NULL REFERENCES webhook_subscription (id) ON DELETE CASCADE,
+    status          webhook_delivery_status NOT NULL DEFAULT 'Pending',
+    attempts        INT                     NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP               NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error      TEXT,
+    delivered_at    TIMESTAMP,
+    created_at      TIMESTAMP               NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (event_id, subscription_id)
+);
+
+CREATE INDEX IF NOT EXISTS webhook_delivery_pending_idx ON webhook_delivery (next_attempt_at) WHERE status = 'Pending';
@@ -0,0 +1,20 @@
+--- Лента изменений для подписчиков /api/events/stream, id монотонно растет и служит Last-Event-ID.
+--- organization_id организация тендера, author_* автор предложения, по ним определяется, кому видно событие
+CREATE TABLE IF NOT EXISTS change_event
+(
+    id                     BIGSERIAL PRIMARY KEY,
+    entity_type            audit_entity_type NOT NULL,
+    entity_id              UUID              NOT NULL,
+    action                 audit_action      NOT NULL,
+    organization_id        UUID              NOT NULL REFERENCES organization (id) ON DELETE CASCADE,
+    author_employee_id     UUID,
+    author_organization_id UUID,
+    payload                JSONB             NOT NULL,
+    created_at             TIMESTAMP         NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS change_event_organization_idx ON change_event (organization_id, id);
+CREATE INDEX IF NOT EXISTS change_event_author_employee_idx ON change_event (author_employee_id, id)
+    WHERE author_employee_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS change_event_author_organization_idx ON change_event (author_organization_id, id)
+    WHERE author_organization_id IS NOT NULL;
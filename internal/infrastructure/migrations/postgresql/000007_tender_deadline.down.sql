@@ -0,0 +1,5 @@
+--- Журнал только дополняется: записи планировщика без сотрудника остаются, поэтому actor_id остается nullable
+
+DROP INDEX IF EXISTS tender_published_deadline_idx;
+ALTER TABLE tender DROP COLUMN IF EXISTS decision_deadline;
+ALTER TABLE tender DROP COLUMN IF EXISTS submission_deadline;
@@ -0,0 +1,9 @@
+--- Сроки хранятся в UTC: прием предложений до submission_deadline, решения до decision_deadline
+ALTER TABLE tender ADD COLUMN IF NOT EXISTS submission_deadline TIMESTAMP;
+ALTER TABLE tender ADD COLUMN IF NOT EXISTS decision_deadline TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS tender_published_deadline_idx ON tender (COALESCE(decision_deadline, submission_deadline))
+    WHERE status = 'Published';
+
+--- События планировщика записываются в журнал без сотрудника
+ALTER TABLE audit_event ALTER COLUMN actor_id DROP NOT NULL;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS tender_search_vector_idx;
+ALTER TABLE tender DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,11 @@
+--- Поисковый вектор строится по обеим конфигурациям, чтобы находились словоформы и русских, и английских слов.
+--- Название весомее описания
+ALTER TABLE tender
+    ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian', name), 'A') ||
+        setweight(to_tsvector('english', name), 'A') ||
+        setweight(to_tsvector('russian', COALESCE(description, '')), 'B') ||
+        setweight(to_tsvector('english', COALESCE(description, '')), 'B')
+        ) STORED;
+
+CREATE INDEX IF NOT EXISTS tender_search_vector_idx ON tender USING GIN (search_vector);
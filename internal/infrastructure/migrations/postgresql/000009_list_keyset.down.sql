@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS review_bid_idx;
+DROP INDEX IF EXISTS bid_author_name_idx;
+DROP INDEX IF EXISTS bid_tender_name_idx;
+DROP INDEX IF EXISTS tender_organization_name_idx;
+DROP INDEX IF EXISTS tender_published_name_idx;
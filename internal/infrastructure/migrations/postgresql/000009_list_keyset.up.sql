@@ -0,0 +1,9 @@
+--- Индексы под постраничную выборку списков по (name, id): курсор продолжает список с последней записи,
+--- а не пропускает offset строк
+CREATE INDEX IF NOT EXISTS tender_published_name_idx ON tender (name, tender_id) WHERE status = 'Published';
+CREATE INDEX IF NOT EXISTS tender_organization_name_idx ON tender (organization_id, name, tender_id);
+
+CREATE INDEX IF NOT EXISTS bid_tender_name_idx ON bid (tender_id, name, bid_id);
+CREATE INDEX IF NOT EXISTS bid_author_name_idx ON bid (author_id, name, bid_id);
+
+CREATE INDEX IF NOT EXISTS review_bid_idx ON review (bid_id);
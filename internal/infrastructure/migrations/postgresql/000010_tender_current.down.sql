@@ -0,0 +1,18 @@
+DROP TABLE IF EXISTS tender;
+
+ALTER TABLE tender_history RENAME CONSTRAINT tender_history_pkey TO tender_pkey;
+ALTER TABLE tender_history RENAME TO tender;
+
+ALTER TABLE tender
+    ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian', name), 'A') ||
+        setweight(to_tsvector('english', name), 'A') ||
+        setweight(to_tsvector('russian', COALESCE(description, '')), 'B') ||
+        setweight(to_tsvector('english', COALESCE(description, '')), 'B')
+        ) STORED;
+
+CREATE INDEX IF NOT EXISTS tender_search_vector_idx ON tender USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS tender_published_deadline_idx ON tender (COALESCE(decision_deadline, submission_deadline))
+    WHERE status = 'Published';
+CREATE INDEX IF NOT EXISTS tender_published_name_idx ON tender (name, tender_id) WHERE status = 'Published';
+CREATE INDEX IF NOT EXISTS tender_organization_name_idx ON tender (organization_id, name, tender_id);
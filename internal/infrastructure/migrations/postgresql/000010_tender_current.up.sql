@@ -0,0 +1,50 @@
+--- Тендеры разделены так же, как предложения: tender хранит текущую версию, tender_history все версии.
+--- Внешние ключи предложений после переименования ссылаются на конкретную версию в tender_history
+ALTER TABLE tender RENAME TO tender_history;
+ALTER TABLE tender_history RENAME CONSTRAINT tender_pkey TO tender_history_pkey;
+
+--- Поиск и списки читают только текущие версии, индексы истории по ним больше не нужны
+DROP INDEX IF EXISTS tender_published_deadline_idx;
+DROP INDEX IF EXISTS tender_published_name_idx;
+DROP INDEX IF EXISTS tender_organization_name_idx;
+ALTER TABLE tender_history DROP COLUMN IF EXISTS search_vector;
+
+CREATE TABLE IF NOT EXISTS tender
+(
+    tender_id           UUID PRIMARY KEY,
+    name                VARCHAR(100)        NOT NULL,
+    description         VARCHAR(500),
+    service_type        tender_service_type NOT NULL,
+    status              tender_status       NOT NULL DEFAULT 'Created',
+    version             INT                 NOT NULL,
+    organization_id     UUID REFERENCES organization (id) ON DELETE CASCADE,
+    creator_id          UUID                REFERENCES employee (id) ON DELETE SET NULL,
+    created_at          TIMESTAMP                    DEFAULT CURRENT_TIMESTAMP,
+    submission_deadline TIMESTAMP,
+    decision_deadline   TIMESTAMP,
+    search_vector       tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian', name), 'A') ||
+        setweight(to_tsvector('english', name), 'A') ||
+        setweight(to_tsvector('russian', COALESCE(description, '')), 'B') ||
+        setweight(to_tsvector('english', COALESCE(description, '')), 'B')
+        ) STORED,
+    FOREIGN KEY (tender_id, version)
+        REFERENCES tender_history (tender_id, version)
+        ON DELETE CASCADE
+);
+
+INSERT INTO tender (
+    tender_id, name, description, service_type, status, version,
+    organization_id, creator_id, created_at, submission_deadline, decision_deadline
+)
+SELECT DISTINCT ON (tender_id)
+    tender_id, name, description, service_type, status, version,
+    organization_id, creator_id, created_at, submission_deadline, decision_deadline
+FROM tender_history
+ORDER BY tender_id, version DESC;
+
+CREATE INDEX IF NOT EXISTS tender_search_vector_idx ON tender USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS tender_published_deadline_idx ON tender (COALESCE(decision_deadline, submission_deadline))
+    WHERE status = 'Published';
+CREATE INDEX IF NOT EXISTS tender_published_name_idx ON tender (name, tender_id) WHERE status = 'Published';
+CREATE INDEX IF NOT EXISTS tender_organization_name_idx ON tender (organization_id, name, tender_id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS organization_responsible_user_idx;
+DROP INDEX IF EXISTS organization_responsible_unique_idx;
+
+ALTER TABLE organization DROP COLUMN IF EXISTS is_active;
+ALTER TABLE employee DROP COLUMN IF EXISTS is_active;
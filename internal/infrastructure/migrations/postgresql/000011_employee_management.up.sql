@@ -0,0 +1,16 @@
+--- Сотрудников и организации не удаляют, а деактивируют: на них ссылаются тендеры, предложения и аудит
+ALTER TABLE employee ADD COLUMN IF NOT EXISTS is_active BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE organization ADD COLUMN IF NOT EXISTS is_active BOOLEAN NOT NULL DEFAULT TRUE;
+
+--- Сотрудник назначается ответственным за организацию один раз, повторные записи сида удаляются
+DELETE FROM organization_responsible duplicate
+    USING organization_responsible kept
+WHERE duplicate.organization_id = kept.organization_id
+  AND duplicate.user_id = kept.user_id
+  AND duplicate.id > kept.id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS organization_responsible_unique_idx
+    ON organization_responsible (organization_id, user_id);
+
+CREATE INDEX IF NOT EXISTS organization_responsible_user_idx
+    ON organization_responsible (user_id);
@@ -0,0 +1,20 @@
+DO
+$$
+    BEGIN
+        BEGIN
+            CREATE TYPE responsible_role AS ENUM (
+                'owner',
+                'tender_manager',
+                'evaluator',
+                'viewer'
+                );
+        EXCEPTION
+            WHEN duplicate_object THEN
+                NULL;
+        END;
+    END
+$$;
+
+--- До появления ролей любой ответственный имел все права, поэтому существующие записи становятся владельцами
+ALTER TABLE organization_responsible
+    ADD COLUMN IF NOT EXISTS role responsible_role NOT NULL DEFAULT 'owner';
@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS bid_tender_price_idx;
+
+ALTER TABLE tender_history DROP COLUMN IF EXISTS currency;
+ALTER TABLE tender_history DROP COLUMN IF EXISTS budget;
+ALTER TABLE tender DROP COLUMN IF EXISTS currency;
+ALTER TABLE tender DROP COLUMN IF EXISTS budget;
+
+ALTER TABLE bid_history DROP COLUMN IF EXISTS delivery_days;
+ALTER TABLE bid_history DROP COLUMN IF EXISTS currency;
+ALTER TABLE bid_history DROP COLUMN IF EXISTS amount;
+ALTER TABLE bid DROP COLUMN IF EXISTS delivery_days;
+ALTER TABLE bid DROP COLUMN IF EXISTS currency;
+ALTER TABLE bid DROP COLUMN IF EXISTS amount;
@@ -0,0 +1,31 @@
+--- Цена предложения и бюджет тендера: сумма NUMERIC(19, 4) и код валюты ISO 4217.
+--- У предложений, созданных до появления цены, сумма и валюта пустые
+ALTER TABLE bid ADD COLUMN IF NOT EXISTS amount NUMERIC(19, 4) CHECK (amount >= 0);
+ALTER TABLE bid ADD COLUMN IF NOT EXISTS currency CHAR(3);
+ALTER TABLE bid ADD COLUMN IF NOT EXISTS delivery_days INT CHECK (delivery_days > 0);
+DO $$
+BEGIN
+    ALTER TABLE bid ADD CONSTRAINT bid_price_check CHECK ((amount IS NULL) = (currency IS NULL));
+EXCEPTION
+    WHEN duplicate_object THEN NULL;
+END $$;
+
+ALTER TABLE bid_history ADD COLUMN IF NOT EXISTS amount NUMERIC(19, 4);
+ALTER TABLE bid_history ADD COLUMN IF NOT EXISTS currency CHAR(3);
+ALTER TABLE bid_history ADD COLUMN IF NOT EXISTS delivery_days INT;
+
+--- Бюджет задается только вместе с валютой, валюта без бюджета ограничивает валюту предложений
+ALTER TABLE tender ADD COLUMN IF NOT EXISTS budget NUMERIC(19, 4) CHECK (budget >= 0);
+ALTER TABLE tender ADD COLUMN IF NOT EXISTS currency CHAR(3);
+DO $$
+BEGIN
+    ALTER TABLE tender ADD CONSTRAINT tender_budget_check CHECK (budget IS NULL OR currency IS NOT NULL);
+EXCEPTION
+    WHEN duplicate_object THEN NULL;
+END $$;
+
+ALTER TABLE tender_history ADD COLUMN IF NOT EXISTS budget NUMERIC(19, 4);
+ALTER TABLE tender_history ADD COLUMN IF NOT EXISTS currency CHAR(3);
+
+--- Сортировка предложений тендера по цене, предложения без цены идут как самые дорогие
+CREATE INDEX IF NOT EXISTS bid_tender_price_idx ON bid (tender_id, COALESCE(amount, 'Infinity'), bid_id);
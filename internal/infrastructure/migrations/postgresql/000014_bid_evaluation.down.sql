@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS bid_score;
+DROP TABLE IF EXISTS tender_criterion;
+
+DROP TYPE IF EXISTS evaluation_criterion;
+
+-- Значения из enum audit_action в Postgres удалить нельзя, поэтому Criteria и Score остаются
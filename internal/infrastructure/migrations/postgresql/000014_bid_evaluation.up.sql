@@ -0,0 +1,40 @@
+--- Миграция выполняется в транзакции: новые значения audit_action в ней не используются
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'Criteria';
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'Score';
+
+DO
+$$
+    BEGIN
+        BEGIN
+            CREATE TYPE evaluation_criterion AS ENUM (
+                'price',
+                'delivery_time',
+                'quality',
+                'experience'
+                );
+        EXCEPTION
+            WHEN duplicate_object THEN
+                NULL;
+        END;
+    END
+$$;
+
+--- Критерии оценки предложений тендера, веса в процентах и в сумме дают 100
+CREATE TABLE IF NOT EXISTS tender_criterion
+(
+    tender_id UUID                 NOT NULL REFERENCES tender (tender_id) ON DELETE CASCADE,
+    criterion evaluation_criterion NOT NULL,
+    weight    INT                  NOT NULL CHECK (weight BETWEEN 1 AND 100),
+    PRIMARY KEY (tender_id, criterion)
+);
+
+--- Оценки предложения от 0 до 10, по одной от каждого оценщика на критерий
+CREATE TABLE IF NOT EXISTS bid_score
+(
+    bid_id      UUID                 NOT NULL REFERENCES bid (bid_id) ON DELETE CASCADE,
+    employee_id UUID                 NOT NULL REFERENCES employee (id) ON DELETE CASCADE,
+    criterion   evaluation_criterion NOT NULL,
+    score       SMALLINT             NOT NULL CHECK (score BETWEEN 0 AND 10),
+    created_at  TIMESTAMP            NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (bid_id, employee_id, criterion)
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS bid_tender_currency_price_idx;
+CREATE INDEX IF NOT EXISTS bid_tender_price_idx ON bid (tender_id, COALESCE(amount, 'Infinity'), bid_id);
@@ -0,0 +1,5 @@
+--- Сортировка по цене группирует предложения по валюте: суммы в разных валютах между собой не сравниваются.
+--- Предложения без цены идут после всех валют
+DROP INDEX IF EXISTS bid_tender_price_idx;
+CREATE INDEX IF NOT EXISTS bid_tender_currency_price_idx
+    ON bid (tender_id, COALESCE(currency::text, 'ZZZZ'), COALESCE(amount, 'Infinity'), bid_id);
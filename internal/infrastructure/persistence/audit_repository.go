@@ -0,0 +1,117 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"strings"
+	"tenders/internal/domain/entity"
+	"tenders/internal/domain/repository"
+)
+
+type AuditRepo struct {
+	Conn DBTX
+}
+
+func NewAuditRepository(conn DBTX) *AuditRepo {
+	return &AuditRepo{Conn: conn}
+}
+
+var _ repository.AuditRepository = &AuditRepo{}
+
+func (r *AuditRepo) Save(ctx context.Context, event *entity.AuditEvent) error {
+	query := `
+		INSERT INTO audit_event (
+		    id, actor_id, organization_id, entity_type, entity_id,
+		    action, version_before, version_after, request_id, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+	_, err := r.Conn.ExecContext(ctx, query,
+		event.Id, event.ActorId, event.OrganizationId, event.EntityType, event.EntityId,
+		event.Action, nullableVersion(event.VersionBefore), nullableVersion(event.VersionAfter),
+		sql.NullString{String: event.RequestId, Valid: event.RequestId != ""}, event.CreatedAt.ConvertToTime(),
+	)
+	return err
+}
+
+// FindAll возвращает события журнала от новых к старым
+func (r *AuditRepo) FindAll(
+	ctx context.Context, filter repository.AuditFilter, limit, offset int,
+) ([]entity.AuditEvent, error) {
+	var conditions []string
+	var queryArgs []interface{}
+
+	addCondition := func(condition string, arg interface{}) {
+		queryArgs = append(queryArgs, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(queryArgs)))
+	}
+
+	organizationIds := make([]string, 0, len(filter.OrganizationIds))
+	for _, organizationId := range filter.OrganizationIds {
+		organizationIds = append(organizationIds, organizationId.String())
+	}
+	addCondition("organization_id = ANY($%d::uuid[])", pq.StringArray(organizationIds))
+
+	if filter.EntityType != "" {
+		addCondition("entity_type = $%d", filter.EntityType)
+	}
+	if filter.EntityId != uuid.Nil {
+		addCondition("entity_id = $%d", filter.EntityId)
+	}
+	if filter.ActorId != uuid.Nil {
+		addCondition("actor_id = $%d", filter.ActorId)
+	}
+	if !filter.From.IsZero() {
+		addCondition("created_at >= $%d", filter.From)
+	}
+	if !filter.To.IsZero() {
+		addCondition("created_at < $%d", filter.To)
+	}
+
+	queryStr := `
+		SELECT id, actor_id, organization_id, entity_type, entity_id,
+		       action, version_before, version_after, request_id, created_at
+		FROM audit_event
+		WHERE ` + strings.Join(conditions, " AND ")
+	queryStr += fmt.Sprintf(" ORDER BY created_at DESC, id LIMIT $%d OFFSET $%d", len(queryArgs)+1, len(queryArgs)+2)
+	queryArgs = append(queryArgs, limit, offset)
+
+	rows, err := r.Conn.QueryContext(ctx, queryStr, queryArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []entity.AuditEvent{}
+	for rows.Next() {
+		var event entity.AuditEvent
+		var versionBefore, versionAfter sql.NullInt64
+		var requestId sql.NullString
+		err = rows.Scan(
+			&event.Id, &event.ActorId, &event.OrganizationId, &event.EntityType, &event.EntityId,
+			&event.Action, &versionBefore, &versionAfter, &requestId, &event.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		event.VersionBefore = int(versionBefore.Int64)
+		event.VersionAfter = int(versionAfter.Int64)
+		event.RequestId = requestId.String
+
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// nullableVersion сохраняет отсутствующую (нулевую) версию как NULL
+func nullableVersion(version int) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(version), Valid: version != 0}
+}
@@ -0,0 +1,122 @@
+package persistence
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"tenders/internal/domain/entity"
+	"tenders/internal/domain/repository"
+)
+
+type BidDecisionRepo struct {
+	Conn DBTX
+}
+
+func NewBidDecisionRepository(conn DBTX) *BidDecisionRepo {
+	return &BidDecisionRepo{Conn: conn}
+}
+
+var _ repository.BidDecisionRepository = &BidDecisionRepo{}
+
+// Save сохраняет решение сотрудника, повторное решение того же сотрудника перезаписывает предыдущее
+func (r *BidDecisionRepo) Save(ctx context.Context, decision *entity.BidDecision) error {
+	query := `
+		INSERT INTO bid_decision (id, bid_id, employee_id, decision, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (bid_id, employee_id)
+		DO UPDATE SET decision = EXCLUDED.decision, created_at = EXCLUDED.created_at
+	`
+	_, err := r.Conn.ExecContext(ctx, query,
+		decision.Id, decision.BidId, decision.EmployeeId, decision.Decision, decision.CreatedAt.ConvertToTime(),
+	)
+	return err
+}
+
+func (r *BidDecisionRepo) CountByBidIdAndDecision(ctx context.Context, bidId uuid.UUID, decision string) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM bid_decision
+		WHERE bid_id = $1 AND decision = $2
+	`
+	var count int
+	if err := r.Conn.QueryRowContext(ctx, query, bidId, decision).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *BidDecisionRepo) FindAllByBidId(ctx context.Context, bidId uuid.UUID) ([]entity.BidDecision, error) {
+	query := `
+		SELECT id, bid_id, employee_id, decision, created_at
+		FROM bid_decision
+		WHERE bid_id = $1
+		ORDER BY created_at ASC
+	`
+	rows, err := r.Conn.QueryContext(ctx, query, bidId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	decisions := []entity.BidDecision{}
+	for rows.Next() {
+		var decision entity.BidDecision
+		err = rows.Scan(
+			&decision.Id, &decision.BidId, &decision.EmployeeId, &decision.Decision, &decision.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		decisions = append(decisions, decision)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return decisions, nil
+}
+
+// SummarizeByBidIds считает решения по каждому из предложений, предложения без решений в результат не попадают
+func (r *BidDecisionRepo) SummarizeByBidIds(
+	ctx context.Context, bidIds []uuid.UUID,
+) (map[uuid.UUID]entity.BidDecisionSummary, error) {
+	summaries := make(map[uuid.UUID]entity.BidDecisionSummary, len(bidIds))
+	if len(bidIds) == 0 {
+		return summaries, nil
+	}
+
+	ids := make([]string, 0, len(bidIds))
+	for _, bidId := range bidIds {
+		ids = append(ids, bidId.String())
+	}
+
+	query := `
+		SELECT bid_id,
+		       COUNT(*) FILTER (WHERE decision = 'Approved'),
+		       COUNT(*) FILTER (WHERE decision = 'Rejected')
+		FROM bid_decision
+		WHERE bid_id = ANY($1::uuid[])
+		GROUP BY bid_id
+	`
+	rows, err := r.Conn.QueryContext(ctx, query, pq.StringArray(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var bidId uuid.UUID
+		var summary entity.BidDecisionSummary
+		if err = rows.Scan(&bidId, &summary.Approved, &summary.Rejected); err != nil {
+			return nil, err
+		}
+		summaries[bidId] = summary
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return summaries, nil
+}
@@ -1,33 +1,39 @@
 package persistence
 
 import (
-	"database/sql"
+	"context"
+	"fmt"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"strings"
 	"tenders/internal/domain/entity"
 	"tenders/internal/domain/repository"
+	"tenders/internal/utils"
 	"tenders/internal/utils/consts"
 )
 
 type BidRepo struct {
-	Conn *sql.DB
+	Conn DBTX
 }
 
-func NewBidRepository(conn *sql.DB) *BidRepo {
+func NewBidRepository(conn DBTX) *BidRepo {
 	return &BidRepo{Conn: conn}
 }
 
 var _ repository.BidRepository = &BidRepo{}
 
-func (r *BidRepo) Create(bid *entity.Bid) (*entity.Bid, error) {
+func (r *BidRepo) Create(ctx context.Context, bid *entity.Bid) (*entity.Bid, error) {
 	query := `INSERT INTO bid (
             bid_id, name, description, status, tender_id,
-        	tender_version, author_type, author_id, version, created_at
+        	tender_version, author_type, author_id, version, created_at,
+        	amount, currency, delivery_days
         ) 
-	    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING created_at`
-	err := r.Conn.QueryRow(query,
+	    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13) RETURNING created_at`
+	err := r.Conn.QueryRowContext(ctx, query,
 		bid.BidId, bid.Name, bid.Description, bid.Status,
 		bid.TenderId, bid.TenderVersion, bid.AuthorType,
 		bid.AuthorId, bid.Version, bid.CreatedAt.ConvertToTime(),
+		bid.Amount, nullableCurrency(bid.Currency), bid.DeliveryDays,
 	).Scan(&bid.CreatedAt)
 	if err != nil {
 		return nil, err
@@ -35,36 +41,51 @@ func (r *BidRepo) Create(bid *entity.Bid) (*entity.Bid, error) {
 	return bid, nil
 }
 
-func (r *BidRepo) FindAllByEmployeeIdAndOrgId(employeeId, orgId uuid.UUID, limit, offset int) ([]entity.Bid, error) {
-	bids := []entity.Bid{}
-	queryStr := `
-		SELECT b.bid_id, b.name, b.description, b.status,
-		       b.tender_id, b.tender_version, b.author_type, b.author_id, b.version, b.created_at
-		FROM bid b
-		WHERE (author_id = $1 AND b.author_type = $2) OR (author_id = $3 AND b.author_type = $4)
-		ORDER BY name ASC LIMIT $5 OFFSET $6
-	`
-
-	rows, err := r.Conn.Query(queryStr,
+func (r *BidRepo) FindAllByEmployeeIdAndOrgIds(
+	ctx context.Context, employeeId uuid.UUID, orgIds []uuid.UUID, filter repository.BidFilter, page repository.Page,
+) (*repository.PageResult[entity.Bid], error) {
+	condition := "((author_id = $1 AND author_type = $2) OR (author_id = ANY($3::uuid[]) AND author_type = $4))"
+	return r.findBidPage(ctx, condition, []interface{}{
 		employeeId, consts.AuthorTypeUser,
-		orgId, consts.AuthorTypeOrganization,
-		limit, offset,
-	)
+		uuidArray(orgIds), consts.AuthorTypeOrganization,
+	}, filter, page)
+}
+
+func (r *BidRepo) FindAllByTenderId(
+	ctx context.Context, tenderId uuid.UUID, filter repository.BidFilter, page repository.Page,
+) (*repository.PageResult[entity.Bid], error) {
+	return r.findBidPage(ctx, "tender_id = $1", []interface{}{tenderId}, filter, page)
+}
+
+func (r *BidRepo) FindAllByTenderIdAndFilter(
+	ctx context.Context, tenderId uuid.UUID, filter repository.BidFilter,
+) ([]entity.Bid, error) {
+	conditions, queryArgs := bidConditions("tender_id = $1", []interface{}{tenderId}, filter)
+
+	query := `
+		SELECT bid_id, name, description, tender_id, tender_version, status, author_type, author_id, version, created_at,
+		       amount, COALESCE(currency, ''), delivery_days
+		FROM bid
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY created_at, bid_id`
+
+	rows, err := r.Conn.QueryContext(ctx, query, queryArgs...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
+	bids := []entity.Bid{}
 	for rows.Next() {
 		var bid entity.Bid
-		err = rows.Scan(
-			&bid.BidId, &bid.Name, &bid.Description, &bid.Status, &bid.TenderId,
-			&bid.TenderVersion, &bid.AuthorType, &bid.AuthorId, &bid.Version, &bid.CreatedAt,
-		)
-		if err != nil {
+		if err = rows.Scan(
+			&bid.BidId, &bid.Name, &bid.Description,
+			&bid.TenderId, &bid.TenderVersion, &bid.Status,
+			&bid.AuthorType, &bid.AuthorId, &bid.Version, &bid.CreatedAt,
+			&bid.Amount, &bid.Currency, &bid.DeliveryDays,
+		); err != nil {
 			return nil, err
 		}
-
 		bids = append(bids, bid)
 	}
 
@@ -75,27 +96,39 @@ func (r *BidRepo) FindAllByEmployeeIdAndOrgId(employeeId, orgId uuid.UUID, limit
 	return bids, nil
 }
 
-func (r *BidRepo) FindAllByTenderId(tenderId uuid.UUID, limit, offset int) ([]entity.Bid, error) {
-	bids := []entity.Bid{}
+// findBidPage выбирает страницу предложений, подходящих под условие condition и filter, в порядке page.Sort,
+// по умолчанию по названию. Совпадающие значения ключа идут по id
+func (r *BidRepo) findBidPage(
+	ctx context.Context, condition string, queryArgs []interface{}, filter repository.BidFilter, page repository.Page,
+) (*repository.PageResult[entity.Bid], error) {
+	conditions, queryArgs := bidConditions(condition, queryArgs, filter)
+
 	queryStr := `
-		SELECT bid_id, name, description, tender_id, tender_version, status, author_type, author_id, version, created_at
+		SELECT bid_id, name, description, tender_id, tender_version, status, author_type, author_id, version, created_at,
+		       amount, COALESCE(currency, ''), delivery_days
 		FROM bid
-		WHERE tender_id = $1
-		ORDER BY name ASC LIMIT $2 OFFSET $3
-	`
+		WHERE ` + strings.Join(conditions, " AND ")
+
+	total, err := countTotal(ctx, r.Conn, page, queryStr, queryArgs...)
+	if err != nil {
+		return nil, err
+	}
 
-	rows, err := r.Conn.Query(queryStr, tenderId, limit, offset)
+	queryStr, queryArgs = keysetPage(queryStr, queryArgs, page, "", "name", "bid_id")
+	rows, err := r.Conn.QueryContext(ctx, queryStr, queryArgs...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
+	bids := []entity.Bid{}
 	for rows.Next() {
 		var bid entity.Bid
 		err = rows.Scan(
 			&bid.BidId, &bid.Name, &bid.Description,
 			&bid.TenderId, &bid.TenderVersion, &bid.Status,
 			&bid.AuthorType, &bid.AuthorId, &bid.Version, &bid.CreatedAt,
+			&bid.Amount, &bid.Currency, &bid.DeliveryDays,
 		)
 		if err != nil {
 			return nil, err
@@ -108,61 +141,141 @@ func (r *BidRepo) FindAllByTenderId(tenderId uuid.UUID, limit, offset int) ([]en
 		return nil, err
 	}
 
-	return bids, nil
+	return newPageResult(bids, page, total, func(bid entity.Bid) repository.Cursor {
+		return repository.Cursor{Group: bidSortGroup(page.Sort, bid), Key: bidSortKey(page.Sort, bid), Id: bid.BidId}
+	}), nil
 }
 
-func (r *BidRepo) FindByBidId(bidId uuid.UUID) (*entity.Bid, error) {
-	var bid entity.Bid
+// bidConditions дополняет условие condition условиями filter
+func bidConditions(
+	condition string, queryArgs []interface{}, filter repository.BidFilter,
+) ([]string, []interface{}) {
+	conditions := []string{condition}
+	addCondition := func(condition string, arg interface{}) {
+		queryArgs = append(queryArgs, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(queryArgs)))
+	}
+
+	if len(filter.Statuses) > 0 {
+		addCondition("status::text = ANY($%d::text[])", pq.StringArray(filter.Statuses))
+	}
+	if len(filter.AuthorTypes) > 0 {
+		addCondition("author_type::text = ANY($%d::text[])", pq.StringArray(filter.AuthorTypes))
+	}
+	if !filter.CreatedFrom.IsZero() {
+		addCondition("created_at >= $%d", filter.CreatedFrom.UTC())
+	}
+	if !filter.CreatedTo.IsZero() {
+		addCondition("created_at < $%d", filter.CreatedTo.UTC())
+	}
+	return conditions, queryArgs
+}
+
+func bidSortGroup(sort repository.Sort, bid entity.Bid) string {
+	if sort.Field != repository.SortByPrice {
+		return ""
+	}
+	if bid.Amount == nil {
+		return repository.PriceCursorGroupUnset
+	}
+	return bid.Currency
+}
+
+func bidSortKey(sort repository.Sort, bid entity.Bid) string {
+	if sort.Field != repository.SortByPrice {
+		return sortKey(sort, bid.Name, bid.Status, bid.Version, bid.CreatedAt)
+	}
+	if bid.Amount == nil {
+		return repository.PriceCursorKeyUnset
+	}
+	return bid.Amount.String()
+}
+
+// FindPriceStatsByTenderId считает цены предложений тендера, подходящих под filter, отдельно по валютам.
+// Медиана четного числа цен равна среднему двух средних: percentile_disc по возрастанию и убыванию дает их обе
+func (r *BidRepo) FindPriceStatsByTenderId(
+	ctx context.Context, tenderId uuid.UUID, filter repository.BidFilter,
+) ([]entity.PriceStats, error) {
+	conditions, queryArgs := bidConditions("tender_id = $1 AND amount IS NOT NULL", []interface{}{tenderId}, filter)
 	queryStr := `
-		SELECT bid_id, name, description, tender_id, tender_version, status, author_type, author_id, version, created_at
+		SELECT currency, COUNT(*), MIN(amount),
+		       (percentile_disc(0.5) WITHIN GROUP (ORDER BY amount) +
+		        percentile_disc(0.5) WITHIN GROUP (ORDER BY amount DESC)) / 2,
+		       MAX(amount)
 		FROM bid
-		WHERE bid_id = $1
-	`
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		GROUP BY currency
+		ORDER BY currency`
 
-	row := r.Conn.QueryRow(queryStr, bidId)
+	rows, err := r.Conn.QueryContext(ctx, queryStr, queryArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
-	err := row.Scan(
-		&bid.BidId, &bid.Name, &bid.Description,
-		&bid.TenderId, &bid.TenderVersion, &bid.Status,
-		&bid.AuthorType, &bid.AuthorId, &bid.Version, &bid.CreatedAt,
-	)
+	stats := []entity.PriceStats{}
+	for rows.Next() {
+		var currencyStats entity.PriceStats
+		err = rows.Scan(
+			&currencyStats.Currency, &currencyStats.Count,
+			&currencyStats.Min, &currencyStats.Median, &currencyStats.Max,
+		)
+		if err != nil {
+			return nil, err
+		}
+		stats = append(stats, currencyStats)
+	}
 
-	if err != nil {
+	if err = rows.Err(); err != nil {
 		return nil, err
 	}
+	return stats, nil
+}
 
-	return &bid, nil
+func (r *BidRepo) FindByBidId(ctx context.Context, bidId uuid.UUID) (*entity.Bid, error) {
+	return r.findByBidId(ctx, bidId, "")
 }
 
-func (r *BidRepo) FindByBidIdTx(tx *sql.Tx, bidId uuid.UUID) (*entity.Bid, error) {
-	query := `
-		SELECT bid_id, name, description, status, tender_id, tender_version, author_type, author_id, version, created_at
+func (r *BidRepo) LockByBidId(ctx context.Context, bidId uuid.UUID) (*entity.Bid, error) {
+	return r.findByBidId(ctx, bidId, "FOR UPDATE")
+}
+
+func (r *BidRepo) findByBidId(ctx context.Context, bidId uuid.UUID, lock string) (*entity.Bid, error) {
+	var bid entity.Bid
+	queryStr := `
+		SELECT bid_id, name, description, tender_id, tender_version, status, author_type, author_id, version, created_at,
+		       amount, COALESCE(currency, ''), delivery_days
 		FROM bid
 		WHERE bid_id = $1
-	`
-	row := tx.QueryRow(query, bidId)
-	var bid entity.Bid
+		` + lock
+
+	row := r.Conn.QueryRowContext(ctx, queryStr, bidId)
+
 	err := row.Scan(
 		&bid.BidId, &bid.Name, &bid.Description,
-		&bid.Status, &bid.TenderId, &bid.TenderVersion,
+		&bid.TenderId, &bid.TenderVersion, &bid.Status,
 		&bid.AuthorType, &bid.AuthorId, &bid.Version, &bid.CreatedAt,
+		&bid.Amount, &bid.Currency, &bid.DeliveryDays,
 	)
+
 	if err != nil {
 		return nil, err
 	}
+
 	return &bid, nil
 }
 
-func (r *BidRepo) FindAllByOrganizationForEmployee(employeeId uuid.UUID) ([]entity.Bid, error) {
+func (r *BidRepo) FindAllByOrganizationForEmployee(ctx context.Context, employeeId uuid.UUID) ([]entity.Bid, error) {
 	query := `
 		SELECT b.bid_id, b.name, b.description, b.status, b.tender_id,
-		       b.tender_version, b.author_type, b.author_id, b.version, b.created_at
+		       b.tender_version, b.author_type, b.author_id, b.version, b.created_at,
+		       b.amount, COALESCE(b.currency, ''), b.delivery_days
 		FROM bid b
 		JOIN organization_responsible org ON b.author_id = org.organization_id
 		WHERE org.user_id = $1 AND b.author_type = 'ORGANIZATION'
 	`
 
-	rows, err := r.Conn.Query(query, employeeId)
+	rows, err := r.Conn.QueryContext(ctx, query, employeeId)
 	if err != nil {
 		return nil, err
 	}
@@ -174,6 +287,7 @@ func (r *BidRepo) FindAllByOrganizationForEmployee(employeeId uuid.UUID) ([]enti
 		if err = rows.Scan(
 			&bid.BidId, &bid.Name, &bid.Description, &bid.Status, &bid.TenderId, &bid.TenderVersion,
 			&bid.AuthorType, &bid.AuthorId, &bid.Version, &bid.CreatedAt,
+			&bid.Amount, &bid.Currency, &bid.DeliveryDays,
 		); err != nil {
 			return nil, err
 		}
@@ -187,56 +301,73 @@ func (r *BidRepo) FindAllByOrganizationForEmployee(employeeId uuid.UUID) ([]enti
 	return bids, nil
 }
 
-func (r *BidRepo) BeginTransaction() (*sql.Tx, error) {
-	return r.Conn.Begin()
-}
-
-func (r *BidRepo) SaveHistoricalVersionTx(tx *sql.Tx, bid *entity.Bid) error {
+func (r *BidRepo) SaveHistoricalVersion(ctx context.Context, bid *entity.Bid) error {
 	query := `
 		INSERT INTO bid_history (
 		    bid_id, name, description, status,
-		    tender_id, tender_version, author_type, author_id, version, created_at
+		    tender_id, tender_version, author_type, author_id, version, created_at,
+		    amount, currency, delivery_days
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 	`
-	_, err := tx.Exec(query,
+	_, err := r.Conn.ExecContext(ctx, query,
 		bid.BidId, bid.Name, bid.Description, bid.Status, bid.TenderId,
 		bid.TenderVersion, bid.AuthorType, bid.AuthorId, bid.Version, bid.CreatedAt.ConvertToTime(),
+		bid.Amount, nullableCurrency(bid.Currency), bid.DeliveryDays,
 	)
+	if isUniqueViolation(err) {
+		return utils.VersionConflictError
+	}
 	return err
 }
 
-func (r *BidRepo) UpdateBidTx(tx *sql.Tx, bid *entity.Bid) error {
+// Update перезаписывает предложение, если его версия в базе все еще равна previousVersion
+func (r *BidRepo) Update(ctx context.Context, bid *entity.Bid, previousVersion int) error {
 	query := `
 		UPDATE bid
 		SET name = $1, description = $2, status = $3, tender_id = $4, 
-		    tender_version = $5, author_type = $6, author_id = $7, version = $8
-		WHERE bid_id = $9
+		    tender_version = $5, author_type = $6, author_id = $7, version = $8,
+		    amount = $9, currency = $10, delivery_days = $11
+		WHERE bid_id = $12 AND version = $13
 	`
 
-	_, err := tx.Exec(query,
+	result, err := r.Conn.ExecContext(ctx, query,
 		bid.Name, bid.Description, bid.Status, bid.TenderId,
 		bid.TenderVersion, bid.AuthorType, bid.AuthorId, bid.Version,
-		bid.BidId,
+		bid.Amount, nullableCurrency(bid.Currency), bid.DeliveryDays,
+		bid.BidId, previousVersion,
 	)
-	return err
+	if err != nil {
+		return err
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return utils.VersionConflictError
+	}
+	return nil
 }
 
-func (r *BidRepo) FindVersionInHistoryTx(tx *sql.Tx, bidId uuid.UUID, version int) (*entity.Bid, error) {
+func (r *BidRepo) FindVersionInHistory(ctx context.Context, bidId uuid.UUID, version int) (*entity.Bid, error) {
 	query := `
 		SELECT 
 		    bid_id, name, description, status, tender_id,
-		    tender_version, author_type, author_id, version, created_at
+		    tender_version, author_type, author_id, version, created_at,
+		    amount, COALESCE(currency, ''), delivery_days
 		FROM bid_history
 		WHERE bid_id = $1 and version = $2
 		LIMIT 1
 	`
-	row := tx.QueryRow(query, bidId, version)
+	row := r.Conn.QueryRowContext(ctx, query, bidId, version)
 	var bid entity.Bid
 	err := row.Scan(
 		&bid.BidId, &bid.Name, &bid.Description,
 		&bid.Status, &bid.TenderId, &bid.TenderVersion,
 		&bid.AuthorType, &bid.AuthorId, &bid.Version, &bid.CreatedAt,
+		&bid.Amount, &bid.Currency, &bid.DeliveryDays,
 	)
 	if err != nil {
 		return nil, err
@@ -244,17 +375,19 @@ func (r *BidRepo) FindVersionInHistoryTx(tx *sql.Tx, bidId uuid.UUID, version in
 	return &bid, nil
 }
 
-func (r *BidRepo) FindByAuthorAndTender(authorId uuid.UUID, tenderId uuid.UUID) (*entity.Bid, error) {
+func (r *BidRepo) FindByAuthorAndTender(ctx context.Context, authorId uuid.UUID, tenderId uuid.UUID) (*entity.Bid, error) {
 	query := `
         SELECT bid_id, name, description, status, tender_id,
-               tender_version, author_type, author_id, version, created_at
+               tender_version, author_type, author_id, version, created_at,
+               amount, COALESCE(currency, ''), delivery_days
         FROM bid
         WHERE author_id = $1 AND tender_id = $2
     `
 	var bid entity.Bid
-	err := r.Conn.QueryRow(query, authorId, tenderId).Scan(
+	err := r.Conn.QueryRowContext(ctx, query, authorId, tenderId).Scan(
 		&bid.BidId, &bid.Name, &bid.Description, &bid.Status, &bid.TenderId,
 		&bid.TenderVersion, &bid.AuthorType, &bid.AuthorId, &bid.Version, &bid.CreatedAt,
+		&bid.Amount, &bid.Currency, &bid.DeliveryDays,
 	)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,104 @@
+package persistence
+
+import (
+	"context"
+	"tenders/internal/domain/entity"
+	"tenders/internal/domain/repository"
+	"tenders/internal/utils/consts"
+)
+
+// ChangeEventChannel канал LISTEN/NOTIFY, в который публикуется id нового события
+const ChangeEventChannel = "change_event"
+
+// changeEventLockKey ключ advisory-блокировки, под которой выдаются id событий
+const changeEventLockKey = 7340001
+
+type ChangeEventRepo struct {
+	Conn DBTX
+}
+
+func NewChangeEventRepository(conn DBTX) *ChangeEventRepo {
+	return &ChangeEventRepo{Conn: conn}
+}
+
+var _ repository.ChangeEventRepository = &ChangeEventRepo{}
+
+// Save должен вызываться в транзакции. Блокировка до конца транзакции нужна, чтобы события фиксировались
+// в порядке id: иначе подписчик мог бы прочитать событие с большим id раньше и пропустить меньшее
+func (r *ChangeEventRepo) Save(ctx context.Context, event *entity.ChangeEvent) error {
+	if _, err := r.Conn.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, changeEventLockKey); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO change_event (
+			entity_type, entity_id, action, organization_id, author_employee_id, author_organization_id, payload, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id
+	`
+	err := r.Conn.QueryRowContext(ctx, query,
+		event.EntityType, event.EntityId, event.Action, event.OrganizationId,
+		event.AuthorEmployeeId, event.AuthorOrganizationId, []byte(event.Data), event.CreatedAt.ConvertToTime(),
+	).Scan(&event.Id)
+	if err != nil {
+		return err
+	}
+
+	// NOTIFY доставляется слушателям только после фиксации транзакции
+	_, err = r.Conn.ExecContext(ctx, `SELECT pg_notify($1, $2::text)`, ChangeEventChannel, event.Id)
+	return err
+}
+
+func (r *ChangeEventRepo) FindAfter(
+	ctx context.Context, filter repository.ChangeEventFilter, afterId int64, limit int,
+) ([]entity.ChangeEvent, error) {
+	query := `
+		SELECT id, entity_type, entity_id, action, organization_id, author_employee_id, author_organization_id,
+		       payload, created_at
+		FROM change_event
+		WHERE id > $1
+		  AND ((entity_type = $2 AND organization_id = ANY ($3::uuid[]))
+		    OR (entity_type = $4 AND (organization_id = ANY ($5::uuid[])
+		      OR author_organization_id = ANY ($5::uuid[])
+		      OR author_employee_id = $6))
+		    OR (entity_type = $7 AND organization_id = ANY ($8::uuid[])))
+		ORDER BY id
+		LIMIT $9
+	`
+	rows, err := r.Conn.QueryContext(ctx, query, afterId,
+		consts.AuditEntityTender, uuidArray(filter.TenderOrganizationIds),
+		consts.AuditEntityBid, uuidArray(filter.BidOrganizationIds), filter.EmployeeId,
+		consts.AuditEntityReview, uuidArray(filter.ReviewOrganizationIds),
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []entity.ChangeEvent{}
+	for rows.Next() {
+		var event entity.ChangeEvent
+		err = rows.Scan(
+			&event.Id, &event.EntityType, &event.EntityId, &event.Action, &event.OrganizationId,
+			&event.AuthorEmployeeId, &event.AuthorOrganizationId, &event.Data, &event.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (r *ChangeEventRepo) LastId(ctx context.Context) (int64, error) {
+	var lastId int64
+	err := r.Conn.QueryRowContext(ctx, `SELECT COALESCE(MAX(id), 0) FROM change_event`).Scan(&lastId)
+	return lastId, err
+}
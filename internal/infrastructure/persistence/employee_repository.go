@@ -1,61 +1,135 @@
 package persistence
 
 import (
+	"context"
 	"database/sql"
 	"github.com/google/uuid"
 	"tenders/internal/domain/entity"
 	"tenders/internal/domain/repository"
+	"tenders/internal/utils"
 )
 
 type EmployeeRepo struct {
-	Conn *sql.DB
+	Conn DBTX
 }
 
-func NewEmployeeRepository(conn *sql.DB) *EmployeeRepo {
+func NewEmployeeRepository(conn DBTX) *EmployeeRepo {
 	return &EmployeeRepo{Conn: conn}
 }
 
 var _ repository.EmployeeRepository = &EmployeeRepo{}
 
-func (r *EmployeeRepo) FindEmployeeIdByUsername(username string) (uuid.UUID, error) {
+const employeeColumns = `e.id, e.username, e.first_name, e.last_name, e.password_hash, e.is_active, e.created_at, e.updated_at`
+
+func (r *EmployeeRepo) Create(ctx context.Context, employee *entity.Employee) error {
+	query := `
+		INSERT INTO employee (id, username, first_name, last_name, password_hash, is_active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	_, err := r.Conn.ExecContext(ctx, query,
+		employee.Id, employee.Username, employee.FirstName, employee.LastName,
+		nullablePasswordHash(employee.PasswordHash), employee.IsActive,
+		employee.CreatedAt.UTC(), employee.UpdatedAt.UTC(),
+	)
+	if isUniqueViolation(err) {
+		return utils.UsernameTakenError
+	}
+	return err
+}
+
+func (r *EmployeeRepo) Update(ctx context.Context, employee *entity.Employee) error {
+	query := `
+		UPDATE employee
+		SET first_name = $1, last_name = $2, password_hash = $3, is_active = $4, updated_at = $5
+		WHERE id = $6
+	`
+	_, err := r.Conn.ExecContext(ctx, query,
+		employee.FirstName, employee.LastName, nullablePasswordHash(employee.PasswordHash), employee.IsActive,
+		employee.UpdatedAt.UTC(), employee.Id,
+	)
+	return err
+}
+
+func (r *EmployeeRepo) FindEmployeeIdByUsername(ctx context.Context, username string) (uuid.UUID, error) {
 	var employeeId uuid.UUID
 	query := `
         SELECT e.id 
         FROM employee e
         WHERE e.username = $1
     `
-	err := r.Conn.QueryRow(query, username).Scan(&employeeId)
+	err := r.Conn.QueryRowContext(ctx, query, username).Scan(&employeeId)
 	if err != nil {
 		return uuid.Nil, err
 	}
 	return employeeId, nil
 }
 
-func (r *EmployeeRepo) FindEmployeeIdByUsernameIfResponsibleForOrg(username string, organizationId uuid.UUID) (uuid.UUID, error) {
-	var employeeId uuid.UUID
+func (r *EmployeeRepo) FindById(ctx context.Context, id uuid.UUID) (*entity.Employee, error) {
+	query := `SELECT ` + employeeColumns + ` FROM employee e WHERE e.id = $1`
+	return scanEmployee(r.Conn.QueryRowContext(ctx, query, id))
+}
+
+func (r *EmployeeRepo) FindByUsername(ctx context.Context, username string) (*entity.Employee, error) {
+	query := `SELECT ` + employeeColumns + ` FROM employee e WHERE e.username = $1`
+	return scanEmployee(r.Conn.QueryRowContext(ctx, query, username))
+}
+
+func (r *EmployeeRepo) FindAllByOrganizationId(ctx context.Context, organizationId uuid.UUID) ([]entity.Responsible, error) {
 	query := `
-        SELECT e.id 
-        FROM employee e
-        JOIN organization_responsible org ON e.id = org.user_id
-        WHERE e.username = $1 AND org.organization_id = $2
-    `
-	err := r.Conn.QueryRow(query, username, organizationId).Scan(&employeeId)
+		SELECT ` + employeeColumns + `, ore.role
+		FROM employee e
+		JOIN organization_responsible ore ON e.id = ore.user_id
+		WHERE ore.organization_id = $1
+		ORDER BY e.username
+	`
+
+	rows, err := r.Conn.QueryContext(ctx, query, organizationId)
 	if err != nil {
-		return uuid.Nil, err
+		return nil, err
 	}
-	return employeeId, nil
-}
+	defer rows.Close()
 
-func (r *EmployeeRepo) FindById(id uuid.UUID) (*entity.Employee, error) {
-	query := `SELECT id, username, first_name, last_name, created_at, updated_at FROM employee WHERE id = $1`
+	responsibles := []entity.Responsible{}
+	for rows.Next() {
+		var role entity.ResponsibleRole
+		employee, err := scanEmployee(rows, &role)
+		if err != nil {
+			return nil, err
+		}
+		responsibles = append(responsibles, entity.Responsible{Employee: *employee, Role: role})
+	}
 
-	row := r.Conn.QueryRow(query, id)
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return responsibles, nil
+}
+
+// scanner общий интерфейс *sql.Row и *sql.Rows
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
 
+// scanEmployee читает колонки employeeColumns, а после них колонки extra
+func scanEmployee(row scanner, extra ...interface{}) (*entity.Employee, error) {
 	var employee entity.Employee
-	err := row.Scan(&employee.Id, &employee.Username, &employee.FirstName, &employee.LastName, &employee.CreatedAt, &employee.UpdatedAt)
+	var firstName, lastName, passwordHash sql.NullString
+	dest := []interface{}{
+		&employee.Id, &employee.Username, &firstName, &lastName,
+		&passwordHash, &employee.IsActive, &employee.CreatedAt, &employee.UpdatedAt,
+	}
+	err := row.Scan(append(dest, extra...)...)
 	if err != nil {
 		return nil, err
 	}
+	employee.FirstName = firstName.String
+	employee.LastName = lastName.String
+	employee.PasswordHash = passwordHash.String
 
 	return &employee, nil
 }
+
+// nullablePasswordHash сохраняет отсутствие пароля как NULL
+func nullablePasswordHash(passwordHash string) sql.NullString {
+	return sql.NullString{String: passwordHash, Valid: passwordHash != ""}
+}
@@ -0,0 +1,148 @@
+package persistence
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"tenders/internal/domain/entity"
+	"tenders/internal/domain/repository"
+)
+
+type EvaluationRepo struct {
+	Conn DBTX
+}
+
+func NewEvaluationRepository(conn DBTX) *EvaluationRepo {
+	return &EvaluationRepo{Conn: conn}
+}
+
+var _ repository.EvaluationRepository = &EvaluationRepo{}
+
+func (r *EvaluationRepo) ReplaceCriteria(
+	ctx context.Context, tenderId uuid.UUID, criteria []entity.TenderCriterion,
+) error {
+	if _, err := r.Conn.ExecContext(ctx, `DELETE FROM tender_criterion WHERE tender_id = $1`, tenderId); err != nil {
+		return err
+	}
+
+	query := `INSERT INTO tender_criterion (tender_id, criterion, weight) VALUES ($1, $2, $3)`
+	for _, criterion := range criteria {
+		if _, err := r.Conn.ExecContext(ctx, query, tenderId, criterion.Criterion, criterion.Weight); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *EvaluationRepo) FindCriteriaByTenderId(
+	ctx context.Context, tenderId uuid.UUID,
+) ([]entity.TenderCriterion, error) {
+	return r.findCriteria(ctx, `
+		SELECT criterion, weight
+		FROM tender_criterion
+		WHERE tender_id = $1
+		ORDER BY criterion
+	`, tenderId)
+}
+
+func (r *EvaluationRepo) LockCriteriaByTenderId(
+	ctx context.Context, tenderId uuid.UUID,
+) ([]entity.TenderCriterion, error) {
+	return r.findCriteria(ctx, `
+		SELECT criterion, weight
+		FROM tender_criterion
+		WHERE tender_id = $1
+		ORDER BY criterion
+		FOR SHARE
+	`, tenderId)
+}
+
+func (r *EvaluationRepo) findCriteria(
+	ctx context.Context, query string, tenderId uuid.UUID,
+) ([]entity.TenderCriterion, error) {
+	rows, err := r.Conn.QueryContext(ctx, query, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	criteria := []entity.TenderCriterion{}
+	for rows.Next() {
+		var criterion entity.TenderCriterion
+		if err = rows.Scan(&criterion.Criterion, &criterion.Weight); err != nil {
+			return nil, err
+		}
+		criteria = append(criteria, criterion)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return criteria, nil
+}
+
+func (r *EvaluationRepo) ExistsScoresByTenderId(ctx context.Context, tenderId uuid.UUID) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM bid_score s
+			JOIN bid b ON b.bid_id = s.bid_id
+			WHERE b.tender_id = $1
+		)
+	`
+	var exists bool
+	if err := r.Conn.QueryRowContext(ctx, query, tenderId).Scan(&exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+func (r *EvaluationRepo) SaveScores(ctx context.Context, scores []entity.BidScore) error {
+	query := `
+		INSERT INTO bid_score (bid_id, employee_id, criterion, score, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (bid_id, employee_id, criterion)
+		DO UPDATE SET score = EXCLUDED.score, created_at = EXCLUDED.created_at
+	`
+	for _, score := range scores {
+		_, err := r.Conn.ExecContext(ctx, query,
+			score.BidId, score.EmployeeId, score.Criterion, score.Score, score.CreatedAt.ConvertToTime().UTC(),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *EvaluationRepo) FindScoresByTenderId(ctx context.Context, tenderId uuid.UUID) ([]entity.BidScore, error) {
+	query := `
+		SELECT s.bid_id, s.employee_id, e.username, s.criterion, s.score, s.created_at
+		FROM bid_score s
+		JOIN bid b ON b.bid_id = s.bid_id
+		JOIN employee e ON e.id = s.employee_id
+		WHERE b.tender_id = $1
+		ORDER BY s.bid_id, e.username, s.employee_id, s.criterion
+	`
+	rows, err := r.Conn.QueryContext(ctx, query, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	scores := []entity.BidScore{}
+	for rows.Next() {
+		var score entity.BidScore
+		err = rows.Scan(
+			&score.BidId, &score.EmployeeId, &score.EmployeeUsername, &score.Criterion, &score.Score, &score.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		scores = append(scores, score)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return scores, nil
+}
@@ -1,60 +1,222 @@
 package persistence
 
 import (
+	"context"
 	"database/sql"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"tenders/internal/domain/entity"
 	"tenders/internal/domain/repository"
 )
 
 type OrganizationRepo struct {
-	Conn *sql.DB
+	Conn DBTX
 }
 
-func NewOrganizationRepository(conn *sql.DB) *OrganizationRepo {
+func NewOrganizationRepository(conn DBTX) *OrganizationRepo {
 	return &OrganizationRepo{Conn: conn}
 }
 
 var _ repository.OrganizationRepository = &OrganizationRepo{}
 
-func (r *OrganizationRepo) FindById(id uuid.UUID) (*entity.Organization, error) {
+const organizationColumns = `o.id, o.name, o.description, o.type, o.is_active, o.created_at, o.updated_at`
+
+func (r *OrganizationRepo) Create(ctx context.Context, organization *entity.Organization) error {
 	query := `
-		SELECT id, name, description, type, created_at, updated_at
-		FROM organization
-		WHERE id = $1
+		INSERT INTO organization (id, name, description, type, is_active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
 	`
+	_, err := r.Conn.ExecContext(ctx, query,
+		organization.Id, organization.Name, organization.Description, organization.Type, organization.IsActive,
+		organization.CreatedAt.UTC(), organization.UpdatedAt.UTC(),
+	)
+	return err
+}
 
-	var org entity.Organization
-	err := r.Conn.QueryRow(query, id).Scan(
-		&org.Id,
-		&org.Name,
-		&org.Description,
-		&org.Type,
-		&org.CreatedAt,
-		&org.UpdatedAt,
+func (r *OrganizationRepo) Update(ctx context.Context, organization *entity.Organization) error {
+	query := `
+		UPDATE organization
+		SET name = $1, description = $2, type = $3, is_active = $4, updated_at = $5
+		WHERE id = $6
+	`
+	_, err := r.Conn.ExecContext(ctx, query,
+		organization.Name, organization.Description, organization.Type, organization.IsActive,
+		organization.UpdatedAt.UTC(), organization.Id,
 	)
+	return err
+}
+
+func (r *OrganizationRepo) FindById(ctx context.Context, id uuid.UUID) (*entity.Organization, error) {
+	query := `SELECT ` + organizationColumns + ` FROM organization o WHERE o.id = $1`
+	return scanOrganization(r.Conn.QueryRowContext(ctx, query, id))
+}
 
+func (r *OrganizationRepo) FindByIdForUpdate(ctx context.Context, id uuid.UUID) (*entity.Organization, error) {
+	query := `SELECT ` + organizationColumns + ` FROM organization o WHERE o.id = $1 FOR UPDATE`
+	return scanOrganization(r.Conn.QueryRowContext(ctx, query, id))
+}
+
+func (r *OrganizationRepo) FindAllByEmployeeId(ctx context.Context, employeeId uuid.UUID) ([]entity.Organization, error) {
+	query := `
+		SELECT ` + organizationColumns + `
+		FROM organization o
+		JOIN organization_responsible ore ON o.id = ore.organization_id
+		WHERE ore.user_id = $1 AND o.is_active
+		ORDER BY o.name, o.id
+	`
+
+	rows, err := r.Conn.QueryContext(ctx, query, employeeId)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
-	return &org, nil
+	organizations := []entity.Organization{}
+	for rows.Next() {
+		organization, err := scanOrganization(rows)
+		if err != nil {
+			return nil, err
+		}
+		organizations = append(organizations, *organization)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return organizations, nil
 }
 
-func (r *OrganizationRepo) FindByEmployeeId(employeeId uuid.UUID) (*entity.Organization, error) {
+func (r *OrganizationRepo) CountResponsibles(
+	ctx context.Context, organizationId uuid.UUID, roles []entity.ResponsibleRole,
+) (int, error) {
 	query := `
-		SELECT o.id, o.name, o.description, o.type, o.created_at, o.updated_at
-		FROM organization o
-		JOIN organization_responsible ore ON o.id = ore.organization_id
-		WHERE ore.user_id = $1
+		SELECT COUNT(*)
+		FROM organization_responsible ore
+		JOIN employee e ON e.id = ore.user_id
+		WHERE ore.organization_id = $1 AND ore.role::text = ANY($2) AND e.is_active
+	`
+
+	var count int
+	if err := r.Conn.QueryRowContext(ctx, query, organizationId, roleArray(roles)).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *OrganizationRepo) FindRole(
+	ctx context.Context, organizationId, employeeId uuid.UUID,
+) (entity.ResponsibleRole, error) {
+	query := `
+		SELECT ore.role
+		FROM organization_responsible ore
+		JOIN organization o ON o.id = ore.organization_id
+		WHERE ore.organization_id = $1 AND ore.user_id = $2 AND o.is_active
 	`
 
+	var role entity.ResponsibleRole
+	if err := r.Conn.QueryRowContext(ctx, query, organizationId, employeeId).Scan(&role); err != nil {
+		return "", err
+	}
+	return role, nil
+}
+
+func (r *OrganizationRepo) HasRole(
+	ctx context.Context, employeeId uuid.UUID, roles []entity.ResponsibleRole,
+) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM organization_responsible ore
+			JOIN organization o ON o.id = ore.organization_id
+			WHERE ore.user_id = $1 AND ore.role::text = ANY($2) AND o.is_active
+		)
+	`
+
+	var exists bool
+	if err := r.Conn.QueryRowContext(ctx, query, employeeId, roleArray(roles)).Scan(&exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+func (r *OrganizationRepo) ShareActiveOrganization(
+	ctx context.Context, managerId, employeeId uuid.UUID, managerRoles []entity.ResponsibleRole,
+) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM organization_responsible manager
+			JOIN organization_responsible managed ON managed.organization_id = manager.organization_id
+			JOIN organization o ON o.id = manager.organization_id
+			WHERE manager.user_id = $1 AND managed.user_id = $2 AND manager.role::text = ANY($3) AND o.is_active
+		)
+	`
+
+	var exists bool
+	err := r.Conn.QueryRowContext(ctx, query, managerId, employeeId, roleArray(managerRoles)).Scan(&exists)
+	if err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+func (r *OrganizationRepo) AddResponsible(
+	ctx context.Context, organizationId, employeeId uuid.UUID, role entity.ResponsibleRole,
+) error {
+	query := `
+		INSERT INTO organization_responsible (organization_id, user_id, role)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (organization_id, user_id) DO UPDATE SET role = EXCLUDED.role
+	`
+	_, err := r.Conn.ExecContext(ctx, query, organizationId, employeeId, role)
+	return err
+}
+
+func (r *OrganizationRepo) RemoveResponsible(ctx context.Context, organizationId, employeeId uuid.UUID) error {
+	query := `DELETE FROM organization_responsible WHERE organization_id = $1 AND user_id = $2`
+
+	result, err := r.Conn.ExecContext(ctx, query, organizationId, employeeId)
+	if err != nil {
+		return err
+	}
+
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if removed == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func roleArray(roles []entity.ResponsibleRole) pq.StringArray {
+	array := make(pq.StringArray, 0, len(roles))
+	for _, role := range roles {
+		array = append(array, string(role))
+	}
+	return array
+}
+
+// uuidArray передает список id как uuid[] для сравнения через ANY
+func uuidArray(ids []uuid.UUID) pq.StringArray {
+	array := make(pq.StringArray, 0, len(ids))
+	for _, id := range ids {
+		array = append(array, id.String())
+	}
+	return array
+}
+
+func scanOrganization(row scanner) (*entity.Organization, error) {
 	var org entity.Organization
-	err := r.Conn.QueryRow(query, employeeId).Scan(
+	var description sql.NullString
+	err := row.Scan(
 		&org.Id,
 		&org.Name,
-		&org.Description,
+		&description,
 		&org.Type,
+		&org.IsActive,
 		&org.CreatedAt,
 		&org.UpdatedAt,
 	)
@@ -62,6 +224,7 @@ func (r *OrganizationRepo) FindByEmployeeId(employeeId uuid.UUID) (*entity.Organ
 	if err != nil {
 		return nil, err
 	}
+	org.Description = description.String
 
 	return &org, nil
 }
@@ -0,0 +1,28 @@
+package persistence
+
+import (
+	"context"
+	"tenders/internal/domain/entity"
+	"tenders/internal/domain/repository"
+)
+
+type OutboxRepo struct {
+	Conn DBTX
+}
+
+func NewOutboxRepository(conn DBTX) *OutboxRepo {
+	return &OutboxRepo{Conn: conn}
+}
+
+var _ repository.OutboxRepository = &OutboxRepo{}
+
+func (r *OutboxRepo) Save(ctx context.Context, event *entity.OutboxEvent) error {
+	query := `
+		INSERT INTO outbox_event (id, organization_id, event_type, payload, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+	_, err := r.Conn.ExecContext(ctx, query,
+		event.Id, event.OrganizationId, event.EventType, []byte(event.Payload), event.CreatedAt.ConvertToTime(),
+	)
+	return err
+}
@@ -0,0 +1,119 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"tenders/internal/domain/repository"
+	"tenders/internal/utils/common/custom_types"
+)
+
+// sortColumn выражение сортировки по полю и тип, к которому приводится значение курсора.
+// Если задано group, записи сначала упорядочиваются по нему, его значение хранится в Cursor.Group
+type sortColumn struct {
+	group      string
+	expression string
+	cast       string
+}
+
+var sortColumns = map[string]sortColumn{
+	repository.SortByName:      {expression: "name", cast: "text"},
+	repository.SortByCreatedAt: {expression: "created_at", cast: "timestamp"},
+	repository.SortByVersion:   {expression: "version", cast: "int"},
+	repository.SortByStatus:    {expression: "status::text", cast: "text"},
+	// Выражения совпадают с индексом bid_tender_currency_price_idx, суммы в разных валютах между собой не сравниваются
+	repository.SortByPrice: {
+		group:      "COALESCE(currency::text, '" + repository.PriceCursorGroupUnset + "')",
+		expression: "COALESCE(amount, '" + repository.PriceCursorKeyUnset + "')",
+		cast:       "numeric",
+	},
+}
+
+// keysetPage дописывает к запросу, который заканчивается условиями WHERE, продолжение после курсора,
+// сортировку по (группа, ключ, idColumn) и лимит. Ключ задается page.Sort, по умолчанию это defaultKey по возрастанию.
+// Колонки таблицы берутся с префиксом alias. Без курсора страница выбирается по смещению
+func keysetPage(
+	queryStr string, queryArgs []interface{}, page repository.Page, alias, defaultKey, idColumn string,
+) (string, []interface{}) {
+	column := sortColumn{expression: defaultKey, cast: "text"}
+	if page.Sort.Field != "" {
+		column = sortColumns[page.Sort.Field]
+	}
+	columns := []string{alias + column.expression, alias + idColumn}
+	if column.group != "" {
+		columns = append([]string{alias + column.group}, columns...)
+	}
+
+	direction, comparison := "ASC", ">"
+	if page.Sort.Desc {
+		direction, comparison = "DESC", "<"
+	}
+
+	if page.After != nil {
+		var values []string
+		if column.group != "" {
+			queryArgs = append(queryArgs, page.After.Group)
+			values = append(values, fmt.Sprintf("$%d::text", len(queryArgs)))
+		}
+		queryArgs = append(queryArgs, page.After.Key, page.After.Id)
+		values = append(values, fmt.Sprintf("$%d::%s", len(queryArgs)-1, column.cast), fmt.Sprintf("$%d", len(queryArgs)))
+		queryStr += fmt.Sprintf(" AND (%s) %s (%s)", strings.Join(columns, ", "), comparison, strings.Join(values, ", "))
+	}
+
+	order := make([]string, 0, len(columns))
+	for _, orderColumn := range columns {
+		order = append(order, orderColumn+" "+direction)
+	}
+	queryArgs = append(queryArgs, page.Limit)
+	queryStr += fmt.Sprintf(" ORDER BY %s LIMIT $%d", strings.Join(order, ", "), len(queryArgs))
+
+	if page.After == nil {
+		queryArgs = append(queryArgs, page.Offset)
+		queryStr += fmt.Sprintf(" OFFSET $%d", len(queryArgs))
+	}
+	return queryStr, queryArgs
+}
+
+// sortKey значение ключа сортировки записи для курсора
+func sortKey(sort repository.Sort, name, status string, version int, createdAt custom_types.RFC3339Time) string {
+	switch sort.Field {
+	case repository.SortByCreatedAt:
+		return createdAt.ConvertToTime().UTC().Format(repository.CursorTimeLayout)
+	case repository.SortByVersion:
+		return strconv.Itoa(version)
+	case repository.SortByStatus:
+		return status
+	default:
+		return name
+	}
+}
+
+// countTotal считает записи запроса без страницы, если общее число запрошено в page
+func countTotal(
+	ctx context.Context, conn DBTX, page repository.Page, queryStr string, queryArgs ...interface{},
+) (*int, error) {
+	if !page.WithTotal {
+		return nil, nil
+	}
+
+	var total int
+	err := conn.QueryRowContext(ctx, "SELECT COUNT(*) FROM ("+queryStr+") AS list", queryArgs...).Scan(&total)
+	if err != nil {
+		return nil, err
+	}
+	return &total, nil
+}
+
+// newPageResult собирает страницу. Курсор выдается только для полной страницы, на неполной список закончился
+func newPageResult[T any](
+	items []T, page repository.Page, total *int, cursor func(T) repository.Cursor,
+) *repository.PageResult[T] {
+	result := &repository.PageResult[T]{Items: items, Total: total}
+	if len(items) > 0 && len(items) == page.Limit {
+		next := cursor(items[len(items)-1])
+		next.Sort = page.Sort.String()
+		result.Next = &next
+	}
+	return result
+}
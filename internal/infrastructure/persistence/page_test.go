@@ -0,0 +1,73 @@
+package persistence
+
+import (
+	"github.com/google/uuid"
+	"reflect"
+	"tenders/internal/domain/repository"
+	"testing"
+)
+
+func TestKeysetPage(t *testing.T) {
+	id := uuid.New()
+	base := "SELECT * FROM bid WHERE tender_id = $1"
+
+	tests := []struct {
+		name      string
+		page      repository.Page
+		alias     string
+		wantQuery string
+		wantArgs  []interface{}
+	}{
+		{
+			name:      "default key with offset",
+			page:      repository.Page{Limit: 5, Offset: 10},
+			wantQuery: base + " ORDER BY name ASC, bid_id ASC LIMIT $2 OFFSET $3",
+			wantArgs:  []interface{}{"t1", 5, 10},
+		},
+		{
+			name:      "cursor ignores offset",
+			page:      repository.Page{Limit: 5, Offset: 10, After: &repository.Cursor{Key: "b", Id: id}},
+			wantQuery: base + " AND (name, bid_id) > ($2::text, $3) ORDER BY name ASC, bid_id ASC LIMIT $4",
+			wantArgs:  []interface{}{"t1", "b", id, 5},
+		},
+		{
+			name: "descending with cast",
+			page: repository.Page{
+				Limit: 3, Sort: repository.Sort{Field: repository.SortByVersion, Desc: true},
+				After: &repository.Cursor{Key: "4", Id: id},
+			},
+			wantQuery: base + " AND (version, bid_id) < ($2::int, $3) ORDER BY version DESC, bid_id DESC LIMIT $4",
+			wantArgs:  []interface{}{"t1", "4", id, 3},
+		},
+		{
+			name:      "alias",
+			page:      repository.Page{Limit: 2, Sort: repository.Sort{Field: repository.SortByCreatedAt}},
+			alias:     "t.",
+			wantQuery: base + " ORDER BY t.created_at ASC, t.bid_id ASC LIMIT $2 OFFSET $3",
+			wantArgs:  []interface{}{"t1", 2, 0},
+		},
+		{
+			name: "price groups by currency",
+			page: repository.Page{
+				Limit: 5, Sort: repository.Sort{Field: repository.SortByPrice},
+				After: &repository.Cursor{Group: "RUB", Key: "100", Id: id},
+			},
+			wantQuery: base + " AND (COALESCE(currency::text, 'ZZZZ'), COALESCE(amount, 'Infinity'), bid_id)" +
+				" > ($2::text, $3::numeric, $4)" +
+				" ORDER BY COALESCE(currency::text, 'ZZZZ') ASC, COALESCE(amount, 'Infinity') ASC, bid_id ASC LIMIT $5",
+			wantArgs: []interface{}{"t1", "RUB", "100", id, 5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args := keysetPage(base, []interface{}{"t1"}, tt.page, tt.alias, "name", "bid_id")
+			if query != tt.wantQuery {
+				t.Errorf("query =\n%s\nwant\n%s", query, tt.wantQuery)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("args = %v, want %v", args, tt.wantArgs)
+			}
+		})
+	}
+}
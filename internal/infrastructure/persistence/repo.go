@@ -1,16 +1,33 @@
 package persistence
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"github.com/lib/pq"
 	"tenders/internal/domain/repository"
 )
 
+// DBTX общий интерфейс *sql.DB и *sql.Tx, благодаря которому одни и те же репозитории работают и в транзакции, и без нее
+type DBTX interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
 type Repositories struct {
 	TenderRepo       repository.TenderRepository
 	EmployeeRepo     repository.EmployeeRepository
 	OrganizationRepo repository.OrganizationRepository
 	BidRepo          repository.BidRepository
 	ReviewRepo       repository.ReviewRepository
+	BidDecisionRepo  repository.BidDecisionRepository
+	EvaluationRepo   repository.EvaluationRepository
+	AuditRepo        repository.AuditRepository
+	WebhookRepo      repository.WebhookSubscriptionRepository
+	DeliveryRepo     repository.WebhookDeliveryRepository
+	ChangeEventRepo  repository.ChangeEventRepository
+	UnitOfWork       repository.UnitOfWork
 	Db               *sql.DB
 }
 
@@ -21,10 +38,28 @@ func NewRepositories(conn *sql.DB) *Repositories {
 		OrganizationRepo: NewOrganizationRepository(conn),
 		BidRepo:          NewBidRepository(conn),
 		ReviewRepo:       NewReviewRepository(conn),
+		BidDecisionRepo:  NewBidDecisionRepository(conn),
+		EvaluationRepo:   NewEvaluationRepository(conn),
+		AuditRepo:        NewAuditRepository(conn),
+		WebhookRepo:      NewWebhookSubscriptionRepository(conn),
+		DeliveryRepo:     NewWebhookDeliveryRepository(conn),
+		ChangeEventRepo:  NewChangeEventRepository(conn),
+		UnitOfWork:       NewUnitOfWork(conn),
 		Db:               conn,
 	}
 }
 
+// isUniqueViolation проверяет, что запрос нарушил уникальный индекс или первичный ключ
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
+// nullableCurrency сохраняет незаданную валюту как NULL
+func nullableCurrency(currency string) sql.NullString {
+	return sql.NullString{String: currency, Valid: currency != ""}
+}
+
 func (r *Repositories) Close() error {
 	return r.Db.Close()
 }
@@ -1,26 +1,26 @@
 package persistence
 
 import (
-	"database/sql"
+	"context"
 	"github.com/google/uuid"
 	"tenders/internal/domain/entity"
 	"tenders/internal/domain/repository"
 )
 
 type ReviewRepo struct {
-	Conn *sql.DB
+	Conn DBTX
 }
 
-func NewReviewRepository(conn *sql.DB) *ReviewRepo {
+func NewReviewRepository(conn DBTX) *ReviewRepo {
 	return &ReviewRepo{Conn: conn}
 }
 
 var _ repository.ReviewRepository = &ReviewRepo{}
 
-func (r *ReviewRepo) Create(review *entity.Review) (*entity.Review, error) {
+func (r *ReviewRepo) Create(ctx context.Context, review *entity.Review) (*entity.Review, error) {
 	query := `INSERT INTO review (id, bid_id, description, created_at) 
 	    VALUES ($1, $2, $3, $4) RETURNING created_at`
-	err := r.Conn.QueryRow(query,
+	err := r.Conn.QueryRowContext(ctx, query,
 		review.Id, review.BidId, review.Description, review.CreatedAt.ConvertToTime(),
 	).Scan(&review.CreatedAt)
 	if err != nil {
@@ -29,8 +29,10 @@ func (r *ReviewRepo) Create(review *entity.Review) (*entity.Review, error) {
 	return review, nil
 }
 
-func (r *ReviewRepo) FindAllByBidAuthor(authorId uuid.UUID, limit, offset int) ([]entity.Review, error) {
-	query := `
+func (r *ReviewRepo) FindAllByBidAuthor(
+	ctx context.Context, authorId uuid.UUID, page repository.Page,
+) (*repository.PageResult[entity.Review], error) {
+	queryStr := `
         SELECT id, bid_id, description, created_at
         FROM review
         WHERE bid_id IN (
@@ -38,10 +40,16 @@ func (r *ReviewRepo) FindAllByBidAuthor(authorId uuid.UUID, limit, offset int) (
             FROM bid
             WHERE author_id = $1
         )
-        ORDER BY description ASC
-        LIMIT $2 OFFSET $3
     `
-	rows, err := r.Conn.Query(query, authorId, limit, offset)
+	queryArgs := []interface{}{authorId}
+
+	total, err := countTotal(ctx, r.Conn, page, queryStr, queryArgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	queryStr, queryArgs = keysetPage(queryStr, queryArgs, page, "", "description", "id")
+	rows, err := r.Conn.QueryContext(ctx, queryStr, queryArgs...)
 	if err != nil {
 		return nil, err
 	}
@@ -57,5 +65,11 @@ func (r *ReviewRepo) FindAllByBidAuthor(authorId uuid.UUID, limit, offset int) (
 		reviews = append(reviews, review)
 	}
 
-	return reviews, nil
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return newPageResult(reviews, page, total, func(review entity.Review) repository.Cursor {
+		return repository.Cursor{Key: review.Description, Id: review.Id}
+	}), nil
 }
@@ -1,116 +1,184 @@
 package persistence
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"strings"
 	"tenders/internal/domain/entity"
 	"tenders/internal/domain/repository"
+	"tenders/internal/utils"
+	"tenders/internal/utils/common/custom_types"
+	"tenders/internal/utils/consts"
+	"time"
 )
 
 type TenderRepo struct {
-	Conn *sql.DB
+	Conn DBTX
 }
 
-func NewTenderRepository(conn *sql.DB) *TenderRepo {
+func NewTenderRepository(conn DBTX) *TenderRepo {
 	return &TenderRepo{Conn: conn}
 }
 
 var _ repository.TenderRepository = &TenderRepo{}
 
-func (r *TenderRepo) Create(tender *entity.Tender) (*entity.Tender, error) {
+// Create сохраняет новую версию тендера в историю и делает ее текущей.
+// Текущая версия заменяется, только если она предшествует новой, иначе возвращается VersionConflictError.
+// В истории creator_id и created_at автор и время создания версии, у текущего тендера остаются автор и время
+// создания самого тендера. Возвращается текущий тендер, как его отдает FindByTenderId
+func (r *TenderRepo) Create(ctx context.Context, tender *entity.Tender) (*entity.Tender, error) {
 	insertQuery := `
-        INSERT INTO tender (
+        INSERT INTO tender_history (
             name, description, service_type, status,
-            organization_id, creator_id, created_at, tender_id, version
+            organization_id, creator_id, created_at, tender_id, version,
+            submission_deadline, decision_deadline, budget, currency
         ) 
-        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING created_at
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13) RETURNING created_at
     `
 	created := tender.CreatedAt.ConvertToTime()
 
-	err := r.Conn.QueryRow(insertQuery,
+	err := r.Conn.QueryRowContext(ctx, insertQuery,
 		tender.Name, tender.Description, tender.ServiceType, tender.Status, tender.OrganizationID,
-		tender.CreatorID, created, tender.TenderId, tender.Version,
+		nullableCreator(tender.CreatorID), created, tender.TenderId, tender.Version,
+		nullableTime(tender.SubmissionDeadline), nullableTime(tender.DecisionDeadline),
+		tender.Budget, nullableCurrency(tender.Currency),
 	).Scan(&tender.CreatedAt)
 
 	if err != nil {
+		// Версия с таким номером уже создана параллельным запросом
+		if isUniqueViolation(err) {
+			return nil, utils.VersionConflictError
+		}
+		return nil, err
+	}
+
+	upsertQuery := `
+        INSERT INTO tender (
+            name, description, service_type, status,
+            organization_id, creator_id, created_at, tender_id, version,
+            submission_deadline, decision_deadline, budget, currency
+        )
+        SELECT name, description, service_type, status,
+               organization_id, creator_id, created_at, tender_id, version,
+               submission_deadline, decision_deadline, budget, currency
+        FROM tender_history
+        WHERE tender_id = $1 AND version = $2
+        ON CONFLICT (tender_id) DO UPDATE
+        SET name = EXCLUDED.name, description = EXCLUDED.description, service_type = EXCLUDED.service_type,
+            status = EXCLUDED.status, organization_id = EXCLUDED.organization_id,
+            version = EXCLUDED.version,
+            submission_deadline = EXCLUDED.submission_deadline, decision_deadline = EXCLUDED.decision_deadline,
+            budget = EXCLUDED.budget, currency = EXCLUDED.currency
+        WHERE tender.version = EXCLUDED.version - 1
+        RETURNING creator_id, created_at
+    `
+	// Создатель текущего тендера может быть NULL, его значение не должно остаться от автора версии
+	var creatorId uuid.NullUUID
+	err = r.Conn.QueryRowContext(ctx, upsertQuery, tender.TenderId, tender.Version).Scan(&creatorId, &tender.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.VersionConflictError
+		}
 		return nil, err
 	}
+	tender.CreatorID = creatorId.UUID
 	return tender, nil
 }
 
-// FindAllAvailableByOrganizationId находит список тендеров организцаии к который принадлежит работник
-func (r *TenderRepo) FindAllAvailableByOrganizationId(organizationId uuid.UUID, limit, offset int) ([]entity.Tender, error) {
-	tenders := []entity.Tender{}
+// FindAllAvailableByOrganizationIds находит список тендеров организаций, за которые отвечает работник
+func (r *TenderRepo) FindAllAvailableByOrganizationIds(
+	ctx context.Context, organizationIds []uuid.UUID, filter repository.TenderFilter, page repository.Page,
+) (*repository.PageResult[entity.Tender], error) {
+	conditions, queryArgs := tenderConditions(filter, false)
+	queryArgs = append(queryArgs, uuidArray(organizationIds))
+	conditions = append(conditions, fmt.Sprintf("t.organization_id = ANY($%d::uuid[])", len(queryArgs)))
+
 	queryStr := `
-		SELECT DISTINCT ON (t.tender_id, t.name) t.tender_id, t.name, t.description, t.service_type, t.status, t.version,
-		       t.organization_id, t.creator_id, t.created_at
+		SELECT t.tender_id, t.name, t.description, t.service_type, t.status, t.version,
+		       t.organization_id, t.creator_id, t.created_at, t.submission_deadline, t.decision_deadline,
+		       t.budget, COALESCE(t.currency, '')
 		FROM tender t
-		WHERE t.organization_id = $1 AND t.version = (
-			SELECT MAX(version)
-			FROM tender
-			WHERE tender_id = t.tender_id
-		)
-		ORDER BY t.name ASC LIMIT $2 OFFSET $3
-	`
+		WHERE ` + strings.Join(conditions, " AND ")
+	return r.findTenderPage(ctx, queryStr, queryArgs, page)
+}
 
-	rows, err := r.Conn.Query(queryStr, organizationId, limit, offset)
-	if err != nil {
-		return nil, err
+// searchQuery объединяет разбор запроса русской и английской конфигурациями
+const searchQuery = "(websearch_to_tsquery('russian', $%[1]d) || websearch_to_tsquery('english', $%[1]d))"
+
+// headlineOptions подсветка совпадений для ts_headline. Конфигурация russian разбирает латиницу
+// английским стеммером, поэтому подсвечиваются совпадения на обоих языках
+const headlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15, MaxFragments=2"
+
+// tenderConditions условия выборки текущих версий тендеров по filter, с published только опубликованных
+func tenderConditions(filter repository.TenderFilter, published bool) ([]string, []interface{}) {
+	var conditions []string
+	if published {
+		// Условие записано литералом, чтобы планировщик мог использовать частичные индексы по опубликованным
+		conditions = append(conditions, fmt.Sprintf("t.status = '%s'", consts.TenderPublished))
 	}
-	defer rows.Close()
+	var queryArgs []interface{}
 
-	for rows.Next() {
-		var tender entity.Tender
-		err = rows.Scan(
-			&tender.TenderId, &tender.Name, &tender.Description,
-			&tender.ServiceType, &tender.Status, &tender.Version,
-			&tender.OrganizationID, &tender.CreatorID, &tender.CreatedAt,
-		)
-		if err != nil {
-			return nil, err
-		}
+	addCondition := func(condition string, arg interface{}) {
+		queryArgs = append(queryArgs, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(queryArgs)))
+	}
 
-		tenders = append(tenders, tender)
+	if len(filter.ServiceTypes) > 0 {
+		addCondition("t.service_type::text = ANY($%d::text[])", pq.StringArray(filter.ServiceTypes))
+	}
+	if len(filter.Statuses) > 0 {
+		addCondition("t.status::text = ANY($%d::text[])", pq.StringArray(filter.Statuses))
+	}
+	if filter.OrganizationId != uuid.Nil {
+		addCondition("t.organization_id = $%d", filter.OrganizationId)
+	}
+	if !filter.CreatedFrom.IsZero() {
+		addCondition("t.created_at >= $%d", filter.CreatedFrom.UTC())
+	}
+	if !filter.CreatedTo.IsZero() {
+		addCondition("t.created_at < $%d", filter.CreatedTo.UTC())
+	}
+	if filter.Query != "" {
+		addCondition("t.search_vector @@ "+searchQuery, filter.Query)
 	}
 
-	if err = rows.Err(); err != nil {
-		return nil, err
+	if len(conditions) == 0 {
+		conditions = append(conditions, "TRUE")
 	}
 
-	return tenders, nil
+	return conditions, queryArgs
 }
 
-func (r *TenderRepo) FindAllPublished(serviceTypes []string, limit, offset int) ([]entity.Tender, error) {
-	queryStr := `SELECT tender_id, name, description, service_type, status,
-       	version, organization_id, creator_id, created_at 
-		FROM tender t
-		WHERE status = 'Published' AND t.version = (
-			SELECT MAX(version)
-			FROM tender
-			WHERE tender_id = t.tender_id
-		)`
+func (r *TenderRepo) FindAllPublished(
+	ctx context.Context, filter repository.TenderFilter, page repository.Page,
+) (*repository.PageResult[entity.Tender], error) {
+	conditions, queryArgs := tenderConditions(filter, true)
 
-	argIndex := 1
-	var queryArgs []interface{}
+	queryStr := `
+		SELECT t.tender_id, t.name, t.description, t.service_type, t.status, t.version,
+		       t.organization_id, t.creator_id, t.created_at, t.submission_deadline, t.decision_deadline,
+		       t.budget, COALESCE(t.currency, '')
+		FROM tender t
+		WHERE ` + strings.Join(conditions, " AND ")
+	return r.findTenderPage(ctx, queryStr, queryArgs, page)
+}
 
-	// Добавление фильтрации по типу услуг, если есть фильтры
-	if len(serviceTypes) > 0 {
-		placeholders := make([]string, len(serviceTypes))
-		for i, serviceType := range serviceTypes {
-			placeholders[i] = fmt.Sprintf("$%d", i+1)
-			queryArgs = append(queryArgs, serviceType)
-			argIndex++
-		}
-		queryStr += fmt.Sprintf(" AND service_type IN (%s)", strings.Join(placeholders, ","))
+// findTenderPage выбирает страницу тендеров запроса queryStr в порядке page.Sort, по умолчанию по названию.
+// Совпадающие значения ключа идут по id
+func (r *TenderRepo) findTenderPage(
+	ctx context.Context, queryStr string, queryArgs []interface{}, page repository.Page,
+) (*repository.PageResult[entity.Tender], error) {
+	total, err := countTotal(ctx, r.Conn, page, queryStr, queryArgs...)
+	if err != nil {
+		return nil, err
 	}
 
-	// Добавление сортировки и лимита с оффсетом
-	queryStr += fmt.Sprintf(" ORDER BY name ASC LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
-
-	queryArgs = append(queryArgs, limit, offset)
-	rows, err := r.Conn.Query(queryStr, queryArgs...)
+	queryStr, queryArgs = keysetPage(queryStr, queryArgs, page, "t.", "name", "tender_id")
+	rows, err := r.Conn.QueryContext(ctx, queryStr, queryArgs...)
 	if err != nil {
 		return nil, err
 	}
@@ -123,6 +191,7 @@ func (r *TenderRepo) FindAllPublished(serviceTypes []string, limit, offset int)
 			&tender.TenderId, &tender.Name, &tender.Description,
 			&tender.ServiceType, &tender.Status, &tender.Version,
 			&tender.OrganizationID, &tender.CreatorID, &tender.CreatedAt,
+			&tender.SubmissionDeadline, &tender.DecisionDeadline, &tender.Budget, &tender.Currency,
 		)
 		if err != nil {
 			return nil, err
@@ -135,21 +204,29 @@ func (r *TenderRepo) FindAllPublished(serviceTypes []string, limit, offset int)
 		return nil, err
 	}
 
-	return tenders, nil
+	return newPageResult(tenders, page, total, func(tender entity.Tender) repository.Cursor {
+		return repository.Cursor{Key: tenderSortKey(page.Sort, tender), Id: tender.TenderId}
+	}), nil
 }
 
-func (r *TenderRepo) FindByTenderId(tenderId uuid.UUID) (*entity.Tender, error) {
+func tenderSortKey(sort repository.Sort, tender entity.Tender) string {
+	return sortKey(sort, tender.Name, tender.Status, tender.Version, tender.CreatedAt)
+}
+
+func (r *TenderRepo) FindByTenderId(ctx context.Context, tenderId uuid.UUID) (*entity.Tender, error) {
 	var tender entity.Tender
 	queryStr := `
 		SELECT tender_id, name, description, service_type, status, version,
-		       organization_id, creator_id, created_at
+		       organization_id, creator_id, created_at, submission_deadline, decision_deadline,
+		       budget, COALESCE(currency, '')
 		FROM tender
-		WHERE tender_id = $1 ORDER BY version DESC LIMIT 1
+		WHERE tender_id = $1
 	`
-	err := r.Conn.QueryRow(queryStr, tenderId).Scan(
+	err := r.Conn.QueryRowContext(ctx, queryStr, tenderId).Scan(
 		&tender.TenderId, &tender.Name, &tender.Description,
 		&tender.ServiceType, &tender.Status, &tender.Version,
 		&tender.OrganizationID, &tender.CreatorID, &tender.CreatedAt,
+		&tender.SubmissionDeadline, &tender.DecisionDeadline, &tender.Budget, &tender.Currency,
 	)
 	if err != nil {
 		return nil, err
@@ -158,18 +235,20 @@ func (r *TenderRepo) FindByTenderId(tenderId uuid.UUID) (*entity.Tender, error)
 	return &tender, nil
 }
 
-func (r *TenderRepo) FindByTenderIdAndVersion(tenderId uuid.UUID, version int) (*entity.Tender, error) {
+func (r *TenderRepo) FindByTenderIdAndVersion(ctx context.Context, tenderId uuid.UUID, version int) (*entity.Tender, error) {
 	var tender entity.Tender
 	queryStr := `
 		SELECT tender_id, name, description, service_type, status, version,
-		       organization_id, creator_id, created_at
-		FROM tender
+		       organization_id, creator_id, created_at, submission_deadline, decision_deadline,
+		       budget, COALESCE(currency, '')
+		FROM tender_history
 		WHERE tender_id = $1 AND version = $2
 	`
-	err := r.Conn.QueryRow(queryStr, tenderId, version).Scan(
+	err := r.Conn.QueryRowContext(ctx, queryStr, tenderId, version).Scan(
 		&tender.TenderId, &tender.Name, &tender.Description,
 		&tender.ServiceType, &tender.Status, &tender.Version,
 		&tender.OrganizationID, &tender.CreatorID, &tender.CreatedAt,
+		&tender.SubmissionDeadline, &tender.DecisionDeadline, &tender.Budget, &tender.Currency,
 	)
 	if err != nil {
 		return nil, err
@@ -177,36 +256,229 @@ func (r *TenderRepo) FindByTenderIdAndVersion(tenderId uuid.UUID, version int) (
 	return &tender, nil
 }
 
-func (r *TenderRepo) FindLatestVersionByTenderId(tenderId uuid.UUID) (int, error) {
+// FindAllVersionsByTenderId возвращает историю версий тендера от новых к старым
+func (r *TenderRepo) FindAllVersionsByTenderId(
+	ctx context.Context, tenderId uuid.UUID, limit, offset int,
+) ([]entity.TenderVersion, error) {
+	queryStr := `
+		SELECT t.tender_id, t.name, t.description, t.service_type, t.status, t.version,
+		       t.organization_id, t.creator_id, t.created_at, t.submission_deadline, t.decision_deadline,
+		       t.budget, COALESCE(t.currency, ''), e.username
+		FROM tender_history t
+		LEFT JOIN employee e ON t.creator_id = e.id
+		WHERE t.tender_id = $1
+		ORDER BY t.version DESC LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.Conn.QueryContext(ctx, queryStr, tenderId, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	versions := []entity.TenderVersion{}
+	for rows.Next() {
+		var version entity.TenderVersion
+		var creatorId uuid.NullUUID
+		var creatorUsername sql.NullString
+		err = rows.Scan(
+			&version.TenderId, &version.Name, &version.Description,
+			&version.ServiceType, &version.Status, &version.Version,
+			&version.OrganizationID, &creatorId, &version.CreatedAt,
+			&version.SubmissionDeadline, &version.DecisionDeadline,
+			&version.Budget, &version.Currency, &creatorUsername,
+		)
+		if err != nil {
+			return nil, err
+		}
+		version.CreatorID, version.CreatorUsername = creatorId.UUID, creatorUsername.String
+
+		versions = append(versions, version)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+func (r *TenderRepo) FindVersionByTenderIdAndVersion(
+	ctx context.Context, tenderId uuid.UUID, version int,
+) (*entity.TenderVersion, error) {
+	var tenderVersion entity.TenderVersion
+	var creatorId uuid.NullUUID
+	var creatorUsername sql.NullString
+	queryStr := `
+		SELECT t.tender_id, t.name, t.description, t.service_type, t.status, t.version,
+		       t.organization_id, t.creator_id, t.created_at, t.submission_deadline, t.decision_deadline,
+		       t.budget, COALESCE(t.currency, ''), e.username
+		FROM tender_history t
+		LEFT JOIN employee e ON t.creator_id = e.id
+		WHERE t.tender_id = $1 AND t.version = $2
+	`
+	err := r.Conn.QueryRowContext(ctx, queryStr, tenderId, version).Scan(
+		&tenderVersion.TenderId, &tenderVersion.Name, &tenderVersion.Description,
+		&tenderVersion.ServiceType, &tenderVersion.Status, &tenderVersion.Version,
+		&tenderVersion.OrganizationID, &creatorId, &tenderVersion.CreatedAt,
+		&tenderVersion.SubmissionDeadline, &tenderVersion.DecisionDeadline,
+		&tenderVersion.Budget, &tenderVersion.Currency, &creatorUsername,
+	)
+	if err != nil {
+		return nil, err
+	}
+	tenderVersion.CreatorID, tenderVersion.CreatorUsername = creatorId.UUID, creatorUsername.String
+	return &tenderVersion, nil
+}
+
+func (r *TenderRepo) FindLatestVersionByTenderId(ctx context.Context, tenderId uuid.UUID) (int, error) {
 	var version int
 	query := `
-		SELECT MAX(version)
+		SELECT version
 		FROM tender
 		WHERE tender_id = $1
 	`
-	err := r.Conn.QueryRow(query, tenderId).Scan(&version)
+	err := r.Conn.QueryRowContext(ctx, query, tenderId).Scan(&version)
 	if err != nil {
 		return -734, err
 	}
 	return version, nil
 }
 
-func (r *TenderRepo) CheckEmployeeAccessToTender(employeeId uuid.UUID, tenderId uuid.UUID) (bool, error) {
-	query := `
-		SELECT EXISTS (
-			SELECT 1
-			FROM tender t
-			INNER JOIN organization o ON t.organization_id = o.id
-			INNER JOIN organization_responsible orp ON o.id = orp.organization_id
-			WHERE orp.user_id = $1 AND t.tender_id = $2
-		);
+func (r *TenderRepo) FindAllExpiredPublished(ctx context.Context, now time.Time, limit int) ([]entity.Tender, error) {
+	queryStr := `
+		SELECT t.tender_id, t.name, t.description, t.service_type, t.status, t.version,
+		       t.organization_id, t.creator_id, t.created_at, t.submission_deadline, t.decision_deadline,
+		       t.budget, COALESCE(t.currency, '')
+		FROM tender t
+		WHERE t.status = 'Published'
+		  AND COALESCE(t.decision_deadline, t.submission_deadline) <= $1
+		ORDER BY COALESCE(t.decision_deadline, t.submission_deadline) LIMIT $2
 	`
 
-	var hasAccess bool
-	err := r.Conn.QueryRow(query, employeeId, tenderId).Scan(&hasAccess)
+	rows, err := r.Conn.QueryContext(ctx, queryStr, now.UTC(), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tenders := []entity.Tender{}
+	for rows.Next() {
+		var tender entity.Tender
+		err = rows.Scan(
+			&tender.TenderId, &tender.Name, &tender.Description,
+			&tender.ServiceType, &tender.Status, &tender.Version,
+			&tender.OrganizationID, &tender.CreatorID, &tender.CreatedAt,
+			&tender.SubmissionDeadline, &tender.DecisionDeadline, &tender.Budget, &tender.Currency,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		tenders = append(tenders, tender)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tenders, nil
+}
+
+// nullableCreator версии, созданные системой, и версии удаленных сотрудников хранятся без автора
+func nullableCreator(creatorId uuid.UUID) uuid.NullUUID {
+	return uuid.NullUUID{UUID: creatorId, Valid: creatorId != uuid.Nil}
+}
+
+// nullableTime сохраняет отсутствующий срок как NULL, а заданный в UTC, так как колонки без часового пояса
+func nullableTime(t *custom_types.RFC3339Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t.ConvertToTime().UTC(), Valid: true}
+}
+
+func (r *TenderRepo) SearchPublished(
+	ctx context.Context, filter repository.TenderFilter, page repository.Page,
+) (*repository.PageResult[entity.TenderSearchResult], error) {
+	conditions, queryArgs := tenderConditions(filter, true)
+	// Поисковый запрос добавляется в условия последним, ранжирование и подсветка используют тот же параметр
+	query := fmt.Sprintf(searchQuery, len(queryArgs))
+	rank := fmt.Sprintf("ts_rank_cd(t.search_vector, %s)", query)
+
+	total, err := countTotal(ctx, r.Conn, page, "SELECT 1 FROM tender t WHERE "+strings.Join(conditions, " AND "), queryArgs...)
 	if err != nil {
-		return false, err
+		return nil, err
+	}
+
+	queryStr := fmt.Sprintf(`
+		SELECT t.tender_id, t.name, t.description, t.service_type, t.status, t.version,
+		       t.organization_id, t.creator_id, t.created_at, t.submission_deadline, t.decision_deadline,
+		       t.budget, COALESCE(t.currency, ''),
+		       %[3]s AS rank,
+		       ts_headline('russian', t.name, %[1]s, 'HighlightAll=true, StartSel=<mark>, StopSel=</mark>'),
+		       ts_headline('russian', COALESCE(t.description, ''), %[1]s, '%[2]s')
+		FROM tender t
+		WHERE `, query, headlineOptions, rank) + strings.Join(conditions, " AND ")
+
+	if page.Sort.Field != "" {
+		queryStr, queryArgs = keysetPage(queryStr, queryArgs, page, "t.", "name", "tender_id")
+	} else {
+		queryStr, queryArgs = rankPage(queryStr, queryArgs, page, rank)
+	}
+
+	rows, err := r.Conn.QueryContext(ctx, queryStr, queryArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := []entity.TenderSearchResult{}
+	for rows.Next() {
+		var result entity.TenderSearchResult
+		err = rows.Scan(
+			&result.TenderId, &result.Name, &result.Description,
+			&result.ServiceType, &result.Status, &result.Version,
+			&result.OrganizationID, &result.CreatorID, &result.CreatedAt,
+			&result.SubmissionDeadline, &result.DecisionDeadline, &result.Budget, &result.Currency,
+			&result.Rank, &result.Highlight.Name, &result.Highlight.Description,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, result)
 	}
 
-	return hasAccess, nil
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return newPageResult(results, page, total, func(result entity.TenderSearchResult) repository.Cursor {
+		if page.Sort.Field != "" {
+			return repository.Cursor{Key: tenderSortKey(page.Sort, result.Tender), Id: result.TenderId}
+		}
+		return repository.Cursor{Rank: result.Rank, Key: result.Name, Id: result.TenderId}
+	}), nil
+}
+
+// rankPage дописывает к поисковому запросу продолжение после курсора, сортировку по убыванию релевантности rank
+// и лимит. При равной релевантности результаты идут по названию и id
+func rankPage(queryStr string, queryArgs []interface{}, page repository.Page, rank string) (string, []interface{}) {
+	if page.After != nil {
+		queryArgs = append(queryArgs, page.After.Rank, page.After.Key, page.After.Id)
+		queryStr += fmt.Sprintf(
+			" AND (%[1]s < $%[2]d::real OR (%[1]s = $%[2]d::real AND (t.name, t.tender_id) > ($%[3]d, $%[4]d)))",
+			rank, len(queryArgs)-2, len(queryArgs)-1, len(queryArgs),
+		)
+	}
+
+	queryArgs = append(queryArgs, page.Limit)
+	queryStr += fmt.Sprintf(" ORDER BY rank DESC, t.name ASC, t.tender_id ASC LIMIT $%d", len(queryArgs))
+
+	if page.After == nil {
+		queryArgs = append(queryArgs, page.Offset)
+		queryStr += fmt.Sprintf(" OFFSET $%d", len(queryArgs))
+	}
+	return queryStr, queryArgs
 }
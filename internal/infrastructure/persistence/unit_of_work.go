@@ -0,0 +1,47 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"tenders/internal/domain/repository"
+)
+
+type UnitOfWork struct {
+	Conn *sql.DB
+}
+
+func NewUnitOfWork(conn *sql.DB) *UnitOfWork {
+	return &UnitOfWork{Conn: conn}
+}
+
+var _ repository.UnitOfWork = &UnitOfWork{}
+
+func (u *UnitOfWork) WithinTx(ctx context.Context, fn func(repos *repository.TxRepositories) error) (err error) {
+	tx, err := u.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		} else if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	return fn(&repository.TxRepositories{
+		Tender:       NewTenderRepository(tx),
+		Employee:     NewEmployeeRepository(tx),
+		Organization: NewOrganizationRepository(tx),
+		Bid:          NewBidRepository(tx),
+		Review:       NewReviewRepository(tx),
+		BidDecision:  NewBidDecisionRepository(tx),
+		Evaluation:   NewEvaluationRepository(tx),
+		Audit:        NewAuditRepository(tx),
+		Outbox:       NewOutboxRepository(tx),
+		ChangeEvent:  NewChangeEventRepository(tx),
+	})
+}
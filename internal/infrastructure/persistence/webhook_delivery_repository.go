@@ -0,0 +1,126 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"tenders/internal/domain/entity"
+	"tenders/internal/domain/repository"
+	"tenders/internal/utils/consts"
+	"time"
+)
+
+type WebhookDeliveryRepo struct {
+	Conn DBTX
+}
+
+func NewWebhookDeliveryRepository(conn DBTX) *WebhookDeliveryRepo {
+	return &WebhookDeliveryRepo{Conn: conn}
+}
+
+var _ repository.WebhookDeliveryRepository = &WebhookDeliveryRepo{}
+
+func (r *WebhookDeliveryRepo) FanOutEvents(ctx context.Context, limit int) (int, error) {
+	query := `
+		WITH events AS (
+			SELECT id, organization_id, event_type
+			FROM outbox_event
+			WHERE dispatched_at IS NULL
+			ORDER BY created_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		), deliveries AS (
+			INSERT INTO webhook_delivery (event_id, subscription_id)
+			SELECT e.id, s.id
+			FROM events e
+			INNER JOIN webhook_subscription s ON s.organization_id = e.organization_id
+			WHERE s.active AND (cardinality(s.event_types) = 0 OR e.event_type = ANY (s.event_types))
+			ON CONFLICT (event_id, subscription_id) DO NOTHING
+		)
+		UPDATE outbox_event
+		SET dispatched_at = CURRENT_TIMESTAMP
+		WHERE id IN (SELECT id FROM events)
+	`
+	result, err := r.Conn.ExecContext(ctx, query, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	dispatched, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(dispatched), nil
+}
+
+func (r *WebhookDeliveryRepo) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]entity.WebhookDelivery, error) {
+	query := `
+		WITH due AS (
+			SELECT id
+			FROM webhook_delivery
+			WHERE status = 'Pending' AND next_attempt_at <= CURRENT_TIMESTAMP
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE webhook_delivery d
+		SET next_attempt_at = CURRENT_TIMESTAMP + $2 * INTERVAL '1 millisecond'
+		FROM due, outbox_event e, webhook_subscription s
+		WHERE d.id = due.id AND e.id = d.event_id AND s.id = d.subscription_id
+		RETURNING d.id, d.event_id, e.event_type, e.payload, s.url, s.secret, d.attempts
+	`
+	rows, err := r.Conn.QueryContext(ctx, query, limit, lease.Milliseconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []entity.WebhookDelivery{}
+	for rows.Next() {
+		var delivery entity.WebhookDelivery
+		err = rows.Scan(
+			&delivery.Id, &delivery.EventId, &delivery.EventType, &delivery.Payload,
+			&delivery.Url, &delivery.Secret, &delivery.Attempts,
+		)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (r *WebhookDeliveryRepo) MarkDelivered(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE webhook_delivery
+		SET status = $1, attempts = attempts + 1, delivered_at = CURRENT_TIMESTAMP, last_error = NULL
+		WHERE id = $2
+	`
+	_, err := r.Conn.ExecContext(ctx, query, consts.WebhookDeliveryDelivered, id)
+	return err
+}
+
+func (r *WebhookDeliveryRepo) MarkFailed(
+	ctx context.Context, id uuid.UUID, attempts int, retryAfter time.Duration, lastError string, deadLetter bool,
+) error {
+	status := consts.WebhookDeliveryPending
+	if deadLetter {
+		status = consts.WebhookDeliveryDeadLetter
+	}
+
+	query := `
+		UPDATE webhook_delivery
+		SET status = $1, attempts = $2, last_error = $3,
+		    next_attempt_at = CURRENT_TIMESTAMP + $4 * INTERVAL '1 millisecond'
+		WHERE id = $5
+	`
+	_, err := r.Conn.ExecContext(ctx, query,
+		status, attempts, sql.NullString{String: lastError, Valid: lastError != ""}, retryAfter.Milliseconds(), id,
+	)
+	return err
+}
@@ -0,0 +1,108 @@
+package persistence
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"tenders/internal/domain/entity"
+	"tenders/internal/domain/repository"
+)
+
+type WebhookSubscriptionRepo struct {
+	Conn DBTX
+}
+
+func NewWebhookSubscriptionRepository(conn DBTX) *WebhookSubscriptionRepo {
+	return &WebhookSubscriptionRepo{Conn: conn}
+}
+
+var _ repository.WebhookSubscriptionRepository = &WebhookSubscriptionRepo{}
+
+func (r *WebhookSubscriptionRepo) Create(ctx context.Context, subscription *entity.WebhookSubscription) error {
+	query := `
+		INSERT INTO webhook_subscription (id, organization_id, url, secret, event_types, active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	_, err := r.Conn.ExecContext(ctx, query,
+		subscription.Id, subscription.OrganizationId, subscription.Url, subscription.Secret,
+		pq.StringArray(subscription.EventTypes), subscription.Active,
+		subscription.CreatedAt.ConvertToTime(), subscription.UpdatedAt.ConvertToTime(),
+	)
+	return err
+}
+
+func (r *WebhookSubscriptionRepo) Update(ctx context.Context, subscription *entity.WebhookSubscription) error {
+	query := `
+		UPDATE webhook_subscription
+		SET url = $1, event_types = $2, active = $3, updated_at = $4
+		WHERE id = $5
+	`
+	_, err := r.Conn.ExecContext(ctx, query,
+		subscription.Url, pq.StringArray(subscription.EventTypes), subscription.Active,
+		subscription.UpdatedAt.ConvertToTime(), subscription.Id,
+	)
+	return err
+}
+
+func (r *WebhookSubscriptionRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := r.Conn.ExecContext(ctx, `DELETE FROM webhook_subscription WHERE id = $1`, id)
+	return err
+}
+
+func (r *WebhookSubscriptionRepo) FindById(ctx context.Context, id uuid.UUID) (*entity.WebhookSubscription, error) {
+	query := `
+		SELECT id, organization_id, url, secret, event_types, active, created_at, updated_at
+		FROM webhook_subscription
+		WHERE id = $1
+	`
+	var subscription entity.WebhookSubscription
+	var eventTypes pq.StringArray
+	err := r.Conn.QueryRowContext(ctx, query, id).Scan(
+		&subscription.Id, &subscription.OrganizationId, &subscription.Url, &subscription.Secret,
+		&eventTypes, &subscription.Active, &subscription.CreatedAt, &subscription.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	subscription.EventTypes = eventTypes
+
+	return &subscription, nil
+}
+
+func (r *WebhookSubscriptionRepo) FindAllByOrganizationId(
+	ctx context.Context, organizationId uuid.UUID,
+) ([]entity.WebhookSubscription, error) {
+	query := `
+		SELECT id, organization_id, url, secret, event_types, active, created_at, updated_at
+		FROM webhook_subscription
+		WHERE organization_id = $1
+		ORDER BY created_at ASC, id
+	`
+	rows, err := r.Conn.QueryContext(ctx, query, organizationId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	subscriptions := []entity.WebhookSubscription{}
+	for rows.Next() {
+		var subscription entity.WebhookSubscription
+		var eventTypes pq.StringArray
+		err = rows.Scan(
+			&subscription.Id, &subscription.OrganizationId, &subscription.Url, &subscription.Secret,
+			&eventTypes, &subscription.Active, &subscription.CreatedAt, &subscription.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		subscription.EventTypes = eventTypes
+
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
@@ -0,0 +1,56 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"tenders/internal/application/interfaces"
+	"time"
+)
+
+const closeBatchSize = 100
+
+// TenderCloser периодически закрывает опубликованные тендеры с истекшим сроком
+type TenderCloser struct {
+	service  interfaces.TenderService
+	interval time.Duration
+	// timeout ограничивает один проход, чтобы зависший запрос к базе не останавливал планировщик
+	timeout time.Duration
+}
+
+func NewTenderCloser(service interfaces.TenderService, interval, timeout time.Duration) *TenderCloser {
+	return &TenderCloser{service: service, interval: interval, timeout: timeout}
+}
+
+// Run закрывает тендеры каждые interval, пока не отменен ctx
+func (c *TenderCloser) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		c.closeExpired(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *TenderCloser) closeExpired(ctx context.Context) {
+	for {
+		passCtx, cancel := context.WithTimeout(ctx, c.timeout)
+		closed, err := c.service.CloseExpired(passCtx, closeBatchSize)
+		cancel()
+		if err != nil {
+			log.Printf("scheduler: failed to close expired tenders: %v", err)
+			return
+		}
+		if closed > 0 {
+			log.Printf("scheduler: closed %d expired tenders", closed)
+		}
+		if closed < closeBatchSize {
+			return
+		}
+	}
+}
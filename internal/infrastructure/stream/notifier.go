@@ -0,0 +1,99 @@
+package stream
+
+import (
+	"context"
+	"github.com/lib/pq"
+	"log"
+	"sync"
+	"tenders/internal/domain/repository"
+	"time"
+)
+
+const (
+	minReconnectInterval = time.Second
+	maxReconnectInterval = time.Minute
+	pingInterval         = 90 * time.Second
+)
+
+// Notifier слушает канал Postgres и будит подписчиков. Уведомления приходят от всех экземпляров приложения,
+// сами события подписчики читают из базы, поэтому пропущенное уведомление не теряет событий
+type Notifier struct {
+	connStr string
+	channel string
+
+	mu          sync.Mutex
+	subscribers map[chan struct{}]struct{}
+}
+
+func NewNotifier(connStr, channel string) *Notifier {
+	return &Notifier{
+		connStr:     connStr,
+		channel:     channel,
+		subscribers: make(map[chan struct{}]struct{}),
+	}
+}
+
+var _ repository.ChangeNotifier = &Notifier{}
+
+func (n *Notifier) Subscribe() (<-chan struct{}, func()) {
+	wake := make(chan struct{}, 1)
+
+	n.mu.Lock()
+	n.subscribers[wake] = struct{}{}
+	n.mu.Unlock()
+
+	return wake, func() {
+		n.mu.Lock()
+		delete(n.subscribers, wake)
+		n.mu.Unlock()
+	}
+}
+
+// Run слушает канал, пока не отменен ctx
+func (n *Notifier) Run(ctx context.Context) {
+	listener := pq.NewListener(n.connStr, minReconnectInterval, maxReconnectInterval,
+		func(event pq.ListenerEventType, err error) {
+			if err != nil {
+				log.Printf("stream: listener event %d: %v", event, err)
+			}
+		},
+	)
+	defer listener.Close()
+
+	if err := listener.Listen(n.channel); err != nil {
+		log.Printf("stream: failed to listen %s: %v", n.channel, err)
+		return
+	}
+
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		// После переподключения приходит nil: уведомления могли потеряться, поэтому подписчики тоже будятся
+		case <-listener.Notify:
+			n.wakeAll()
+		case <-ticker.C:
+			go func() {
+				if err := listener.Ping(); err != nil {
+					log.Printf("stream: listener ping failed: %v", err)
+				}
+			}()
+		}
+	}
+}
+
+func (n *Notifier) wakeAll() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for wake := range n.subscribers {
+		select {
+		case wake <- struct{}{}:
+		default:
+			// Подписчик еще не обработал предыдущее пробуждение, он все равно перечитает ленту
+		}
+	}
+}
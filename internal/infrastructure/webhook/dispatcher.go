@@ -0,0 +1,195 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"tenders/internal/domain/entity"
+	"tenders/internal/domain/repository"
+	"tenders/internal/utils/common"
+	"time"
+)
+
+const (
+	batchSize = 100
+	// claimSize меньше batchSize, так как доставки отправляются последовательно и должны уложиться в аренду
+	claimSize   = 10
+	baseBackoff = 10 * time.Second
+	maxBackoff  = time.Hour
+	// maxErrorLength ограничивает текст ошибки, сохраняемый в last_error
+	maxErrorLength = 500
+)
+
+// Dispatcher раскладывает события outbox по подпискам и доставляет их с повторами.
+// Несколько экземпляров приложения могут работать одновременно, доставки блокируются в базе
+type Dispatcher struct {
+	deliveryRepo repository.WebhookDeliveryRepository
+	client       *http.Client
+	interval     time.Duration
+	maxAttempts  int
+}
+
+func NewDispatcher(
+	deliveryRepo repository.WebhookDeliveryRepository, interval time.Duration, maxAttempts int, timeout time.Duration,
+) *Dispatcher {
+	return &Dispatcher{
+		deliveryRepo: deliveryRepo,
+		client:       newClient(timeout),
+		interval:     interval,
+		maxAttempts:  maxAttempts,
+	}
+}
+
+// newClient клиент, который подключается только к публичным адресам и не следует редиректам.
+// Адрес проверяется после резолва, поэтому имя хоста не может указывать во внутреннюю сеть
+func newClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, Control: denyInternalAddress}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// Через прокси проверялся бы адрес прокси, а не получателя
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func denyInternalAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if !common.IsPublicIP(net.ParseIP(host)) {
+		return fmt.Errorf("address %s is not allowed", host)
+	}
+	return nil
+}
+
+// Run обрабатывает события каждые interval, пока не отменен ctx
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		d.dispatch(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *Dispatcher) dispatch(ctx context.Context) {
+	for {
+		fannedOut, err := d.deliveryRepo.FanOutEvents(ctx, batchSize)
+		if err != nil {
+			log.Printf("webhook: failed to fan out events: %v", err)
+			break
+		}
+		if fannedOut < batchSize {
+			break
+		}
+	}
+
+	// Аренда дольше отправки всей пачки, чтобы доставку не взял другой экземпляр, пока идет отправка
+	lease := d.client.Timeout*claimSize + d.interval
+	for {
+		deliveries, err := d.deliveryRepo.ClaimDue(ctx, claimSize, lease)
+		if err != nil {
+			log.Printf("webhook: failed to claim deliveries: %v", err)
+			return
+		}
+
+		for _, delivery := range deliveries {
+			d.deliver(ctx, delivery)
+		}
+
+		if len(deliveries) < claimSize {
+			return
+		}
+	}
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, delivery entity.WebhookDelivery) {
+	sendErr := d.send(ctx, delivery)
+	if sendErr == nil {
+		if err := d.deliveryRepo.MarkDelivered(ctx, delivery.Id); err != nil {
+			log.Printf("webhook: failed to mark delivery %s as delivered: %v", delivery.Id, err)
+		}
+		return
+	}
+
+	attempts := delivery.Attempts + 1
+	deadLetter := attempts >= d.maxAttempts
+	lastError := sendErr.Error()
+	if len(lastError) > maxErrorLength {
+		lastError = lastError[:maxErrorLength]
+	}
+
+	err := d.deliveryRepo.MarkFailed(ctx, delivery.Id, attempts, Backoff(attempts), lastError, deadLetter)
+	if err != nil {
+		log.Printf("webhook: failed to mark delivery %s as failed: %v", delivery.Id, err)
+	}
+}
+
+func (d *Dispatcher) send(ctx context.Context, delivery entity.WebhookDelivery) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Url, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Id", delivery.EventId.String())
+	req.Header.Set("X-Webhook-Event", delivery.EventType)
+	req.Header.Set("X-Webhook-Timestamp", timestamp)
+	req.Header.Set("X-Webhook-Signature", "sha256="+Sign(delivery.Secret, timestamp, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Sign считает HMAC-SHA256 от "timestamp.body", получатель проверяет подпись тем же секретом
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Backoff задержка перед следующей попыткой: 10s, 20s, 40s, ... но не больше часа
+func Backoff(attempts int) time.Duration {
+	backoff := baseBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return backoff
+}
@@ -0,0 +1,111 @@
+package webhook
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"tenders/internal/domain/entity"
+	"testing"
+	"time"
+)
+
+func TestSign(t *testing.T) {
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp string
+		body      string
+		want      string
+	}{
+		{
+			name:      "payload",
+			secret:    "secret",
+			timestamp: "1700000000",
+			body:      `{"event":"tender.published"}`,
+			want:      "1ad17531a2cc2ad38c9100c8fbdf4b46d0e5b15fc698b9b7bfce4e5418c4e2fe",
+		},
+		{
+			name:      "empty secret and body",
+			timestamp: "1700000000",
+			want:      "c1da1b6c6b8e9da7f4bbb90f7cab0820f271ad19ccbf80c88479c4e14f37d1c6",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sign(tt.secret, tt.timestamp, []byte(tt.body)); got != tt.want {
+				t.Errorf("Sign() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if Sign("secret", "1700000001", nil) == Sign("secret", "1700000000", nil) {
+		t.Error("signature does not depend on timestamp")
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{0, 10 * time.Second},
+		{1, 10 * time.Second},
+		{2, 20 * time.Second},
+		{3, 40 * time.Second},
+		{9, 2560 * time.Second},
+		{10, time.Hour},
+		{100, time.Hour},
+	}
+
+	for _, tt := range tests {
+		if got := Backoff(tt.attempts); got != tt.want {
+			t.Errorf("Backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestSendRejectsInternalAddress(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits.Add(1)
+	}))
+	defer server.Close()
+
+	dispatcher := NewDispatcher(nil, time.Second, 3, time.Second)
+	delivery := entity.WebhookDelivery{Id: uuid.New(), EventId: uuid.New(), Url: server.URL, Payload: []byte("{}")}
+	if err := dispatcher.send(context.Background(), delivery); err == nil {
+		t.Fatal("send to loopback succeeded, want error")
+	}
+	if hits.Load() != 0 {
+		t.Errorf("loopback server got %d requests, want 0", hits.Load())
+	}
+}
+
+func TestClientDoesNotFollowRedirects(t *testing.T) {
+	var redirected atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/target" {
+			redirected.Add(1)
+			return
+		}
+		http.Redirect(w, r, "/target", http.StatusTemporaryRedirect)
+	}))
+	defer server.Close()
+
+	client := newClient(time.Second)
+	// Тестовый сервер слушает loopback, поэтому проверяется только политика редиректов
+	client.Transport = http.DefaultTransport
+	resp, err := client.Post(server.URL, "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusTemporaryRedirect || redirected.Load() != 0 {
+		t.Errorf("status = %d, redirected = %d, want %d and 0",
+			resp.StatusCode, redirected.Load(), http.StatusTemporaryRedirect)
+	}
+}
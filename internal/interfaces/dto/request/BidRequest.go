@@ -1,18 +1,27 @@
 package request
 
 import (
+	"encoding/json"
 	"github.com/google/uuid"
 	"tenders/internal/domain/entity"
 	"tenders/internal/utils"
+	"tenders/internal/utils/common/custom_types"
 	"tenders/internal/utils/consts"
 )
 
+// maxDeliveryDays верхняя граница срока поставки, 10 лет
+const maxDeliveryDays = 3650
+
 type BidRequest struct {
 	Name        string    `json:"name"`
 	Description string    `json:"description"`
 	TenderId    uuid.UUID `json:"tenderId"`
 	AuthorType  string    `json:"authorType"`
 	AuthorId    uuid.UUID `json:"authorId"`
+	// Amount принимается числом или строкой, чтобы не терять точность
+	Amount       json.Number `json:"amount"`
+	Currency     string      `json:"currency"`
+	DeliveryDays *int        `json:"deliveryDays"`
 }
 
 // MapToBid мапит в тендер и валидирует
@@ -31,15 +40,51 @@ func (bidRequest BidRequest) MapToBid() (*entity.Bid, error) {
 		errorFields = append(errorFields, "authorType")
 	}
 
+	// Цена обязательна, предложения без нее остались только от старых версий
+	amount, priceErrors := parsePrice(bidRequest.Amount, bidRequest.Currency, true)
+	errorFields = append(errorFields, priceErrors...)
+
+	if !validDeliveryDays(bidRequest.DeliveryDays) {
+		errorFields = append(errorFields, "deliveryDays")
+	}
+
 	if len(errorFields) > 0 {
 		return nil, utils.NewValidationError(errorFields)
 	}
 
 	return &entity.Bid{
-		Name:        bidRequest.Name,
-		Description: bidRequest.Description,
-		TenderId:    bidRequest.TenderId,
-		AuthorType:  bidRequest.AuthorType,
-		AuthorId:    bidRequest.AuthorId,
+		Name:         bidRequest.Name,
+		Description:  bidRequest.Description,
+		TenderId:     bidRequest.TenderId,
+		AuthorType:   bidRequest.AuthorType,
+		AuthorId:     bidRequest.AuthorId,
+		Amount:       amount,
+		Currency:     bidRequest.Currency,
+		DeliveryDays: bidRequest.DeliveryDays,
 	}, nil
 }
+
+// parsePrice разбирает сумму и валюту. Они передаются вместе, с required обязательно
+func parsePrice(amount json.Number, currency string, required bool) (*custom_types.Decimal, []string) {
+	if amount == "" && currency == "" && !required {
+		return nil, nil
+	}
+
+	var errorFields []string
+	parsed, err := custom_types.ParseDecimal(amount.String())
+	if err != nil {
+		errorFields = append(errorFields, "amount")
+	}
+	if !entity.ValidCurrencies[currency] {
+		errorFields = append(errorFields, "currency")
+	}
+
+	if len(errorFields) > 0 {
+		return nil, errorFields
+	}
+	return &parsed, nil
+}
+
+func validDeliveryDays(deliveryDays *int) bool {
+	return deliveryDays == nil || (*deliveryDays > 0 && *deliveryDays <= maxDeliveryDays)
+}
@@ -0,0 +1,34 @@
+package request
+
+import (
+	"tenders/internal/domain/entity"
+	"tenders/internal/utils"
+)
+
+type CriteriaRequest struct {
+	Criteria []entity.TenderCriterion `json:"criteria"`
+}
+
+// MapToCriteria валидирует критерии: каждый критерий указывается один раз, веса в процентах дают в сумме 100
+func (request CriteriaRequest) MapToCriteria() ([]entity.TenderCriterion, error) {
+	if len(request.Criteria) == 0 {
+		return nil, utils.NewValidationError([]string{"criteria"})
+	}
+
+	total := 0
+	seen := make(map[entity.Criterion]bool, len(request.Criteria))
+	for _, criterion := range request.Criteria {
+		if !entity.ValidCriteria[criterion.Criterion] || seen[criterion.Criterion] ||
+			criterion.Weight < 1 || criterion.Weight > entity.CriteriaWeightTotal {
+			return nil, utils.NewValidationError([]string{"criteria"})
+		}
+		seen[criterion.Criterion] = true
+		total += criterion.Weight
+	}
+
+	if total != entity.CriteriaWeightTotal {
+		return nil, utils.NewValidationError([]string{"criteria"})
+	}
+
+	return request.Criteria, nil
+}
@@ -1,13 +1,22 @@
 package request
 
 import (
+	"encoding/json"
 	"tenders/internal/domain/entity"
 	"tenders/internal/utils"
 )
 
 type EditBidRequest struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	Name         string      `json:"name"`
+	Description  string      `json:"description"`
+	Amount       json.Number `json:"amount"`
+	Currency     string      `json:"currency"`
+	DeliveryDays *int        `json:"deliveryDays"`
+}
+
+// ChangesPrice true, если запрос меняет сумму или валюту
+func (request EditBidRequest) ChangesPrice() bool {
+	return request.Amount != "" || request.Currency != ""
 }
 
 func (request EditBidRequest) MapToBid(bid entity.Bid) (entity.Bid, error) {
@@ -29,6 +38,32 @@ func (request EditBidRequest) MapToBid(bid entity.Bid) (entity.Bid, error) {
 		}
 	}
 
+	// Сумма и валюта меняются по отдельности, но у итогового предложения должны быть заданы обе
+	if request.ChangesPrice() {
+		amount, currency := request.Amount, request.Currency
+		if amount == "" && bid.Amount != nil {
+			amount = json.Number(bid.Amount.String())
+		}
+		if currency == "" {
+			currency = bid.Currency
+		}
+
+		parsed, priceErrors := parsePrice(amount, currency, true)
+		if len(priceErrors) > 0 {
+			errorFields = append(errorFields, priceErrors...)
+		} else {
+			bid.Amount, bid.Currency = parsed, currency
+		}
+	}
+
+	if request.DeliveryDays != nil {
+		if validDeliveryDays(request.DeliveryDays) {
+			bid.DeliveryDays = request.DeliveryDays
+		} else {
+			errorFields = append(errorFields, "deliveryDays")
+		}
+	}
+
 	if len(errorFields) > 0 {
 		return bid, utils.NewValidationError(errorFields)
 	}
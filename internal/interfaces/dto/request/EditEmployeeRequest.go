@@ -0,0 +1,44 @@
+package request
+
+import (
+	"tenders/internal/domain/entity"
+	"tenders/internal/utils"
+	"unicode/utf8"
+)
+
+type EditEmployeeRequest struct {
+	FirstName *string `json:"firstName"`
+	LastName  *string `json:"lastName"`
+	Password  *string `json:"password"`
+}
+
+// UpdateEmployee меняет имя и фамилию сотрудника и валидирует новый пароль, хеширует его сервис
+func (request EditEmployeeRequest) UpdateEmployee(employee *entity.Employee) error {
+	var errorFields []string
+
+	if request.FirstName != nil {
+		if utf8.RuneCountInString(*request.FirstName) > 50 {
+			errorFields = append(errorFields, "firstName")
+		} else {
+			employee.FirstName = *request.FirstName
+		}
+	}
+
+	if request.LastName != nil {
+		if utf8.RuneCountInString(*request.LastName) > 50 {
+			errorFields = append(errorFields, "lastName")
+		} else {
+			employee.LastName = *request.LastName
+		}
+	}
+
+	if request.Password != nil && !isValidPassword(*request.Password) {
+		errorFields = append(errorFields, "password")
+	}
+
+	if len(errorFields) > 0 {
+		return utils.NewValidationError(errorFields)
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package request
+
+import (
+	"tenders/internal/domain/entity"
+	"tenders/internal/utils"
+	"unicode/utf8"
+)
+
+type EditOrganizationRequest struct {
+	Name        string  `json:"name"`
+	Description *string `json:"description"`
+	Type        string  `json:"type"`
+}
+
+func (request EditOrganizationRequest) UpdateOrganization(organization *entity.Organization) error {
+	var errorFields []string
+
+	if request.Name != "" {
+		if utf8.RuneCountInString(request.Name) > 100 {
+			errorFields = append(errorFields, "name")
+		} else {
+			organization.Name = request.Name
+		}
+	}
+
+	if request.Description != nil {
+		organization.Description = *request.Description
+	}
+
+	if request.Type != "" {
+		if !entity.ValidOrganizationTypes[request.Type] {
+			errorFields = append(errorFields, "type")
+		} else {
+			organization.Type = entity.OrganizationType(request.Type)
+		}
+	}
+
+	if len(errorFields) > 0 {
+		return utils.NewValidationError(errorFields)
+	}
+
+	return nil
+}
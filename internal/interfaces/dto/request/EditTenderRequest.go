@@ -1,15 +1,21 @@
 package request
 
 import (
+	"encoding/json"
 	"tenders/internal/domain/entity"
 	"tenders/internal/utils"
 	"tenders/internal/utils/consts"
+	"time"
 )
 
 type EditTenderRequest struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	ServiceType string `json:"serviceType"`
+	Name               string      `json:"name"`
+	Description        string      `json:"description"`
+	ServiceType        string      `json:"serviceType"`
+	SubmissionDeadline *time.Time  `json:"submissionDeadline"`
+	DecisionDeadline   *time.Time  `json:"decisionDeadline"`
+	Budget             json.Number `json:"budget"`
+	Currency           string      `json:"currency"`
 }
 
 func (request EditTenderRequest) UpdateTender(tender *entity.Tender) error {
@@ -40,6 +46,17 @@ func (request EditTenderRequest) UpdateTender(tender *entity.Tender) error {
 		errorFields = append(errorFields, "serviceType")
 	}
 
+	// Если срок не передан, оставляем текущее значение
+	if request.SubmissionDeadline != nil {
+		tender.SubmissionDeadline = toDeadline(request.SubmissionDeadline)
+	}
+	if request.DecisionDeadline != nil {
+		tender.DecisionDeadline = toDeadline(request.DecisionDeadline)
+	}
+	errorFields = append(errorFields, validateDeadlines(tender, request.SubmissionDeadline, request.DecisionDeadline)...)
+	// Если бюджет и валюта не переданы, оставляем текущие значения
+	errorFields = append(errorFields, updateBudget(tender, request.Budget, request.Currency)...)
+
 	if len(errorFields) > 0 {
 		return utils.NewValidationError(errorFields)
 	}
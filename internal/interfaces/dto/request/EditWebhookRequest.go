@@ -0,0 +1,43 @@
+package request
+
+import (
+	"tenders/internal/domain/entity"
+	"tenders/internal/utils"
+)
+
+type EditWebhookRequest struct {
+	Url        string    `json:"url"`
+	EventTypes *[]string `json:"eventTypes"`
+	Active     *bool     `json:"active"`
+}
+
+func (request EditWebhookRequest) UpdateWebhookSubscription(subscription *entity.WebhookSubscription) error {
+	var errorFields []string
+
+	if request.Url != "" {
+		if !isValidWebhookUrl(request.Url) {
+			errorFields = append(errorFields, "url")
+		} else {
+			subscription.Url = request.Url
+		}
+	}
+
+	// Пустой список допустим и означает подписку на все события
+	if request.EventTypes != nil {
+		if !areValidWebhookEventTypes(*request.EventTypes) {
+			errorFields = append(errorFields, "eventTypes")
+		} else {
+			subscription.EventTypes = *request.EventTypes
+		}
+	}
+
+	if request.Active != nil {
+		subscription.Active = *request.Active
+	}
+
+	if len(errorFields) > 0 {
+		return utils.NewValidationError(errorFields)
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package request
+
+import (
+	"tenders/internal/domain/entity"
+	"tenders/internal/utils"
+	"unicode/utf8"
+)
+
+// bcrypt учитывает только первые 72 байта пароля
+const (
+	minPasswordLength = 8
+	maxPasswordBytes  = 72
+)
+
+type EmployeeRequest struct {
+	Username  string `json:"username"`
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+	Password  string `json:"password"`
+}
+
+// MapToEmployee мапит в сотрудника и валидирует, пароль может быть не передан, тогда сотрудник не сможет получить токен
+func (request EmployeeRequest) MapToEmployee() (*entity.Employee, error) {
+	var errorFields []string
+
+	if request.Username == "" || utf8.RuneCountInString(request.Username) > 50 {
+		errorFields = append(errorFields, "username")
+	}
+
+	if utf8.RuneCountInString(request.FirstName) > 50 {
+		errorFields = append(errorFields, "firstName")
+	}
+
+	if utf8.RuneCountInString(request.LastName) > 50 {
+		errorFields = append(errorFields, "lastName")
+	}
+
+	if request.Password != "" && !isValidPassword(request.Password) {
+		errorFields = append(errorFields, "password")
+	}
+
+	if len(errorFields) > 0 {
+		return nil, utils.NewValidationError(errorFields)
+	}
+
+	return &entity.Employee{
+		Username:  request.Username,
+		FirstName: request.FirstName,
+		LastName:  request.LastName,
+		IsActive:  true,
+	}, nil
+}
+
+func isValidPassword(password string) bool {
+	return utf8.RuneCountInString(password) >= minPasswordLength && len(password) <= maxPasswordBytes
+}
@@ -0,0 +1,36 @@
+package request
+
+import (
+	"tenders/internal/domain/entity"
+	"tenders/internal/utils"
+	"unicode/utf8"
+)
+
+type OrganizationRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Type        string `json:"type"`
+}
+
+func (request OrganizationRequest) MapToOrganization() (*entity.Organization, error) {
+	var errorFields []string
+
+	if request.Name == "" || utf8.RuneCountInString(request.Name) > 100 {
+		errorFields = append(errorFields, "name")
+	}
+
+	if !entity.ValidOrganizationTypes[request.Type] {
+		errorFields = append(errorFields, "type")
+	}
+
+	if len(errorFields) > 0 {
+		return nil, utils.NewValidationError(errorFields)
+	}
+
+	return &entity.Organization{
+		Name:        request.Name,
+		Description: request.Description,
+		Type:        entity.OrganizationType(request.Type),
+		IsActive:    true,
+	}, nil
+}
@@ -0,0 +1,20 @@
+package request
+
+import (
+	"github.com/google/uuid"
+	"tenders/internal/domain/entity"
+	"tenders/internal/utils"
+)
+
+type ResponsibleRequest struct {
+	EmployeeId uuid.UUID `json:"employeeId"`
+	Role       string    `json:"role"`
+}
+
+// MapToRole валидирует роль, ее нужно указывать явно, в том числе при смене роли уже назначенного сотрудника
+func (request ResponsibleRequest) MapToRole() (entity.ResponsibleRole, error) {
+	if !entity.ValidResponsibleRoles[request.Role] {
+		return "", utils.NewValidationError([]string{"role"})
+	}
+	return entity.ResponsibleRole(request.Role), nil
+}
@@ -0,0 +1,28 @@
+package request
+
+import (
+	"tenders/internal/domain/entity"
+	"tenders/internal/utils"
+)
+
+type ScoresRequest struct {
+	Scores []entity.CriterionScore `json:"scores"`
+}
+
+// MapToScores валидирует оценки, их соответствие критериям тендера проверяется при сохранении
+func (request ScoresRequest) MapToScores() ([]entity.CriterionScore, error) {
+	if len(request.Scores) == 0 {
+		return nil, utils.NewValidationError([]string{"scores"})
+	}
+
+	seen := make(map[entity.Criterion]bool, len(request.Scores))
+	for _, score := range request.Scores {
+		if !entity.ValidCriteria[score.Criterion] || seen[score.Criterion] ||
+			score.Score < entity.MinScore || score.Score > entity.MaxScore {
+			return nil, utils.NewValidationError([]string{"scores"})
+		}
+		seen[score.Criterion] = true
+	}
+
+	return request.Scores, nil
+}
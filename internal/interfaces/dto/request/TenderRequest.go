@@ -1,18 +1,26 @@
 package request
 
 import (
+	"encoding/json"
 	"github.com/google/uuid"
 	"tenders/internal/domain/entity"
 	"tenders/internal/utils"
+	"tenders/internal/utils/common/custom_types"
 	"tenders/internal/utils/consts"
+	"time"
 )
 
 type TenderRequest struct {
-	Name            string    `json:"name"`
-	Description     string    `json:"description"`
-	ServiceType     string    `json:"serviceType"`
-	OrganizationID  uuid.UUID `json:"organizationId"`
-	CreatorUsername string    `json:"creatorUsername"`
+	Name               string     `json:"name"`
+	Description        string     `json:"description"`
+	ServiceType        string     `json:"serviceType"`
+	OrganizationID     uuid.UUID  `json:"organizationId"`
+	CreatorUsername    string     `json:"creatorUsername"`
+	SubmissionDeadline *time.Time `json:"submissionDeadline"`
+	DecisionDeadline   *time.Time `json:"decisionDeadline"`
+	// Budget потолок цены предложений, задается вместе с Currency
+	Budget   json.Number `json:"budget"`
+	Currency string      `json:"currency"`
 }
 
 // MapToTender мапит в тендер и валидирует
@@ -37,15 +45,76 @@ func (tenderRequest TenderRequest) MapToTender() (*entity.Tender, error) {
 		errorFields = append(errorFields, "organizationID")
 	}
 
+	tender := entity.Tender{
+		Name:               tenderRequest.Name,
+		Description:        tenderRequest.Description,
+		ServiceType:        tenderRequest.ServiceType,
+		OrganizationID:     tenderRequest.OrganizationID,
+		SubmissionDeadline: toDeadline(tenderRequest.SubmissionDeadline),
+		DecisionDeadline:   toDeadline(tenderRequest.DecisionDeadline),
+	}
+	errorFields = append(errorFields, validateDeadlines(&tender, tenderRequest.SubmissionDeadline, tenderRequest.DecisionDeadline)...)
+	errorFields = append(errorFields, updateBudget(&tender, tenderRequest.Budget, tenderRequest.Currency)...)
+
 	if len(errorFields) > 0 {
 		return nil, utils.NewValidationError(errorFields)
 	}
 
-	tender := entity.Tender{
-		Name:           tenderRequest.Name,
-		Description:    tenderRequest.Description,
-		ServiceType:    tenderRequest.ServiceType,
-		OrganizationID: tenderRequest.OrganizationID,
-	}
 	return &tender, nil
 }
+
+func toDeadline(deadline *time.Time) *custom_types.RFC3339Time {
+	if deadline == nil {
+		return nil
+	}
+	converted := custom_types.RFC3339Time(*deadline)
+	return &converted
+}
+
+// updateBudget задает бюджет и валюту тендера. Валюту можно задать без бюджета, бюджет без валюты нельзя
+func updateBudget(tender *entity.Tender, budget json.Number, currency string) []string {
+	if budget == "" {
+		if currency == "" {
+			return nil
+		}
+		if !entity.ValidCurrencies[currency] {
+			return []string{"currency"}
+		}
+		// Бюджет в прежней валюте в новой не имеет смысла, его нужно передать заново
+		if tender.Budget != nil && currency != tender.Currency {
+			return []string{"budget"}
+		}
+		tender.Currency = currency
+		return nil
+	}
+
+	if currency == "" {
+		currency = tender.Currency
+	}
+	parsed, errorFields := parsePrice(budget, currency, true)
+	if len(errorFields) > 0 {
+		return errorFields
+	}
+	tender.Budget, tender.Currency = parsed, currency
+	return nil
+}
+
+// validateDeadlines проверяет, что переданные в запросе сроки еще не наступили
+// и что срок решений итогового тендера не раньше срока приема предложений
+func validateDeadlines(tender *entity.Tender, submissionDeadline, decisionDeadline *time.Time) []string {
+	var errorFields []string
+	now := time.Now()
+
+	if submissionDeadline != nil && !submissionDeadline.After(now) {
+		errorFields = append(errorFields, "submissionDeadline")
+	}
+
+	if decisionDeadline != nil && !decisionDeadline.After(now) {
+		errorFields = append(errorFields, "decisionDeadline")
+	} else if tender.SubmissionDeadline != nil && tender.DecisionDeadline != nil &&
+		tender.DecisionDeadline.ConvertToTime().Before(tender.SubmissionDeadline.ConvertToTime()) {
+		errorFields = append(errorFields, "decisionDeadline")
+	}
+
+	return errorFields
+}
@@ -0,0 +1,80 @@
+package request
+
+import (
+	"net"
+	"net/url"
+	"strings"
+	"tenders/internal/domain/entity"
+	"tenders/internal/utils"
+	"tenders/internal/utils/common"
+)
+
+type WebhookRequest struct {
+	Url        string   `json:"url"`
+	Secret     string   `json:"secret"`
+	EventTypes []string `json:"eventTypes"`
+	Active     *bool    `json:"active"`
+}
+
+// MapToWebhookSubscription мапит в подписку и валидирует, секрет может быть не передан
+func (request WebhookRequest) MapToWebhookSubscription() (*entity.WebhookSubscription, error) {
+	var errorFields []string
+
+	if !isValidWebhookUrl(request.Url) {
+		errorFields = append(errorFields, "url")
+	}
+
+	if len(request.Secret) > 100 {
+		errorFields = append(errorFields, "secret")
+	}
+
+	if !areValidWebhookEventTypes(request.EventTypes) {
+		errorFields = append(errorFields, "eventTypes")
+	}
+
+	if len(errorFields) > 0 {
+		return nil, utils.NewValidationError(errorFields)
+	}
+
+	subscription := entity.WebhookSubscription{
+		Url:        request.Url,
+		Secret:     request.Secret,
+		EventTypes: request.EventTypes,
+		Active:     request.Active == nil || *request.Active,
+	}
+	if subscription.EventTypes == nil {
+		subscription.EventTypes = []string{}
+	}
+	return &subscription, nil
+}
+
+// isValidWebhookUrl проверяет схему и отклоняет localhost и внутренние адреса. Имена хостов
+// резолвятся только при отправке, там диспетчер проверяет адрес еще раз
+func isValidWebhookUrl(rawUrl string) bool {
+	if rawUrl == "" || len(rawUrl) > 2048 {
+		return false
+	}
+
+	parsed, err := url.Parse(rawUrl)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return false
+	}
+
+	host := strings.ToLower(strings.TrimSuffix(parsed.Hostname(), "."))
+	if host == "" || host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+	if ip := net.ParseIP(host); ip != nil && !common.IsPublicIP(ip) {
+		return false
+	}
+	return true
+}
+
+func areValidWebhookEventTypes(eventTypes []string) bool {
+	for _, eventType := range eventTypes {
+		if !entity.ValidWebhookEventTypes[eventType] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,34 @@
+package request
+
+import "testing"
+
+func TestIsValidWebhookUrl(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://example.com/hooks", true},
+		{"http://example.com:8080/hooks", true},
+		{"https://8.8.8.8/hooks", true},
+		{"", false},
+		{"ftp://example.com/hooks", false},
+		{"https:///hooks", false},
+		{"http://localhost:8080/hooks", false},
+		{"http://LOCALHOST./hooks", false},
+		{"http://api.localhost/hooks", false},
+		{"http://127.0.0.1/hooks", false},
+		{"http://[::1]:8080/hooks", false},
+		{"http://10.1.2.3/hooks", false},
+		{"http://192.168.0.10/hooks", false},
+		{"http://169.254.169.254/latest/meta-data", false},
+		{"http://0.0.0.0/hooks", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			if got := isValidWebhookUrl(tt.url); got != tt.want {
+				t.Errorf("isValidWebhookUrl(%q) = %v, want %v", tt.url, got, tt.want)
+			}
+		})
+	}
+}
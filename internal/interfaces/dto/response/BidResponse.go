@@ -0,0 +1,72 @@
+package response
+
+import (
+	"github.com/google/uuid"
+	"tenders/internal/domain/entity"
+	"tenders/internal/utils/common/custom_types"
+)
+
+// BidResponse краткое представление предложения без описания и привязки к тендеру
+type BidResponse struct {
+	Id         uuid.UUID                `json:"id"`
+	Name       string                   `json:"name"`
+	Status     string                   `json:"status"`
+	AuthorType string                   `json:"authorType"`
+	AuthorId   uuid.UUID                `json:"authorId"`
+	Amount     *custom_types.Decimal    `json:"amount,omitempty"`
+	Currency   string                   `json:"currency,omitempty"`
+	Version    int                      `json:"version"`
+	CreatedAt  custom_types.RFC3339Time `json:"createdAt"`
+}
+
+// BidDetailsResponse полное представление предложения для автора и ответственных за тендер
+type BidDetailsResponse struct {
+	BidResponse
+	Description   string                    `json:"description"`
+	TenderId      uuid.UUID                 `json:"tenderId"`
+	TenderVersion int                       `json:"tenderVersion"`
+	DeliveryDays  *int                      `json:"deliveryDays,omitempty"`
+	Decisions     entity.BidDecisionSummary `json:"decisions"`
+}
+
+func NewBidResponse(bid *entity.Bid) BidResponse {
+	return BidResponse{
+		Id:         bid.BidId,
+		Name:       bid.Name,
+		Status:     bid.Status,
+		AuthorType: bid.AuthorType,
+		AuthorId:   bid.AuthorId,
+		Amount:     bid.Amount,
+		Currency:   bid.Currency,
+		Version:    bid.Version,
+		CreatedAt:  bid.CreatedAt,
+	}
+}
+
+func NewBidDetailsResponse(bid *entity.Bid, decisions entity.BidDecisionSummary) BidDetailsResponse {
+	return BidDetailsResponse{
+		BidResponse:   NewBidResponse(bid),
+		Description:   bid.Description,
+		TenderId:      bid.TenderId,
+		TenderVersion: bid.TenderVersion,
+		DeliveryDays:  bid.DeliveryDays,
+		Decisions:     decisions,
+	}
+}
+
+func NewBidResponses(bids []entity.Bid) []BidResponse {
+	responses := make([]BidResponse, 0, len(bids))
+	for i := range bids {
+		responses = append(responses, NewBidResponse(&bids[i]))
+	}
+	return responses
+}
+
+// NewBidDetailsResponses собирает полные представления, для предложений без решений сводка нулевая
+func NewBidDetailsResponses(bids []entity.Bid, decisions map[uuid.UUID]entity.BidDecisionSummary) []BidDetailsResponse {
+	responses := make([]BidDetailsResponse, 0, len(bids))
+	for i := range bids {
+		responses = append(responses, NewBidDetailsResponse(&bids[i], decisions[bids[i].BidId]))
+	}
+	return responses
+}
@@ -0,0 +1,10 @@
+package response
+
+import "tenders/internal/utils/diff"
+
+// DiffResponse список полей, изменившихся между версиями From и To
+type DiffResponse struct {
+	From    int                `json:"from"`
+	To      int                `json:"to"`
+	Changes []diff.FieldChange `json:"changes"`
+}
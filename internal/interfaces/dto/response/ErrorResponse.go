@@ -1,16 +1,10 @@
 package response
 
-import (
-	"strings"
-)
-
+// ErrorResponse тело ответа с ошибкой: Code стабильный машиночитаемый код, Reason описание для человека,
+// Fields поля запроса, к которым относится ошибка, Permission разрешение, которого не хватило для запроса
 type ErrorResponse struct {
-	Reason string `json:"reason"`
-}
-
-func NewValidationErrorResponse(errors []string) ErrorResponse {
-	reason := "Неправильно заполнены поля: " + strings.Join(errors, ", ")
-	return ErrorResponse{
-		Reason: reason,
-	}
+	Code       string   `json:"code"`
+	Reason     string   `json:"reason"`
+	Fields     []string `json:"fields"`
+	Permission string   `json:"permission,omitempty"`
 }
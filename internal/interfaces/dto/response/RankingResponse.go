@@ -0,0 +1,27 @@
+package response
+
+import "tenders/internal/domain/entity"
+
+// RankingResponse место предложения в рейтинге, у неоцененных предложений место и итог null
+type RankingResponse struct {
+	Rank        *int                   `json:"rank"`
+	Bid         BidResponse            `json:"bid"`
+	Score       *float64               `json:"score"`
+	Evaluations []entity.BidEvaluation `json:"evaluations"`
+}
+
+func NewRankingResponses(rankings []entity.BidRanking) []RankingResponse {
+	responses := make([]RankingResponse, 0, len(rankings))
+	for i := range rankings {
+		response := RankingResponse{
+			Bid:         NewBidResponse(&rankings[i].Bid),
+			Score:       rankings[i].Score,
+			Evaluations: rankings[i].Evaluations,
+		}
+		if rankings[i].Score != nil {
+			response.Rank = &rankings[i].Rank
+		}
+		responses = append(responses, response)
+	}
+	return responses
+}
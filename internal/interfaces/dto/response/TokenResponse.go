@@ -0,0 +1,8 @@
+package response
+
+import "tenders/internal/utils/common/custom_types"
+
+type TokenResponse struct {
+	Token     string                   `json:"token"`
+	ExpiresAt custom_types.RFC3339Time `json:"expiresAt"`
+}
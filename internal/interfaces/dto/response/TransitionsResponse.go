@@ -0,0 +1,10 @@
+package response
+
+import "tenders/internal/domain/statemachine"
+
+// TransitionsResponse текущий статус и переходы, которые может выполнить пользователь
+type TransitionsResponse struct {
+	Status      string                    `json:"status"`
+	Version     int                       `json:"version"`
+	Transitions []statemachine.Transition `json:"transitions"`
+}
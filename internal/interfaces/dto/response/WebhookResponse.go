@@ -0,0 +1,9 @@
+package response
+
+import "tenders/internal/domain/entity"
+
+// WebhookCreatedResponse подписка вместе с секретом, секрет отдается только при создании
+type WebhookCreatedResponse struct {
+	entity.WebhookSubscription
+	Secret string `json:"secret"`
+}
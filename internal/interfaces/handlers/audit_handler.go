@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"net/http"
+	"tenders/internal/application/interfaces"
+	"tenders/internal/domain/entity"
+	"tenders/internal/domain/repository"
+	"tenders/internal/utils"
+	"tenders/internal/utils/common"
+)
+
+type AuditHandler struct {
+	service interfaces.AuditService
+}
+
+func NewAuditHandler(service interfaces.AuditService) *AuditHandler {
+	return &AuditHandler{service: service}
+}
+
+func (h *AuditHandler) GetAuditEvents(w http.ResponseWriter, r *http.Request) {
+	if common.CheckForExtraParams(r, []string{"entityType", "entityId", "actorId", "from", "to", "limit", "offset"}) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
+		return
+	}
+
+	limit, offset, err := common.GetPaginationParams(r)
+	if err != nil {
+		common.RespondWithError(w, r, utils.IncorrectLimitOffsetError)
+		return
+	}
+
+	filter, err := parseAuditFilter(r)
+	if err != nil {
+		common.RespondWithError(w, r, err)
+		return
+	}
+
+	events, err := h.service.FindAll(r.Context(), filter, limit, offset)
+	if err != nil {
+		common.RespondWithError(w, r, err)
+		return
+	}
+
+	common.RespondOKWithJson(w, events)
+}
+
+func parseAuditFilter(r *http.Request) (repository.AuditFilter, error) {
+	var filter repository.AuditFilter
+	var err error
+
+	filter.EntityType = r.URL.Query().Get("entityType")
+	if filter.EntityType != "" && !entity.ValidAuditEntityTypes[filter.EntityType] {
+		return filter, utils.IncorrectFilterError.WithFields("entityType")
+	}
+
+	if filter.EntityId, err = common.GetOptionalUUIDParam(r, "entityId"); err != nil {
+		return filter, utils.IncorrectFilterError.WithFields("entityId")
+	}
+	if filter.ActorId, err = common.GetOptionalUUIDParam(r, "actorId"); err != nil {
+		return filter, utils.IncorrectFilterError.WithFields("actorId")
+	}
+	if filter.From, err = common.GetOptionalTimeParam(r, "from"); err != nil {
+		return filter, utils.IncorrectFilterError.WithFields("from")
+	}
+	if filter.To, err = common.GetOptionalTimeParam(r, "to"); err != nil {
+		return filter, utils.IncorrectFilterError.WithFields("to")
+	}
+
+	return filter, nil
+}
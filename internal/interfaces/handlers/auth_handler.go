@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"net/http"
+	"tenders/internal/application/interfaces"
+	"tenders/internal/interfaces/dto/request"
+	"tenders/internal/interfaces/dto/response"
+	"tenders/internal/utils"
+	"tenders/internal/utils/common"
+	"tenders/internal/utils/common/custom_types"
+)
+
+type AuthHandler struct {
+	service interfaces.AuthService
+}
+
+func NewAuthHandler(service interfaces.AuthService) *AuthHandler {
+	return &AuthHandler{service: service}
+}
+
+func (h *AuthHandler) IssueToken(w http.ResponseWriter, r *http.Request) {
+	var tokenRequest request.TokenRequest
+	if err := common.DecodeAndValidateJSON(r.Body, &tokenRequest); err != nil {
+		common.RespondWithError(w, r, utils.IncorrectRequestBody)
+		return
+	}
+
+	if common.CheckForExtraParams(r, []string{}) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
+		return
+	}
+
+	token, expiresAt, err := h.service.IssueToken(r.Context(), tokenRequest.Username, tokenRequest.Password)
+	if err != nil {
+		common.RespondWithError(w, r, err)
+		return
+	}
+
+	common.RespondOKWithJson(w, response.TokenResponse{
+		Token:     token,
+		ExpiresAt: custom_types.RFC3339Time(expiresAt),
+	})
+}
@@ -1,17 +1,25 @@
 package handlers
 
 import (
-	"errors"
 	"github.com/google/uuid"
 	"net/http"
-	"strings"
 	"tenders/internal/application/interfaces"
+	"tenders/internal/domain/entity"
+	"tenders/internal/domain/repository"
 	"tenders/internal/interfaces/dto/request"
+	"tenders/internal/interfaces/dto/response"
 	"tenders/internal/utils"
 	"tenders/internal/utils/common"
 	"tenders/internal/utils/consts"
+	"tenders/internal/utils/diff"
 )
 
+// bidListParams параметры списков предложений: фильтры, сортировка, страница и представление
+var bidListParams = []string{
+	"status", "author_type", "created_from", "created_to",
+	"sort", "limit", "offset", "cursor", "total", "view",
+}
+
 type BidHandler struct {
 	service interfaces.BidService
 }
@@ -23,301 +31,398 @@ func NewBidHandler(service interfaces.BidService) *BidHandler {
 func (h *BidHandler) CreateBid(w http.ResponseWriter, r *http.Request) {
 	var bidRequest request.BidRequest
 	if err := common.DecodeAndValidateJSON(r.Body, &bidRequest); err != nil {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectRequestBody)
+		common.RespondWithError(w, r, utils.IncorrectRequestBody)
 		return
 	}
 
 	if common.CheckForExtraParams(r, []string{}) {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectParams)
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
 		return
 	}
 
-	bid, err := h.service.CreateNewBid(&bidRequest)
+	bid, err := h.service.CreateNewBid(r.Context(), &bidRequest)
 	if err != nil {
-		if errors.Is(err, utils.ElementNotExistsError) {
-			common.RespondWithError(w, http.StatusUnauthorized, consts.UserOrOrgNotExists)
-		} else if errors.Is(err, utils.TenderNotExistsError) {
-			common.RespondWithError(w, http.StatusNotFound, consts.TenderNotExists)
-		} else if strings.HasPrefix(err.Error(), "Неправильно") {
-			common.RespondWithError(w, http.StatusBadRequest, err.Error())
-		} else {
-			common.RespondWithError(w, http.StatusInternalServerError, consts.InternalServerError+" "+err.Error())
-		}
+		common.RespondWithError(w, r, err)
 		return
 	}
 
-	common.RespondOKWithJson(w, bid)
+	h.respondWithBidDetails(w, r, bid)
 }
 
 func (h *BidHandler) GetAllBidsByUsername(w http.ResponseWriter, r *http.Request) {
-	if common.CheckForExtraParams(r, []string{"username", "limit", "offset"}) {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectParams)
+	if common.CheckForExtraParams(r, bidListParams) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
 		return
 	}
 
-	username := r.URL.Query().Get("username")
-	if username == "" {
-		common.RespondWithError(w, http.StatusBadRequest, consts.NoUsernameParamPresent)
+	filter, err := parseBidFilter(r)
+	if err != nil {
+		common.RespondWithError(w, r, err)
 		return
 	}
 
-	limit, offset, err := common.GetPaginationParams(r)
+	page, err := common.GetPageParamsWithSort(r, repository.ValidBidSortFields)
 	if err != nil {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectLimitOffsetParams)
+		common.RespondWithError(w, r, err)
 		return
 	}
 
-	tenders, err := h.service.FindAllByEmployeeUsername(username, limit, offset)
+	compact, err := common.IsCompactViewRequested(r)
 	if err != nil {
-		if errors.Is(err, utils.UserNotExistsError) {
-			common.RespondWithError(w, http.StatusUnauthorized, consts.UserNotExists)
-		} else {
-			common.RespondWithError(w, http.StatusInternalServerError, consts.InternalServerError+" "+err.Error())
-		}
+		common.RespondWithError(w, r, utils.IncorrectViewError)
 		return
 	}
 
-	common.RespondOKWithJson(w, tenders)
+	bids, err := h.service.FindAllByEmployee(r.Context(), filter, page)
+	if err != nil {
+		common.RespondWithError(w, r, err)
+		return
+	}
+
+	common.SetPageHeaders(w, bids.Next, bids.Total)
+	h.respondWithBidList(w, r, bids.Items, compact)
 }
 
 func (h *BidHandler) GetAllBidsByTender(w http.ResponseWriter, r *http.Request) {
 	tenderId, err := common.GetUUIDFromRequestPath(r, "tenderId")
 	if err != nil {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectTenderId)
+		common.RespondWithError(w, r, utils.IncorrectTenderIdError)
 		return
 	}
 
-	if common.CheckForExtraParams(r, []string{"username", "limit", "offset"}) {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectParams)
+	if common.CheckForExtraParams(r, bidListParams) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
 		return
 	}
 
-	username := r.URL.Query().Get("username")
-	if username == "" {
-		common.RespondWithError(w, http.StatusBadRequest, consts.NoUsernameParamPresent)
+	filter, err := parseBidFilter(r)
+	if err != nil {
+		common.RespondWithError(w, r, err)
 		return
 	}
 
-	limit, offset, err := common.GetPaginationParams(r)
+	page, err := common.GetPageParamsWithSort(r, repository.ValidBidSortFields)
 	if err != nil {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectLimitOffsetParams)
+		common.RespondWithError(w, r, err)
 		return
 	}
 
-	bids, err := h.service.FindAllByTenderId(tenderId, username, limit, offset)
+	compact, err := common.IsCompactViewRequested(r)
 	if err != nil {
-		if errors.Is(err, utils.TenderNotExistsError) {
-			common.RespondWithError(w, http.StatusNotFound, consts.TenderNotExists)
-		} else if errors.Is(err, utils.UnauthorizedAccessError) {
-			common.RespondWithError(w, http.StatusForbidden, consts.InsufficientPermissions)
-		} else if errors.Is(err, utils.UserNotExistsError) {
-			common.RespondWithError(w, http.StatusUnauthorized, consts.UserNotExists)
-		} else {
-			common.RespondWithError(w, http.StatusInternalServerError, consts.InternalServerError+" "+err.Error())
-		}
+		common.RespondWithError(w, r, utils.IncorrectViewError)
+		return
+	}
+
+	bids, err := h.service.FindAllByTenderId(r.Context(), tenderId, filter, page)
+	if err != nil {
+		common.RespondWithError(w, r, err)
+		return
+	}
+
+	prices, err := h.service.FindPriceStats(r.Context(), tenderId, filter)
+	if err != nil {
+		common.RespondWithError(w, r, err)
+		return
+	}
+
+	common.SetPageHeaders(w, bids.Next, bids.Total)
+	common.SetPriceStatsHeaders(w, prices)
+	h.respondWithBidList(w, r, bids.Items, compact)
+}
+
+// GetPriceStats отдает сводку цен предложений тендера по валютам с теми же фильтрами, что и список
+func (h *BidHandler) GetPriceStats(w http.ResponseWriter, r *http.Request) {
+	tenderId, err := common.GetUUIDFromRequestPath(r, "tenderId")
+	if err != nil {
+		common.RespondWithError(w, r, utils.IncorrectTenderIdError)
+		return
+	}
+
+	if common.CheckForExtraParams(r, []string{"status", "author_type", "created_from", "created_to"}) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
+		return
+	}
+
+	filter, err := parseBidFilter(r)
+	if err != nil {
+		common.RespondWithError(w, r, err)
+		return
+	}
+
+	prices, err := h.service.FindPriceStats(r.Context(), tenderId, filter)
+	if err != nil {
+		common.RespondWithError(w, r, err)
 		return
 	}
 
-	common.RespondOKWithJson(w, bids)
+	common.RespondOKWithJson(w, prices)
+}
+
+func parseBidFilter(r *http.Request) (repository.BidFilter, error) {
+	var filter repository.BidFilter
+	var err error
+	query := r.URL.Query()
+
+	for _, status := range query["status"] {
+		if !entity.ValidBidStatuses[status] {
+			return filter, utils.IncorrectFilterError.WithFields("status")
+		}
+		filter.Statuses = append(filter.Statuses, status)
+	}
+
+	for _, authorType := range query["author_type"] {
+		if authorType != consts.AuthorTypeUser && authorType != consts.AuthorTypeOrganization {
+			return filter, utils.IncorrectFilterError.WithFields("author_type")
+		}
+		filter.AuthorTypes = append(filter.AuthorTypes, authorType)
+	}
+
+	if filter.CreatedFrom, filter.CreatedTo, err = common.GetCreatedRangeParams(r); err != nil {
+		return filter, err
+	}
+
+	return filter, nil
 }
 
 func (h *BidHandler) GetBidStatusById(w http.ResponseWriter, r *http.Request) {
 	bidIdStr := r.PathValue("bidId")
 	bidId, err := uuid.Parse(bidIdStr)
 	if err != nil {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectTenderId)
+		common.RespondWithError(w, r, utils.IncorrectTenderIdError)
 		return
 	}
 
-	if common.CheckForExtraParams(r, []string{"username"}) {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectParams)
+	if common.CheckForExtraParams(r, []string{}) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
 		return
 	}
 
-	username := r.URL.Query().Get("username")
-	if username == "" {
-		common.RespondWithError(w, http.StatusBadRequest, consts.NoUsernameParamPresent)
+	bid, err := h.service.FindVisibleByBidId(r.Context(), bidId)
+	if err != nil {
+		common.RespondWithError(w, r, err)
 		return
 	}
 
-	status, err := h.service.GetStatusByBidId(bidId, username)
+	common.SetETag(w, bid.Version)
+	w.Header().Set("Content-Type", "text/plain")
+	if _, err = w.Write([]byte(bid.Status)); err != nil {
+		common.RespondWithError(w, r, utils.FailedToWriteResponseError)
+	}
+}
+
+func (h *BidHandler) GetBidDiff(w http.ResponseWriter, r *http.Request) {
+	bidId, err := common.GetUUIDFromRequestPath(r, "bidId")
 	if err != nil {
-		if errors.Is(err, utils.BidNotExistsError) {
-			common.RespondWithError(w, http.StatusNotFound, consts.BidNotExists)
-		} else if errors.Is(err, utils.UnauthorizedAccessError) {
-			common.RespondWithError(w, http.StatusForbidden, consts.InsufficientPermissions)
-		} else if errors.Is(err, utils.UserNotExistsError) {
-			common.RespondWithError(w, http.StatusUnauthorized, consts.UserNotExists)
-		} else {
-			common.RespondWithError(w, http.StatusInternalServerError, consts.InternalServerError+" "+err.Error())
-		}
+		common.RespondWithError(w, r, utils.IncorrectBidIdError)
 		return
 	}
 
-	w.Header().Set("Content-Type", "text/plain")
-	if _, err = w.Write([]byte(status)); err != nil {
-		common.RespondWithError(w, http.StatusInternalServerError, consts.FailedToWriteResponse)
+	if common.CheckForExtraParams(r, []string{"from", "to", "format"}) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
+		return
 	}
+
+	from, to, err := common.GetVersionRangeParams(r)
+	if err != nil {
+		common.RespondWithError(w, r, utils.IncorrectVersionRangeError)
+		return
+	}
+
+	jsonPatch, err := common.IsJsonPatchRequested(r)
+	if err != nil {
+		common.RespondWithError(w, r, utils.IncorrectDiffFormatError)
+		return
+	}
+
+	changes, err := h.service.DiffVersions(r.Context(), bidId, from, to)
+	if err != nil {
+		common.RespondWithError(w, r, err)
+		return
+	}
+
+	if jsonPatch {
+		common.RespondOKWithJsonPatch(w, diff.Patch(changes))
+		return
+	}
+	common.RespondOKWithJson(w, response.DiffResponse{From: from, To: to, Changes: changes})
+}
+
+func (h *BidHandler) GetBidTransitions(w http.ResponseWriter, r *http.Request) {
+	bidId, err := common.GetUUIDFromRequestPath(r, "bidId")
+	if err != nil {
+		common.RespondWithError(w, r, utils.IncorrectBidIdError)
+		return
+	}
+
+	if common.CheckForExtraParams(r, []string{}) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
+		return
+	}
+
+	bid, transitions, err := h.service.AvailableTransitions(r.Context(), bidId)
+	if err != nil {
+		common.RespondWithError(w, r, err)
+		return
+	}
+
+	common.SetETag(w, bid.Version)
+	common.RespondOKWithJson(w, response.TransitionsResponse{
+		Status:      bid.Status,
+		Version:     bid.Version,
+		Transitions: transitions,
+	})
 }
+
 func (h *BidHandler) UpdateBidStatusById(w http.ResponseWriter, r *http.Request) {
 	bidId, err := common.GetUUIDFromRequestPath(r, "bidId")
 	if err != nil {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectBidId)
+		common.RespondWithError(w, r, utils.IncorrectBidIdError)
 		return
 	}
 
-	if common.CheckForExtraParams(r, []string{"username", "status"}) {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectParams)
+	if common.CheckForExtraParams(r, []string{"status"}) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
 		return
 	}
 
 	status := r.URL.Query().Get("status")
 	err = common.ValidateBidStatus(status)
 	if status == "" || err != nil {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectStatus)
+		common.RespondWithError(w, r, utils.IncorrectStatusError)
 		return
 	}
 
-	username := r.URL.Query().Get("username")
-	if username == "" {
-		common.RespondWithError(w, http.StatusBadRequest, consts.NoUsernameParamPresent)
+	expectedVersion, err := common.GetExpectedVersion(r)
+	if err != nil {
+		common.RespondWithError(w, r, utils.IncorrectIfMatchError)
 		return
 	}
 
-	bid, err := h.service.UpdateStatus(bidId, status, username)
+	bid, err := h.service.UpdateStatus(r.Context(), bidId, status, expectedVersion)
 	if err != nil {
-		if errors.Is(err, utils.BidNotExistsError) {
-			common.RespondWithError(w, http.StatusNotFound, consts.BidNotExists)
-		} else if errors.Is(err, utils.UnauthorizedAccessError) {
-			common.RespondWithError(w, http.StatusForbidden, consts.InsufficientPermissions)
-		} else if errors.Is(err, utils.UserNotExistsError) {
-			common.RespondWithError(w, http.StatusUnauthorized, consts.UserNotExists)
-		} else {
-			common.RespondWithError(w, http.StatusInternalServerError, consts.InternalServerError+" "+err.Error())
-		}
+		common.RespondWithError(w, r, err)
 		return
 	}
-	common.RespondOKWithJson(w, bid)
+	common.SetETag(w, bid.Version)
+	h.respondWithBidDetails(w, r, bid)
 }
 
 func (h *BidHandler) EditBid(w http.ResponseWriter, r *http.Request) {
 	bidId, err := common.GetUUIDFromRequestPath(r, "bidId")
 	if err != nil {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectBidId)
+		common.RespondWithError(w, r, utils.IncorrectBidIdError)
 		return
 	}
 
-	if common.CheckForExtraParams(r, []string{"username"}) {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectParams)
+	if common.CheckForExtraParams(r, []string{}) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
 		return
 	}
 
-	username := r.URL.Query().Get("username")
-	if username == "" {
-		common.RespondWithError(w, http.StatusBadRequest, consts.NoUsernameParamPresent)
+	var updateRequest request.EditBidRequest
+	if err := common.DecodeAndValidateJSON(r.Body, &updateRequest); err != nil {
+		common.RespondWithError(w, r, utils.IncorrectRequestBody)
 		return
 	}
 
-	var updateRequest request.EditBidRequest
-	if err := common.DecodeAndValidateJSON(r.Body, &updateRequest); err != nil {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectRequestBody)
+	expectedVersion, err := common.GetExpectedVersion(r)
+	if err != nil {
+		common.RespondWithError(w, r, utils.IncorrectIfMatchError)
 		return
 	}
 
-	updatedBid, err := h.service.EditBid(bidId, username, &updateRequest)
+	updatedBid, err := h.service.EditBid(r.Context(), bidId, &updateRequest, expectedVersion)
 	if err != nil {
-		if errors.Is(err, utils.BidNotExistsError) {
-			common.RespondWithError(w, http.StatusNotFound, consts.BidNotExists)
-		} else if errors.Is(err, utils.UnauthorizedAccessError) {
-			common.RespondWithError(w, http.StatusForbidden, consts.InsufficientPermissions)
-		} else if errors.Is(err, utils.UserNotExistsError) {
-			common.RespondWithError(w, http.StatusUnauthorized, consts.UserNotExists)
-		} else {
-			common.RespondWithError(w, http.StatusInternalServerError, consts.InternalServerError+" "+err.Error())
-		}
+		common.RespondWithError(w, r, err)
 		return
 	}
-	common.RespondOKWithJson(w, updatedBid)
+	common.SetETag(w, updatedBid.Version)
+	h.respondWithBidDetails(w, r, updatedBid)
 }
 
 func (h *BidHandler) RollbackBid(w http.ResponseWriter, r *http.Request) {
 	bidId, err := common.GetUUIDFromRequestPath(r, "bidId")
 	if err != nil {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectBidId)
+		common.RespondWithError(w, r, utils.IncorrectBidIdError)
 		return
 	}
 
-	if common.CheckForExtraParams(r, []string{"username", "version"}) {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectParams)
+	if common.CheckForExtraParams(r, []string{"version"}) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
 		return
 	}
 
-	username := r.URL.Query().Get("username")
-	if username == "" {
-		common.RespondWithError(w, http.StatusBadRequest, consts.NoUsernameParamPresent)
+	version, err := common.GetVersionFromRequestPath(r)
+	if err != nil {
+		common.RespondWithError(w, r, utils.IncorrectVersionError)
 		return
 	}
 
-	version, err := common.GetVersionFromRequestPath(r)
+	expectedVersion, err := common.GetExpectedVersion(r)
 	if err != nil {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectVersion)
+		common.RespondWithError(w, r, utils.IncorrectIfMatchError)
 		return
 	}
 
-	updatedBid, err := h.service.RollbackBid(bidId, version, username)
+	updatedBid, err := h.service.RollbackBid(r.Context(), bidId, version, expectedVersion)
 	if err != nil {
-		if errors.Is(err, utils.BidNotExistsError) {
-			common.RespondWithError(w, http.StatusNotFound, consts.BidNotExists)
-		} else if errors.Is(err, utils.VersionNotExistsError) {
-			common.RespondWithError(w, http.StatusNotFound, consts.VersionNotExists)
-		} else if errors.Is(err, utils.UnauthorizedAccessError) {
-			common.RespondWithError(w, http.StatusForbidden, consts.InsufficientPermissions)
-		} else if errors.Is(err, utils.UserNotExistsError) {
-			common.RespondWithError(w, http.StatusUnauthorized, consts.UserNotExists)
-		} else {
-			common.RespondWithError(w, http.StatusInternalServerError, consts.InternalServerError+" "+err.Error())
-		}
+		common.RespondWithError(w, r, err)
 		return
 	}
-	common.RespondOKWithJson(w, updatedBid)
+	common.SetETag(w, updatedBid.Version)
+	h.respondWithBidDetails(w, r, updatedBid)
 }
 
 func (h *BidHandler) SubmitDecision(w http.ResponseWriter, r *http.Request) {
 	bidId, err := common.GetUUIDFromRequestPath(r, "bidId")
 	if err != nil {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectBidId)
+		common.RespondWithError(w, r, utils.IncorrectBidIdError)
 		return
 	}
 
-	if common.CheckForExtraParams(r, []string{"decision", "username"}) {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectParams)
+	if common.CheckForExtraParams(r, []string{"decision"}) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
 		return
 	}
 
-	username := r.URL.Query().Get("username")
-	if username == "" {
-		common.RespondWithError(w, http.StatusBadRequest, consts.NoUsernameParamPresent)
+	decision := r.URL.Query().Get("decision")
+	if decision != consts.BidApproved && decision != consts.BidRejected {
+		common.RespondWithError(w, r, utils.IncorrectDecisionError)
 		return
 	}
 
-	decision := r.URL.Query().Get("decision")
-	if decision != consts.BidApproved && decision != consts.BidRejected {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectDecision)
+	updatedBid, err := h.service.SubmitDecision(r.Context(), bidId, decision)
+	if err != nil {
+		common.RespondWithError(w, r, err)
 		return
 	}
+	common.SetETag(w, updatedBid.Version)
+	h.respondWithBidDetails(w, r, updatedBid)
+}
 
-	updatedBid, err := h.service.SubmitDecision(bidId, username, decision)
+// respondWithBidDetails отдает полное представление предложения вместе со сводкой решений по нему
+func (h *BidHandler) respondWithBidDetails(w http.ResponseWriter, r *http.Request, bid *entity.Bid) {
+	decisions, err := h.service.SummarizeDecisions(r.Context(), *bid)
 	if err != nil {
-		if errors.Is(err, utils.BidNotExistsError) {
-			common.RespondWithError(w, http.StatusNotFound, consts.BidNotExists)
-		} else if errors.Is(err, utils.UnauthorizedAccessError) {
-			common.RespondWithError(w, http.StatusForbidden, consts.InsufficientPermissions)
-		} else if errors.Is(err, utils.UserNotExistsError) {
-			common.RespondWithError(w, http.StatusUnauthorized, consts.UserNotExists)
-		} else {
-			common.RespondWithError(w, http.StatusInternalServerError, consts.InternalServerError+" "+err.Error())
-		}
+		common.RespondWithError(w, r, err)
+		return
+	}
+
+	common.RespondOKWithJson(w, response.NewBidDetailsResponse(bid, decisions[bid.BidId]))
+}
+
+// respondWithBidList отдает список предложений в кратком (view=compact) или полном представлении
+func (h *BidHandler) respondWithBidList(w http.ResponseWriter, r *http.Request, bids []entity.Bid, compact bool) {
+	if compact {
+		common.RespondOKWithJson(w, response.NewBidResponses(bids))
 		return
 	}
-	common.RespondOKWithJson(w, updatedBid)
+
+	decisions, err := h.service.SummarizeDecisions(r.Context(), bids...)
+	if err != nil {
+		common.RespondWithError(w, r, err)
+		return
+	}
+
+	common.RespondOKWithJson(w, response.NewBidDetailsResponses(bids, decisions))
 }
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"net/http"
+	"tenders/internal/application/interfaces"
+	"tenders/internal/interfaces/dto/request"
+	"tenders/internal/utils"
+	"tenders/internal/utils/common"
+)
+
+type EmployeeHandler struct {
+	service interfaces.EmployeeService
+}
+
+func NewEmployeeHandler(service interfaces.EmployeeService) *EmployeeHandler {
+	return &EmployeeHandler{service: service}
+}
+
+func (h *EmployeeHandler) CreateEmployee(w http.ResponseWriter, r *http.Request) {
+	var employeeRequest request.EmployeeRequest
+	if err := common.DecodeAndValidateJSON(r.Body, &employeeRequest); err != nil {
+		common.RespondWithError(w, r, utils.IncorrectRequestBody)
+		return
+	}
+
+	if common.CheckForExtraParams(r, []string{}) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
+		return
+	}
+
+	employee, err := h.service.Create(r.Context(), &employeeRequest)
+	if err != nil {
+		common.RespondWithError(w, r, err)
+		return
+	}
+
+	common.RespondOKWithJson(w, employee)
+}
+
+func (h *EmployeeHandler) EditEmployee(w http.ResponseWriter, r *http.Request) {
+	employeeId, err := common.GetUUIDFromRequestPath(r, "employeeId")
+	if err != nil {
+		common.RespondWithError(w, r, utils.IncorrectEmployeeIdError)
+		return
+	}
+
+	if common.CheckForExtraParams(r, []string{}) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
+		return
+	}
+
+	var editRequest request.EditEmployeeRequest
+	if err := common.DecodeAndValidateJSON(r.Body, &editRequest); err != nil {
+		common.RespondWithError(w, r, utils.IncorrectRequestBody)
+		return
+	}
+
+	employee, err := h.service.Edit(r.Context(), employeeId, &editRequest)
+	if err != nil {
+		common.RespondWithError(w, r, err)
+		return
+	}
+
+	common.RespondOKWithJson(w, employee)
+}
+
+func (h *EmployeeHandler) DeactivateEmployee(w http.ResponseWriter, r *http.Request) {
+	employeeId, err := common.GetUUIDFromRequestPath(r, "employeeId")
+	if err != nil {
+		common.RespondWithError(w, r, utils.IncorrectEmployeeIdError)
+		return
+	}
+
+	if common.CheckForExtraParams(r, []string{}) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
+		return
+	}
+
+	employee, err := h.service.Deactivate(r.Context(), employeeId)
+	if err != nil {
+		common.RespondWithError(w, r, err)
+		return
+	}
+
+	common.RespondOKWithJson(w, employee)
+}
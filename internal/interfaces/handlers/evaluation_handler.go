@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"net/http"
+	"tenders/internal/application/interfaces"
+	"tenders/internal/interfaces/dto/request"
+	"tenders/internal/interfaces/dto/response"
+	"tenders/internal/utils"
+	"tenders/internal/utils/common"
+)
+
+type EvaluationHandler struct {
+	service interfaces.EvaluationService
+}
+
+func NewEvaluationHandler(service interfaces.EvaluationService) *EvaluationHandler {
+	return &EvaluationHandler{service: service}
+}
+
+func (h *EvaluationHandler) SetCriteria(w http.ResponseWriter, r *http.Request) {
+	if common.CheckForExtraParams(r, []string{}) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
+		return
+	}
+
+	tenderId, err := common.GetUUIDFromRequestPath(r, "tenderId")
+	if err != nil {
+		common.RespondWithError(w, r, utils.IncorrectTenderIdError)
+		return
+	}
+
+	var criteriaRequest request.CriteriaRequest
+	if err = common.DecodeAndValidateJSON(r.Body, &criteriaRequest); err != nil {
+		common.RespondWithError(w, r, utils.IncorrectRequestBody)
+		return
+	}
+
+	criteria, err := criteriaRequest.MapToCriteria()
+	if err != nil {
+		common.RespondWithError(w, r, err)
+		return
+	}
+
+	criteria, err = h.service.SetCriteria(r.Context(), tenderId, criteria)
+	if err != nil {
+		common.RespondWithError(w, r, err)
+		return
+	}
+	common.RespondOKWithJson(w, criteria)
+}
+
+func (h *EvaluationHandler) GetCriteria(w http.ResponseWriter, r *http.Request) {
+	if common.CheckForExtraParams(r, []string{}) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
+		return
+	}
+
+	tenderId, err := common.GetUUIDFromRequestPath(r, "tenderId")
+	if err != nil {
+		common.RespondWithError(w, r, utils.IncorrectTenderIdError)
+		return
+	}
+
+	criteria, err := h.service.FindCriteria(r.Context(), tenderId)
+	if err != nil {
+		common.RespondWithError(w, r, err)
+		return
+	}
+	common.RespondOKWithJson(w, criteria)
+}
+
+func (h *EvaluationHandler) SubmitScores(w http.ResponseWriter, r *http.Request) {
+	if common.CheckForExtraParams(r, []string{}) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
+		return
+	}
+
+	bidId, err := common.GetUUIDFromRequestPath(r, "bidId")
+	if err != nil {
+		common.RespondWithError(w, r, utils.IncorrectBidIdError)
+		return
+	}
+
+	var scoresRequest request.ScoresRequest
+	if err = common.DecodeAndValidateJSON(r.Body, &scoresRequest); err != nil {
+		common.RespondWithError(w, r, utils.IncorrectRequestBody)
+		return
+	}
+
+	scores, err := scoresRequest.MapToScores()
+	if err != nil {
+		common.RespondWithError(w, r, err)
+		return
+	}
+
+	evaluation, err := h.service.SubmitScores(r.Context(), bidId, scores)
+	if err != nil {
+		common.RespondWithError(w, r, err)
+		return
+	}
+	common.RespondOKWithJson(w, evaluation)
+}
+
+func (h *EvaluationHandler) GetRanking(w http.ResponseWriter, r *http.Request) {
+	if common.CheckForExtraParams(r, []string{}) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
+		return
+	}
+
+	tenderId, err := common.GetUUIDFromRequestPath(r, "tenderId")
+	if err != nil {
+		common.RespondWithError(w, r, utils.IncorrectTenderIdError)
+		return
+	}
+
+	rankings, err := h.service.Ranking(r.Context(), tenderId)
+	if err != nil {
+		common.RespondWithError(w, r, err)
+		return
+	}
+	common.RespondOKWithJson(w, response.NewRankingResponses(rankings))
+}
@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"tenders/internal/application/interfaces"
+	"tenders/internal/domain/entity"
+	"tenders/internal/domain/repository"
+	"tenders/internal/utils"
+	"tenders/internal/utils/common"
+	"time"
+)
+
+const (
+	streamBatchSize         = 100
+	streamHeartbeatInterval = 30 * time.Second
+)
+
+type EventStreamHandler struct {
+	service interfaces.EventStreamService
+	// queryTimeout ограничивает каждый запрос к базе, сам поток живет, пока клиент подключен
+	queryTimeout time.Duration
+}
+
+func NewEventStreamHandler(service interfaces.EventStreamService, queryTimeout time.Duration) *EventStreamHandler {
+	return &EventStreamHandler{service: service, queryTimeout: queryTimeout}
+}
+
+// StreamEvents отдает ленту изменений в формате Server-Sent Events. Клиент, переподключившийся
+// с заголовком Last-Event-ID (или параметром lastEventId), получает события, пропущенные за время разрыва
+func (h *EventStreamHandler) StreamEvents(w http.ResponseWriter, r *http.Request) {
+	if common.CheckForExtraParams(r, []string{"lastEventId"}) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.queryTimeout)
+	defer cancel()
+
+	filter, err := h.service.VisibilityFilter(ctx)
+	if err != nil {
+		common.RespondWithError(w, r, err)
+		return
+	}
+
+	lastEventId, found, err := getLastEventId(r)
+	if err != nil {
+		common.RespondWithError(w, r, utils.IncorrectLastEventIdError)
+		return
+	}
+
+	// Подписка до чтения ленты, чтобы не пропустить события, зафиксированные между чтением и подпиской
+	wake, unsubscribe := h.service.Subscribe()
+	defer unsubscribe()
+
+	if !found {
+		if lastEventId, err = h.service.LastEventId(ctx); err != nil {
+			common.RespondWithError(w, r, err)
+			return
+		}
+	}
+
+	controller := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if err = controller.Flush(); err != nil {
+		log.Printf("stream: response does not support flushing: %v", err)
+		return
+	}
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		if lastEventId, err = h.sendEventsAfter(w, r, filter, lastEventId); err != nil {
+			log.Printf("stream: %v", err)
+			return
+		}
+		if err = controller.Flush(); err != nil {
+			return
+		}
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-wake:
+		case <-heartbeat.C:
+			if _, err = fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// sendEventsAfter пишет в поток все события после afterId и возвращает id последнего отправленного
+func (h *EventStreamHandler) sendEventsAfter(
+	w http.ResponseWriter, r *http.Request, filter repository.ChangeEventFilter, afterId int64,
+) (int64, error) {
+	for {
+		ctx, cancel := context.WithTimeout(r.Context(), h.queryTimeout)
+		events, err := h.service.FindAfter(ctx, filter, afterId, streamBatchSize)
+		cancel()
+		if err != nil {
+			return afterId, err
+		}
+
+		for _, event := range events {
+			if err = writeServerSentEvent(w, event); err != nil {
+				return afterId, err
+			}
+			afterId = event.Id
+		}
+
+		if len(events) < streamBatchSize {
+			return afterId, nil
+		}
+	}
+}
+
+func writeServerSentEvent(w http.ResponseWriter, event entity.ChangeEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.Id, strings.ToLower(event.EntityType), data)
+	return err
+}
+
+// getLastEventId возвращает id последнего полученного клиентом события, found false, если клиент подключается впервые
+func getLastEventId(r *http.Request) (int64, bool, error) {
+	lastEventIdStr := r.Header.Get("Last-Event-ID")
+	if lastEventIdStr == "" {
+		lastEventIdStr = r.URL.Query().Get("lastEventId")
+	}
+	if lastEventIdStr == "" {
+		return 0, false, nil
+	}
+
+	lastEventId, err := strconv.ParseInt(lastEventIdStr, 10, 64)
+	if err != nil || lastEventId < 0 {
+		return 0, false, utils.IncorrectLastEventIdError
+	}
+	return lastEventId, true, nil
+}
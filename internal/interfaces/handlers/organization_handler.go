@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"net/http"
+	"tenders/internal/application/interfaces"
+	"tenders/internal/interfaces/dto/request"
+	"tenders/internal/utils"
+	"tenders/internal/utils/common"
+)
+
+type OrganizationHandler struct {
+	service interfaces.OrganizationService
+}
+
+func NewOrganizationHandler(service interfaces.OrganizationService) *OrganizationHandler {
+	return &OrganizationHandler{service: service}
+}
+
+func (h *OrganizationHandler) CreateOrganization(w http.ResponseWriter, r *http.Request) {
+	var organizationRequest request.OrganizationRequest
+	if err := common.DecodeAndValidateJSON(r.Body, &organizationRequest); err != nil {
+		common.RespondWithError(w, r, utils.IncorrectRequestBody)
+		return
+	}
+
+	if common.CheckForExtraParams(r, []string{}) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
+		return
+	}
+
+	organization, err := h.service.Create(r.Context(), &organizationRequest)
+	if err != nil {
+		common.RespondWithError(w, r, err)
+		return
+	}
+
+	common.RespondOKWithJson(w, organization)
+}
+
+func (h *OrganizationHandler) EditOrganization(w http.ResponseWriter, r *http.Request) {
+	organizationId, err := common.GetUUIDFromRequestPath(r, "organizationId")
+	if err != nil {
+		common.RespondWithError(w, r, utils.IncorrectOrganizationIdError)
+		return
+	}
+
+	if common.CheckForExtraParams(r, []string{}) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
+		return
+	}
+
+	var editRequest request.EditOrganizationRequest
+	if err := common.DecodeAndValidateJSON(r.Body, &editRequest); err != nil {
+		common.RespondWithError(w, r, utils.IncorrectRequestBody)
+		return
+	}
+
+	organization, err := h.service.Edit(r.Context(), organizationId, &editRequest)
+	if err != nil {
+		common.RespondWithError(w, r, err)
+		return
+	}
+
+	common.RespondOKWithJson(w, organization)
+}
+
+func (h *OrganizationHandler) DeactivateOrganization(w http.ResponseWriter, r *http.Request) {
+	organizationId, err := common.GetUUIDFromRequestPath(r, "organizationId")
+	if err != nil {
+		common.RespondWithError(w, r, utils.IncorrectOrganizationIdError)
+		return
+	}
+
+	if common.CheckForExtraParams(r, []string{}) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
+		return
+	}
+
+	organization, err := h.service.Deactivate(r.Context(), organizationId)
+	if err != nil {
+		common.RespondWithError(w, r, err)
+		return
+	}
+
+	common.RespondOKWithJson(w, organization)
+}
+
+func (h *OrganizationHandler) GetResponsibles(w http.ResponseWriter, r *http.Request) {
+	organizationId, err := common.GetUUIDFromRequestPath(r, "organizationId")
+	if err != nil {
+		common.RespondWithError(w, r, utils.IncorrectOrganizationIdError)
+		return
+	}
+
+	if common.CheckForExtraParams(r, []string{}) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
+		return
+	}
+
+	responsibles, err := h.service.FindResponsibles(r.Context(), organizationId)
+	if err != nil {
+		common.RespondWithError(w, r, err)
+		return
+	}
+
+	common.RespondOKWithJson(w, responsibles)
+}
+
+func (h *OrganizationHandler) AddResponsible(w http.ResponseWriter, r *http.Request) {
+	organizationId, err := common.GetUUIDFromRequestPath(r, "organizationId")
+	if err != nil {
+		common.RespondWithError(w, r, utils.IncorrectOrganizationIdError)
+		return
+	}
+
+	if common.CheckForExtraParams(r, []string{}) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
+		return
+	}
+
+	var responsibleRequest request.ResponsibleRequest
+	if err := common.DecodeAndValidateJSON(r.Body, &responsibleRequest); err != nil {
+		common.RespondWithError(w, r, utils.IncorrectRequestBody)
+		return
+	}
+
+	role, err := responsibleRequest.MapToRole()
+	if err != nil {
+		common.RespondWithError(w, r, err)
+		return
+	}
+
+	responsibles, err := h.service.AddResponsible(r.Context(), organizationId, responsibleRequest.EmployeeId, role)
+	if err != nil {
+		common.RespondWithError(w, r, err)
+		return
+	}
+
+	common.RespondOKWithJson(w, responsibles)
+}
+
+func (h *OrganizationHandler) RemoveResponsible(w http.ResponseWriter, r *http.Request) {
+	organizationId, err := common.GetUUIDFromRequestPath(r, "organizationId")
+	if err != nil {
+		common.RespondWithError(w, r, utils.IncorrectOrganizationIdError)
+		return
+	}
+
+	employeeId, err := common.GetUUIDFromRequestPath(r, "employeeId")
+	if err != nil {
+		common.RespondWithError(w, r, utils.IncorrectEmployeeIdError)
+		return
+	}
+
+	if common.CheckForExtraParams(r, []string{}) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
+		return
+	}
+
+	if err = h.service.RemoveResponsible(r.Context(), organizationId, employeeId); err != nil {
+		common.RespondWithError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
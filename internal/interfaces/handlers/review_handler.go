@@ -1,12 +1,10 @@
 package handlers
 
 import (
-	"errors"
 	"net/http"
 	"tenders/internal/application/interfaces"
 	"tenders/internal/utils"
 	"tenders/internal/utils/common"
-	"tenders/internal/utils/consts"
 )
 
 type ReviewHandler struct {
@@ -18,93 +16,67 @@ func NewReviewHandler(service interfaces.ReviewService) *ReviewHandler {
 }
 
 func (h *ReviewHandler) SubmitFeedback(w http.ResponseWriter, r *http.Request) {
-	if common.CheckForExtraParams(r, []string{"username", "bidFeedback"}) {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectParams)
+	if common.CheckForExtraParams(r, []string{"bidFeedback"}) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
 		return
 	}
 
 	bidId, err := common.GetUUIDFromRequestPath(r, "bidId")
 	if err != nil {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectBidId)
-		return
-	}
-
-	username := r.URL.Query().Get("username")
-	if username == "" {
-		common.RespondWithError(w, http.StatusBadRequest, consts.NoUsernameParamPresent)
+		common.RespondWithError(w, r, utils.IncorrectBidIdError)
 		return
 	}
 
 	bidFeedback := r.URL.Query().Get("bidFeedback")
 	if bidFeedback == "" {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectFeedback)
+		common.RespondWithError(w, r, utils.IncorrectFeedbackError)
 		return
 	}
 
-	bid, err := h.service.SubmitFeedback(bidId, username, bidFeedback)
+	bid, err := h.service.SubmitFeedback(r.Context(), bidId, bidFeedback)
 	if err != nil {
-		if errors.Is(err, utils.BidNotExistsError) {
-			common.RespondWithError(w, http.StatusNotFound, consts.BidNotExists)
-		} else if errors.Is(err, utils.UnauthorizedAccessError) {
-			common.RespondWithError(w, http.StatusForbidden, consts.InsufficientPermissions)
-		} else if errors.Is(err, utils.UserNotExistsError) {
-			common.RespondWithError(w, http.StatusUnauthorized, consts.UserNotExists)
-		} else {
-			common.RespondWithError(w, http.StatusInternalServerError, consts.InternalServerError+" "+err.Error())
-		}
+		common.RespondWithError(w, r, err)
 		return
 	}
 	common.RespondOKWithJson(w, bid)
 }
 
 func (h *ReviewHandler) GetReviewsList(w http.ResponseWriter, r *http.Request) {
-	if common.CheckForExtraParams(r, []string{"authorUsername", "requesterUsername", "limit", "offset"}) {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectParams)
+	if common.CheckForExtraParams(r, []string{"authorUsername", "limit", "offset", "cursor", "total"}) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
 		return
 	}
 
 	tenderId, err := common.GetUUIDFromRequestPath(r, "tenderId")
 	if err != nil {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectTenderId)
+		common.RespondWithError(w, r, utils.IncorrectTenderIdError)
 		return
 	}
 
 	authorUsername := r.URL.Query().Get("authorUsername")
 	if authorUsername == "" {
-		common.RespondWithError(w, http.StatusBadRequest, consts.NoAuthorUsernameParamPresent)
-		return
-	}
-
-	requesterUsername := r.URL.Query().Get("requesterUsername")
-	if requesterUsername == "" {
-		common.RespondWithError(w, http.StatusBadRequest, consts.NoRequesterUsernameParamPresent)
+		common.RespondWithError(w, r, utils.NoAuthorUsernameError)
 		return
 	}
 
-	limit, offset, err := common.GetPaginationParams(r)
+	page, err := common.GetPageParams(r)
 	if err != nil {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectLimitOffsetParams)
+		common.RespondWithError(w, r, err)
 		return
 	}
 
-	reviews, err := h.service.FindAllReviewsByBidAuthor(tenderId, authorUsername, requesterUsername, limit, offset)
+	reviews, err := h.service.FindAllReviewsByBidAuthor(r.Context(), tenderId, authorUsername, page)
 	if err != nil {
-		if errors.Is(err, utils.UserNotExistsError) {
-			common.RespondWithError(w, http.StatusUnauthorized, consts.UserNotExists)
-		} else if errors.Is(err, utils.TenderNotExistsError) {
-			common.RespondWithError(w, http.StatusNotFound, consts.TenderNotExists)
-		} else if errors.Is(err, utils.BidForTenderNotExistsError) {
-			common.RespondWithError(w, http.StatusBadRequest, consts.BidForTenderNotExistsError)
-		} else {
-			common.RespondWithError(w, http.StatusInternalServerError, consts.InternalServerError+" "+err.Error())
-		}
+		common.RespondWithError(w, r, err)
 		return
 	}
 
-	if len(reviews) == 0 {
-		common.RespondWithError(w, http.StatusNotFound, consts.ReviewsNotFound)
+	// Пустая страница после курсора означает конец списка, а не отсутствие отзывов
+	if len(reviews.Items) == 0 && page.After == nil {
+		common.RespondWithError(w, r, utils.ReviewsNotExistsError)
 		return
 	}
 
-	common.RespondOKWithJson(w, reviews)
+	common.SetPageHeaders(w, reviews.Next, reviews.Total)
+	common.RespondOKWithJson(w, reviews.Items)
 }
@@ -1,18 +1,22 @@
 package handlers
 
 import (
-	"database/sql"
-	"errors"
 	"github.com/google/uuid"
 	"net/http"
 	"strings"
 	"tenders/internal/application/interfaces"
+	"tenders/internal/domain/entity"
+	"tenders/internal/domain/repository"
 	"tenders/internal/interfaces/dto/request"
+	"tenders/internal/interfaces/dto/response"
 	"tenders/internal/utils"
 	"tenders/internal/utils/common"
-	"tenders/internal/utils/consts"
+	"tenders/internal/utils/diff"
+	"unicode/utf8"
 )
 
+const maxSearchQueryLength = 200
+
 type TenderHandler struct {
 	service interfaces.TenderService
 }
@@ -24,245 +28,395 @@ func NewTenderHandler(service interfaces.TenderService) *TenderHandler {
 func (h *TenderHandler) CreateTender(w http.ResponseWriter, r *http.Request) {
 	var tenderRequest request.TenderRequest
 	if err := common.DecodeAndValidateJSON(r.Body, &tenderRequest); err != nil {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectRequestBody)
+		common.RespondWithError(w, r, utils.IncorrectRequestBody)
 		return
 	}
 
 	if common.CheckForExtraParams(r, []string{}) {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectParams)
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
 		return
 	}
 
-	tender, err := h.service.Create(&tenderRequest)
+	tender, err := h.service.Create(r.Context(), &tenderRequest)
 	if err != nil {
-		if errors.Is(err, utils.UserNotExistsError) {
-			common.RespondWithError(w, http.StatusUnauthorized, consts.UserNotExists)
-		} else if errors.Is(err, utils.UnauthorizedAccessError) {
-			common.RespondWithError(w, http.StatusForbidden, consts.InsufficientPermissions)
-		} else if strings.HasPrefix(err.Error(), "Неправильно") {
-			common.RespondWithError(w, http.StatusBadRequest, err.Error())
-		} else {
-			common.RespondWithError(w, http.StatusInternalServerError, consts.InternalServerError+" "+err.Error())
-		}
+		common.RespondWithError(w, r, err)
 		return
 	}
 
 	common.RespondOKWithJson(w, tender)
 }
 
+// GetAllTenders отдает только опубликованные тендеры, поэтому фильтр status в нем не принимается
 func (h *TenderHandler) GetAllTenders(w http.ResponseWriter, r *http.Request) {
-	if common.CheckForExtraParams(r, []string{"service_type", "limit", "offset"}) {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectParams)
+	if common.CheckForExtraParams(r, []string{
+		"service_type", "organization_id", "created_from", "created_to", "q",
+		"sort", "limit", "offset", "cursor", "total",
+	}) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
 		return
 	}
 
-	serviceTypeFilter, err := common.GetServiceTypeFilter(r)
+	filter, err := parseTenderFilter(r)
 	if err != nil {
-		common.RespondWithError(w, http.StatusBadRequest, err.Error())
+		common.RespondWithError(w, r, err)
 		return
 	}
 
-	limit, offset, err := common.GetPaginationParams(r)
+	page, err := common.GetPageParams(r)
 	if err != nil {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectLimitOffsetParams)
+		common.RespondWithError(w, r, err)
 		return
 	}
 
-	tenders, err := h.service.FindAllPublished(serviceTypeFilter, limit, offset)
+	// С поисковым запросом тендеры сортируются по релевантности и дополняются подсветкой совпадений
+	if filter.Query != "" {
+		results, err := h.service.SearchPublished(r.Context(), filter, page)
+		if err != nil {
+			common.RespondWithError(w, r, err)
+			return
+		}
+		common.SetPageHeaders(w, results.Next, results.Total)
+		common.RespondOKWithJson(w, results.Items)
+		return
+	}
+
+	tenders, err := h.service.FindAllPublished(r.Context(), filter, page)
 	if err != nil {
-		common.RespondWithError(w, http.StatusInternalServerError, consts.InternalServerError+" "+err.Error())
+		common.RespondWithError(w, r, err)
 		return
 	}
 
-	common.RespondOKWithJson(w, tenders)
+	common.SetPageHeaders(w, tenders.Next, tenders.Total)
+	common.RespondOKWithJson(w, tenders.Items)
+}
+
+func parseTenderFilter(r *http.Request) (repository.TenderFilter, error) {
+	var filter repository.TenderFilter
+	var err error
+
+	if filter.ServiceTypes, err = common.GetServiceTypeFilter(r); err != nil {
+		return filter, err
+	}
+
+	for _, status := range r.URL.Query()["status"] {
+		if !entity.ValidTenderStatuses[status] {
+			return filter, utils.IncorrectFilterError.WithFields("status")
+		}
+		filter.Statuses = append(filter.Statuses, status)
+	}
+
+	if filter.OrganizationId, err = common.GetOptionalUUIDParam(r, "organization_id"); err != nil {
+		return filter, utils.IncorrectFilterError.WithFields("organization_id")
+	}
+
+	if filter.CreatedFrom, filter.CreatedTo, err = common.GetCreatedRangeParams(r); err != nil {
+		return filter, err
+	}
+
+	filter.Query = strings.TrimSpace(r.URL.Query().Get("q"))
+	if utf8.RuneCountInString(filter.Query) > maxSearchQueryLength {
+		return filter, utils.IncorrectFilterError.WithFields("q")
+	}
+
+	return filter, nil
 }
 
 func (h *TenderHandler) GetAllTendersByUsername(w http.ResponseWriter, r *http.Request) {
-	if common.CheckForExtraParams(r, []string{"username", "limit", "offset"}) {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectParams)
+	if common.CheckForExtraParams(r, []string{
+		"service_type", "status", "organization_id", "created_from", "created_to",
+		"sort", "limit", "offset", "cursor", "total",
+	}) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
 		return
 	}
 
-	username := r.URL.Query().Get("username")
-	if username == "" {
-		common.RespondWithError(w, http.StatusBadRequest, consts.NoUsernameParamPresent)
+	filter, err := parseTenderFilter(r)
+	if err != nil {
+		common.RespondWithError(w, r, err)
 		return
 	}
 
-	limit, offset, err := common.GetPaginationParams(r)
+	page, err := common.GetPageParams(r)
 	if err != nil {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectLimitOffsetParams)
+		common.RespondWithError(w, r, err)
 		return
 	}
 
-	tenders, err := h.service.FindAllAvailableByEmployeeUsername(username, limit, offset)
+	tenders, err := h.service.FindAllAvailableByEmployee(r.Context(), filter, page)
 	if err != nil {
-		if errors.Is(err, utils.UserNotExistsError) {
-			common.RespondWithError(w, http.StatusUnauthorized, consts.UserNotExists)
-		} else {
-			common.RespondWithError(w, http.StatusInternalServerError, consts.InternalServerError+" "+err.Error())
-		}
+		common.RespondWithError(w, r, err)
 		return
 	}
 
-	common.RespondOKWithJson(w, tenders)
+	common.SetPageHeaders(w, tenders.Next, tenders.Total)
+	common.RespondOKWithJson(w, tenders.Items)
 }
 
 func (h *TenderHandler) GetTenderStatusById(w http.ResponseWriter, r *http.Request) {
 	tenderIdStr := r.PathValue("tenderId")
 	tenderId, err := uuid.Parse(tenderIdStr)
 	if err != nil {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectTenderId)
+		common.RespondWithError(w, r, utils.IncorrectTenderIdError)
 		return
 	}
 
-	if common.CheckForExtraParams(r, []string{"username"}) {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectParams)
+	if common.CheckForExtraParams(r, []string{}) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
 		return
 	}
-	username := r.URL.Query().Get("username")
 
-	status, err := h.service.GetStatusByTenderId(tenderId, username)
+	tender, err := h.service.FindVisibleByTenderId(r.Context(), tenderId)
 	if err != nil {
-		if errors.Is(err, utils.TenderNotExistsError) {
-			common.RespondWithError(w, http.StatusNotFound, consts.TenderNotExists)
-		} else if errors.Is(err, utils.UnauthorizedAccessError) {
-			common.RespondWithError(w, http.StatusForbidden, consts.InsufficientPermissions)
-		} else if errors.Is(err, utils.UserNotExistsError) {
-			common.RespondWithError(w, http.StatusUnauthorized, consts.UserNotExists)
-		} else {
-			common.RespondWithError(w, http.StatusInternalServerError, consts.InternalServerError+" "+err.Error())
-		}
+		common.RespondWithError(w, r, err)
 		return
 	}
 
+	common.SetETag(w, tender.Version)
 	w.Header().Set("Content-Type", "text/plain")
-	if _, err = w.Write([]byte(status)); err != nil {
-		common.RespondWithError(w, http.StatusInternalServerError, consts.FailedToWriteResponse)
+	if _, err = w.Write([]byte(tender.Status)); err != nil {
+		common.RespondWithError(w, r, utils.FailedToWriteResponseError)
 	}
 	w.WriteHeader(http.StatusOK)
 }
 
+func (h *TenderHandler) GetTenderById(w http.ResponseWriter, r *http.Request) {
+	tenderId, err := common.GetUUIDFromRequestPath(r, "tenderId")
+	if err != nil {
+		common.RespondWithError(w, r, utils.IncorrectTenderIdError)
+		return
+	}
+
+	if common.CheckForExtraParams(r, []string{}) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
+		return
+	}
+
+	tender, err := h.service.FindDetailsByTenderId(r.Context(), tenderId)
+	if err != nil {
+		common.RespondWithError(w, r, err)
+		return
+	}
+
+	common.SetETag(w, tender.Version)
+	common.RespondOKWithJson(w, tender)
+}
+
+func (h *TenderHandler) GetTenderVersions(w http.ResponseWriter, r *http.Request) {
+	tenderId, err := common.GetUUIDFromRequestPath(r, "tenderId")
+	if err != nil {
+		common.RespondWithError(w, r, utils.IncorrectTenderIdError)
+		return
+	}
+
+	if common.CheckForExtraParams(r, []string{"limit", "offset"}) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
+		return
+	}
+
+	limit, offset, err := common.GetPaginationParams(r)
+	if err != nil {
+		common.RespondWithError(w, r, utils.IncorrectLimitOffsetError)
+		return
+	}
+
+	versions, err := h.service.FindAllVersions(r.Context(), tenderId, limit, offset)
+	if err != nil {
+		common.RespondWithError(w, r, err)
+		return
+	}
+
+	common.RespondOKWithJson(w, versions)
+}
+
+func (h *TenderHandler) GetTenderVersion(w http.ResponseWriter, r *http.Request) {
+	tenderId, err := common.GetUUIDFromRequestPath(r, "tenderId")
+	if err != nil {
+		common.RespondWithError(w, r, utils.IncorrectTenderIdError)
+		return
+	}
+
+	if common.CheckForExtraParams(r, []string{}) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
+		return
+	}
+
+	version, err := common.GetVersionFromRequestPath(r)
+	if err != nil {
+		common.RespondWithError(w, r, utils.IncorrectVersionError)
+		return
+	}
+
+	tenderVersion, err := h.service.FindVersion(r.Context(), tenderId, version)
+	if err != nil {
+		common.RespondWithError(w, r, err)
+		return
+	}
+
+	common.RespondOKWithJson(w, tenderVersion)
+}
+
+func (h *TenderHandler) GetTenderDiff(w http.ResponseWriter, r *http.Request) {
+	tenderId, err := common.GetUUIDFromRequestPath(r, "tenderId")
+	if err != nil {
+		common.RespondWithError(w, r, utils.IncorrectTenderIdError)
+		return
+	}
+
+	if common.CheckForExtraParams(r, []string{"from", "to", "format"}) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
+		return
+	}
+
+	from, to, err := common.GetVersionRangeParams(r)
+	if err != nil {
+		common.RespondWithError(w, r, utils.IncorrectVersionRangeError)
+		return
+	}
+
+	jsonPatch, err := common.IsJsonPatchRequested(r)
+	if err != nil {
+		common.RespondWithError(w, r, utils.IncorrectDiffFormatError)
+		return
+	}
+
+	changes, err := h.service.DiffVersions(r.Context(), tenderId, from, to)
+	if err != nil {
+		common.RespondWithError(w, r, err)
+		return
+	}
+
+	if jsonPatch {
+		common.RespondOKWithJsonPatch(w, diff.Patch(changes))
+		return
+	}
+	common.RespondOKWithJson(w, response.DiffResponse{From: from, To: to, Changes: changes})
+}
+
+func (h *TenderHandler) GetTenderTransitions(w http.ResponseWriter, r *http.Request) {
+	tenderId, err := common.GetUUIDFromRequestPath(r, "tenderId")
+	if err != nil {
+		common.RespondWithError(w, r, utils.IncorrectTenderIdError)
+		return
+	}
+
+	if common.CheckForExtraParams(r, []string{}) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
+		return
+	}
+
+	tender, transitions, err := h.service.AvailableTransitions(r.Context(), tenderId)
+	if err != nil {
+		common.RespondWithError(w, r, err)
+		return
+	}
+
+	common.SetETag(w, tender.Version)
+	common.RespondOKWithJson(w, response.TransitionsResponse{
+		Status:      tender.Status,
+		Version:     tender.Version,
+		Transitions: transitions,
+	})
+}
+
 func (h *TenderHandler) UpdateTenderStatusById(w http.ResponseWriter, r *http.Request) {
 	tenderId, err := common.GetUUIDFromRequestPath(r, "tenderId")
 	if err != nil {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectTenderId)
+		common.RespondWithError(w, r, utils.IncorrectTenderIdError)
 		return
 	}
 
-	if common.CheckForExtraParams(r, []string{"username", "status"}) {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectParams)
+	if common.CheckForExtraParams(r, []string{"status"}) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
 		return
 	}
 
 	status := r.URL.Query().Get("status")
 	err = common.ValidateTenderStatus(status)
 	if status == "" || err != nil {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectStatus)
+		common.RespondWithError(w, r, utils.IncorrectStatusError)
 		return
 	}
 
-	username := r.URL.Query().Get("username")
-	if username == "" {
-		common.RespondWithError(w, http.StatusBadRequest, consts.NoUsernameParamPresent)
+	expectedVersion, err := common.GetExpectedVersion(r)
+	if err != nil {
+		common.RespondWithError(w, r, utils.IncorrectIfMatchError)
 		return
 	}
 
-	tender, err := h.service.UpdateStatus(tenderId, status, username)
+	tender, err := h.service.UpdateStatus(r.Context(), tenderId, status, expectedVersion)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			common.RespondWithError(w, http.StatusNotFound, consts.TenderNotExists)
-		} else if errors.Is(err, utils.UnauthorizedAccessError) {
-			common.RespondWithError(w, http.StatusForbidden, consts.InsufficientPermissions)
-		} else if errors.Is(err, utils.UserNotExistsError) {
-			common.RespondWithError(w, http.StatusUnauthorized, consts.UserNotExists)
-		} else {
-			common.RespondWithError(w, http.StatusInternalServerError, consts.InternalServerError+" "+err.Error())
-		}
+		common.RespondWithError(w, r, err)
 		return
 	}
 
+	common.SetETag(w, tender.Version)
 	common.RespondOKWithJson(w, tender)
 }
 
 func (h *TenderHandler) EditTender(w http.ResponseWriter, r *http.Request) {
 	tenderId, err := common.GetUUIDFromRequestPath(r, "tenderId")
 	if err != nil {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectTenderId)
+		common.RespondWithError(w, r, utils.IncorrectTenderIdError)
 		return
 	}
 
-	if common.CheckForExtraParams(r, []string{"username"}) {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectParams)
+	if common.CheckForExtraParams(r, []string{}) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
 		return
 	}
 
-	username := r.URL.Query().Get("username")
-	if username == "" {
-		common.RespondWithError(w, http.StatusBadRequest, consts.NoUsernameParamPresent)
+	var updateRequest request.EditTenderRequest
+	if err := common.DecodeAndValidateJSON(r.Body, &updateRequest); err != nil {
+		common.RespondWithError(w, r, utils.IncorrectRequestBody)
 		return
 	}
 
-	var updateRequest request.EditTenderRequest
-	if err := common.DecodeAndValidateJSON(r.Body, &updateRequest); err != nil {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectRequestBody)
+	expectedVersion, err := common.GetExpectedVersion(r)
+	if err != nil {
+		common.RespondWithError(w, r, utils.IncorrectIfMatchError)
 		return
 	}
 
-	updatedTender, err := h.service.EditTender(tenderId, username, &updateRequest)
+	updatedTender, err := h.service.EditTender(r.Context(), tenderId, &updateRequest, expectedVersion)
 	if err != nil {
-		if errors.Is(err, utils.TenderNotExistsError) {
-			common.RespondWithError(w, http.StatusNotFound, consts.TenderNotExists)
-		} else if errors.Is(err, utils.UnauthorizedAccessError) {
-			common.RespondWithError(w, http.StatusForbidden, consts.InsufficientPermissions)
-		} else if errors.Is(err, utils.UserNotExistsError) {
-			common.RespondWithError(w, http.StatusUnauthorized, consts.UserNotExists)
-		} else {
-			common.RespondWithError(w, http.StatusInternalServerError, consts.InternalServerError+" "+err.Error())
-		}
+		common.RespondWithError(w, r, err)
 		return
 	}
 
+	common.SetETag(w, updatedTender.Version)
 	common.RespondOKWithJson(w, updatedTender)
 }
 
 func (h *TenderHandler) RollbackTender(w http.ResponseWriter, r *http.Request) {
 	tenderId, err := common.GetUUIDFromRequestPath(r, "tenderId")
 	if err != nil {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectTenderId)
+		common.RespondWithError(w, r, utils.IncorrectTenderIdError)
 		return
 	}
 
-	if common.CheckForExtraParams(r, []string{"username", "version"}) {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectParams)
+	if common.CheckForExtraParams(r, []string{"version"}) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
 		return
 	}
 
-	username := r.URL.Query().Get("username")
-	if username == "" {
-		common.RespondWithError(w, http.StatusBadRequest, consts.NoUsernameParamPresent)
+	version, err := common.GetVersionFromRequestPath(r)
+	if err != nil {
+		common.RespondWithError(w, r, utils.IncorrectVersionError)
 		return
 	}
 
-	version, err := common.GetVersionFromRequestPath(r)
+	expectedVersion, err := common.GetExpectedVersion(r)
 	if err != nil {
-		common.RespondWithError(w, http.StatusBadRequest, consts.IncorrectVersion)
+		common.RespondWithError(w, r, utils.IncorrectIfMatchError)
 		return
 	}
 
-	updatedTender, err := h.service.RollbackTender(tenderId, version, username)
+	updatedTender, err := h.service.RollbackTender(r.Context(), tenderId, version, expectedVersion)
 	if err != nil {
-		if errors.Is(err, utils.TenderNotExistsError) {
-			common.RespondWithError(w, http.StatusNotFound, consts.TenderOrVersionNotExists)
-		} else if errors.Is(err, utils.UnauthorizedAccessError) {
-			common.RespondWithError(w, http.StatusForbidden, consts.InsufficientPermissions)
-		} else if errors.Is(err, utils.UserNotExistsError) {
-			common.RespondWithError(w, http.StatusUnauthorized, consts.UserNotExists)
-		} else {
-			common.RespondWithError(w, http.StatusInternalServerError, consts.InternalServerError+" "+err.Error())
-		}
+		common.RespondWithError(w, r, err)
 		return
 	}
 
+	common.SetETag(w, updatedTender.Version)
 	common.RespondOKWithJson(w, updatedTender)
 }
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"net/http"
+	"tenders/internal/application/interfaces"
+	"tenders/internal/interfaces/dto/request"
+	"tenders/internal/interfaces/dto/response"
+	"tenders/internal/utils"
+	"tenders/internal/utils/common"
+)
+
+type WebhookHandler struct {
+	service interfaces.WebhookService
+}
+
+func NewWebhookHandler(service interfaces.WebhookService) *WebhookHandler {
+	return &WebhookHandler{service: service}
+}
+
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var webhookRequest request.WebhookRequest
+	if err := common.DecodeAndValidateJSON(r.Body, &webhookRequest); err != nil {
+		common.RespondWithError(w, r, utils.IncorrectRequestBody)
+		return
+	}
+
+	if common.CheckForExtraParams(r, []string{}) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
+		return
+	}
+
+	subscription, err := h.service.Create(r.Context(), &webhookRequest)
+	if err != nil {
+		common.RespondWithError(w, r, err)
+		return
+	}
+
+	common.RespondOKWithJson(w, response.WebhookCreatedResponse{
+		WebhookSubscription: *subscription,
+		Secret:              subscription.Secret,
+	})
+}
+
+func (h *WebhookHandler) GetWebhooks(w http.ResponseWriter, r *http.Request) {
+	if common.CheckForExtraParams(r, []string{}) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
+		return
+	}
+
+	subscriptions, err := h.service.FindAll(r.Context())
+	if err != nil {
+		common.RespondWithError(w, r, err)
+		return
+	}
+
+	common.RespondOKWithJson(w, subscriptions)
+}
+
+func (h *WebhookHandler) GetWebhookById(w http.ResponseWriter, r *http.Request) {
+	webhookId, err := common.GetUUIDFromRequestPath(r, "webhookId")
+	if err != nil {
+		common.RespondWithError(w, r, utils.IncorrectWebhookIdError)
+		return
+	}
+
+	if common.CheckForExtraParams(r, []string{}) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
+		return
+	}
+
+	subscription, err := h.service.FindById(r.Context(), webhookId)
+	if err != nil {
+		common.RespondWithError(w, r, err)
+		return
+	}
+
+	common.RespondOKWithJson(w, subscription)
+}
+
+func (h *WebhookHandler) EditWebhook(w http.ResponseWriter, r *http.Request) {
+	webhookId, err := common.GetUUIDFromRequestPath(r, "webhookId")
+	if err != nil {
+		common.RespondWithError(w, r, utils.IncorrectWebhookIdError)
+		return
+	}
+
+	if common.CheckForExtraParams(r, []string{}) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
+		return
+	}
+
+	var editRequest request.EditWebhookRequest
+	if err := common.DecodeAndValidateJSON(r.Body, &editRequest); err != nil {
+		common.RespondWithError(w, r, utils.IncorrectRequestBody)
+		return
+	}
+
+	subscription, err := h.service.Edit(r.Context(), webhookId, &editRequest)
+	if err != nil {
+		common.RespondWithError(w, r, err)
+		return
+	}
+
+	common.RespondOKWithJson(w, subscription)
+}
+
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	webhookId, err := common.GetUUIDFromRequestPath(r, "webhookId")
+	if err != nil {
+		common.RespondWithError(w, r, utils.IncorrectWebhookIdError)
+		return
+	}
+
+	if common.CheckForExtraParams(r, []string{}) {
+		common.RespondWithError(w, r, utils.IncorrectParamsError)
+		return
+	}
+
+	if err = h.service.Delete(r.Context(), webhookId); err != nil {
+		common.RespondWithError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+	"tenders/internal/application/interfaces"
+	"tenders/internal/domain/entity"
+	"tenders/internal/utils/auth"
+	"tenders/internal/utils/common"
+)
+
+// legacyUsernameParams параметры, которыми клиенты раньше передавали имя текущего пользователя
+var legacyUsernameParams = []string{"username", "requesterUsername"}
+
+// Authentication определяет сотрудника по токену из заголовка Authorization и кладет его в контекст запроса.
+// Запросы без токена проходят дальше анонимными, решение о доступе принимают сервисы.
+// Если allowLegacyUsername включен, сотрудник без токена определяется по параметру username
+func Authentication(service interfaces.AuthService, allowLegacyUsername bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var employee *entity.Employee
+			var err error
+
+			if token, found := bearerToken(r); found {
+				employee, err = service.Authenticate(r.Context(), token)
+				if err != nil {
+					common.RespondWithError(w, r, err)
+					return
+				}
+			}
+
+			if allowLegacyUsername {
+				var username string
+				username, r = stripLegacyUsername(r)
+				if employee == nil && username != "" {
+					employee, err = service.AuthenticateByUsername(r.Context(), username)
+					if err != nil {
+						common.RespondWithError(w, r, err)
+						return
+					}
+				}
+			}
+
+			if employee != nil {
+				r = r.WithContext(auth.WithEmployee(r.Context(), employee))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get("Authorization")
+	token, found := strings.CutPrefix(header, "Bearer ")
+	if !found || token == "" {
+		return "", false
+	}
+	return token, true
+}
+
+// stripLegacyUsername убирает устаревшие параметры из запроса, чтобы их не отклоняла проверка лишних параметров
+func stripLegacyUsername(r *http.Request) (string, *http.Request) {
+	query := r.URL.Query()
+
+	var username string
+	for _, param := range legacyUsernameParams {
+		if value := query.Get(param); value != "" && username == "" {
+			username = value
+		}
+		query.Del(param)
+	}
+
+	r = r.Clone(r.Context())
+	r.URL.RawQuery = query.Encode()
+	return username, r
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Deadline ограничивает время обработки запроса, контекст запроса передается во все запросы к базе,
+// поэтому по истечении timeout они прерываются. Для streamingPaths таймаут не применяется,
+// такие обработчики сами ограничивают свои запросы к базе
+func Deadline(timeout time.Duration, streamingPaths ...string) func(http.Handler) http.Handler {
+	streaming := make(map[string]bool, len(streamingPaths))
+	for _, path := range streamingPaths {
+		streaming[path] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if streaming[r.URL.Path] {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package middleware
+
+import (
+	"net/http"
+	"tenders/internal/utils/i18n"
+)
+
+// Localization выбирает язык ответа по заголовку Accept-Language и кладет его в контекст запроса
+func Localization(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		language := i18n.Negotiate(r.Header.Get("Accept-Language"))
+		w.Header().Add("Vary", "Accept-Language")
+
+		next.ServeHTTP(w, r.WithContext(i18n.WithLanguage(r.Context(), language)))
+	})
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"github.com/google/uuid"
+	"net/http"
+	"tenders/internal/utils"
+	"tenders/internal/utils/auth"
+	"tenders/internal/utils/common"
+)
+
+const (
+	organizationHeader = "X-Organization-Id"
+	organizationParam  = "organizationId"
+)
+
+// OrganizationContext берет организацию, от имени которой действует сотрудник, из заголовка X-Organization-Id
+// или параметра organizationId и кладет ее в контекст запроса. Заголовок важнее параметра.
+// Параметр убирается из запроса, чтобы его не отклоняла проверка лишних параметров
+func OrganizationContext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		value := r.Header.Get(organizationHeader)
+
+		query := r.URL.Query()
+		if query.Has(organizationParam) {
+			if value == "" {
+				value = query.Get(organizationParam)
+			}
+			query.Del(organizationParam)
+			r = r.Clone(r.Context())
+			r.URL.RawQuery = query.Encode()
+		}
+
+		if value != "" {
+			organizationId, err := uuid.Parse(value)
+			if err != nil || organizationId == uuid.Nil {
+				common.RespondWithError(w, r, utils.IncorrectOrganizationIdError)
+				return
+			}
+			r = r.WithContext(auth.WithOrganization(r.Context(), organizationId))
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
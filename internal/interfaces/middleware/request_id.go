@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"github.com/google/uuid"
+	"net/http"
+	"tenders/internal/utils/requestid"
+)
+
+const (
+	requestIdHeader    = "X-Request-Id"
+	maxRequestIdLength = 100
+)
+
+// RequestId берет id запроса из заголовка X-Request-Id или генерирует новый и возвращает его в ответе
+func RequestId(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestId := r.Header.Get(requestIdHeader)
+		if requestId == "" || len(requestId) > maxRequestIdLength {
+			requestId = uuid.NewString()
+		}
+		w.Header().Set(requestIdHeader, requestId)
+
+		next.ServeHTTP(w, r.WithContext(requestid.WithRequestId(r.Context(), requestId)))
+	})
+}
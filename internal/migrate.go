@@ -0,0 +1,101 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"tenders/internal/infrastructure/config"
+	"tenders/internal/infrastructure/migrations"
+)
+
+const migrateUsage = `usage: app migrate <command>
+
+commands:
+  up        apply all pending migrations
+  down N    roll back the last N applied migrations (default 1)
+  status    print applied and pending migrations
+  seed      insert test data into employee, organization and organization_responsible`
+
+// Migrate обрабатывает подкоманду migrate
+func Migrate(args []string) {
+	if len(args) == 0 {
+		fmt.Println(migrateUsage)
+		os.Exit(2)
+	}
+
+	conn := config.NewPostgresConn(config.NewConfig().PostgresConfig())
+	defer conn.Close()
+
+	migrator, err := migrations.NewMigrator(conn, migrations.Postgres())
+	if err != nil {
+		log.Fatalf("Can't load migrations, %v", err)
+	}
+
+	ctx := context.Background()
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			log.Fatalf("Can't apply migrations, %v", err)
+		}
+		fmt.Printf("Applied %d migration(s)\n", applied)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				log.Fatalf("Invalid number of steps %q", args[1])
+			}
+		}
+		rolledBack, err := migrator.Down(ctx, steps)
+		if err != nil {
+			log.Fatalf("Can't roll back migrations, %v", err)
+		}
+		fmt.Printf("Rolled back %d migration(s)\n", rolledBack)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			log.Fatalf("Can't get migrations status, %v", err)
+		}
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = "applied at " + status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%06d_%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+	case "seed":
+		if err = migrator.Seed(ctx, migrations.Seed()); err != nil {
+			log.Fatalf("Can't seed database, %v", err)
+		}
+		fmt.Println("Seed data inserted")
+	default:
+		fmt.Println(migrateUsage)
+		os.Exit(2)
+	}
+}
+
+// applyMigrations применяет недостающие миграции при старте приложения и, если нужно, тестовые данные
+func applyMigrations(conn *sql.DB, seed bool) {
+	migrator, err := migrations.NewMigrator(conn, migrations.Postgres())
+	if err != nil {
+		log.Fatalf("Can't load migrations, %v", err)
+	}
+
+	applied, err := migrator.Up(context.Background())
+	if err != nil {
+		log.Fatalf("Can't apply migrations, %v", err)
+	}
+	if applied > 0 {
+		fmt.Printf("Applied %d migration(s)\n", applied)
+	}
+
+	if seed {
+		if err = migrator.Seed(context.Background(), migrations.Seed()); err != nil {
+			log.Fatalf("Can't seed database, %v", err)
+		}
+	}
+}
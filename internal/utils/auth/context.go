@@ -0,0 +1,29 @@
+package auth
+
+import (
+	"context"
+	"tenders/internal/domain/entity"
+	"tenders/internal/utils"
+)
+
+type contextKey struct{}
+
+// WithEmployee кладет аутентифицированного сотрудника в контекст запроса
+func WithEmployee(ctx context.Context, employee *entity.Employee) context.Context {
+	return context.WithValue(ctx, contextKey{}, employee)
+}
+
+// EmployeeFromContext возвращает сотрудника из контекста, если запрос аутентифицирован
+func EmployeeFromContext(ctx context.Context) (*entity.Employee, bool) {
+	employee, ok := ctx.Value(contextKey{}).(*entity.Employee)
+	return employee, ok && employee != nil
+}
+
+// CurrentEmployee то же самое, что EmployeeFromContext, но для эндпоинтов, где аутентификация обязательна
+func CurrentEmployee(ctx context.Context) (*entity.Employee, error) {
+	employee, ok := EmployeeFromContext(ctx)
+	if !ok {
+		return nil, utils.UserNotExistsError
+	}
+	return employee, nil
+}
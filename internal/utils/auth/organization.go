@@ -0,0 +1,19 @@
+package auth
+
+import (
+	"context"
+	"github.com/google/uuid"
+)
+
+type organizationContextKey struct{}
+
+// WithOrganization кладет в контекст организацию, от имени которой действует сотрудник
+func WithOrganization(ctx context.Context, organizationId uuid.UUID) context.Context {
+	return context.WithValue(ctx, organizationContextKey{}, organizationId)
+}
+
+// OrganizationFromContext возвращает организацию, выбранную в запросе заголовком или параметром
+func OrganizationFromContext(ctx context.Context) (uuid.UUID, bool) {
+	organizationId, ok := ctx.Value(organizationContextKey{}).(uuid.UUID)
+	return organizationId, ok && organizationId != uuid.Nil
+}
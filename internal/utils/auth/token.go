@@ -0,0 +1,113 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"strings"
+	"tenders/internal/domain/entity"
+	"time"
+)
+
+var (
+	InvalidTokenError = errors.New("invalid token")
+	ExpiredTokenError = errors.New("token expired")
+)
+
+type Claims struct {
+	Subject   uuid.UUID `json:"sub"`
+	Username  string    `json:"username"`
+	IssuedAt  int64     `json:"iat"`
+	ExpiresAt int64     `json:"exp"`
+}
+
+type tokenHeader struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ"`
+}
+
+// TokenManager выпускает и проверяет JWT, подписанные HMAC-SHA256 локальным ключом
+type TokenManager struct {
+	secret []byte
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+func NewTokenManager(secret []byte, ttl time.Duration) *TokenManager {
+	return &TokenManager{secret: secret, ttl: ttl, now: time.Now}
+}
+
+func (m *TokenManager) Issue(employee *entity.Employee) (string, time.Time, error) {
+	issuedAt := m.now()
+	expiresAt := issuedAt.Add(m.ttl)
+
+	header, err := encodeSegment(tokenHeader{Alg: "HS256", Typ: "JWT"})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	payload, err := encodeSegment(Claims{
+		Subject:   employee.Id,
+		Username:  employee.Username,
+		IssuedAt:  issuedAt.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	signingInput := header + "." + payload
+	return signingInput + "." + m.sign(signingInput), expiresAt, nil
+}
+
+func (m *TokenManager) Verify(token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, InvalidTokenError
+	}
+
+	expected := m.sign(parts[0] + "." + parts[1])
+	if !hmac.Equal([]byte(expected), []byte(parts[2])) {
+		return nil, InvalidTokenError
+	}
+
+	var header tokenHeader
+	if err := decodeSegment(parts[0], &header); err != nil || header.Alg != "HS256" {
+		return nil, InvalidTokenError
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil || claims.Subject == uuid.Nil {
+		return nil, InvalidTokenError
+	}
+
+	if m.now().Unix() >= claims.ExpiresAt {
+		return nil, ExpiredTokenError
+	}
+
+	return &claims, nil
+}
+
+func (m *TokenManager) sign(signingInput string) string {
+	mac := hmac.New(sha256.New, m.secret)
+	mac.Write([]byte(signingInput))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func encodeSegment(v interface{}) (string, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	raw, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, v)
+}
@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"encoding/base64"
+	"errors"
+	"github.com/google/uuid"
+	"strings"
+	"tenders/internal/domain/entity"
+	"testing"
+	"time"
+)
+
+func newTestManager(secret string, now time.Time) *TokenManager {
+	manager := NewTokenManager([]byte(secret), time.Hour)
+	manager.now = func() time.Time { return now }
+	return manager
+}
+
+func TestTokenIssueAndVerify(t *testing.T) {
+	issuedAt := time.Date(2024, 9, 16, 10, 0, 0, 0, time.UTC)
+	employee := &entity.Employee{Id: uuid.New(), Username: "user1"}
+
+	token, expiresAt, err := newTestManager("secret", issuedAt).Issue(employee)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	if !expiresAt.Equal(issuedAt.Add(time.Hour)) {
+		t.Errorf("expiresAt = %v, want %v", expiresAt, issuedAt.Add(time.Hour))
+	}
+
+	tests := []struct {
+		name    string
+		verify  time.Time
+		wantErr error
+	}{
+		{name: "just issued", verify: issuedAt},
+		{name: "before expiry", verify: expiresAt.Add(-time.Second)},
+		{name: "at expiry", verify: expiresAt, wantErr: ExpiredTokenError},
+		{name: "after expiry", verify: expiresAt.Add(time.Minute), wantErr: ExpiredTokenError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := newTestManager("secret", tt.verify).Verify(token)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Verify err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+			if claims.Subject != employee.Id || claims.Username != employee.Username {
+				t.Errorf("claims = %+v, want subject %s and username %s", claims, employee.Id, employee.Username)
+			}
+			if claims.IssuedAt != issuedAt.Unix() || claims.ExpiresAt != expiresAt.Unix() {
+				t.Errorf("claims = %+v, want iat %d and exp %d", claims, issuedAt.Unix(), expiresAt.Unix())
+			}
+		})
+	}
+}
+
+func TestTokenVerifyRejectsTampering(t *testing.T) {
+	now := time.Date(2024, 9, 16, 10, 0, 0, 0, time.UTC)
+	manager := newTestManager("secret", now)
+
+	token, _, err := manager.Issue(&entity.Employee{Id: uuid.New(), Username: "user1"})
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	parts := strings.Split(token, ".")
+
+	sign := func(header, payload string) string {
+		return header + "." + payload + "." + manager.sign(header+"."+payload)
+	}
+	segment := func(raw string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(raw))
+	}
+	forgedPayload := segment(`{"sub":"` + uuid.NewString() + `","username":"admin","iat":0,"exp":9999999999}`)
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "empty", token: ""},
+		{name: "two segments", token: parts[0] + "." + parts[1]},
+		{name: "four segments", token: token + ".extra"},
+		{name: "forged payload", token: parts[0] + "." + forgedPayload + "." + parts[2]},
+		{name: "truncated signature", token: parts[0] + "." + parts[1] + "." + parts[2][:len(parts[2])-1]},
+		{name: "no signature", token: parts[0] + "." + parts[1] + "."},
+		{name: "signed with another key", token: func() string {
+			other, _, _ := newTestManager("other", now).Issue(&entity.Employee{Id: uuid.New(), Username: "user1"})
+			return other
+		}()},
+		{name: "alg none", token: sign(segment(`{"alg":"none","typ":"JWT"}`), parts[1])},
+		{name: "header not json", token: sign(segment("header"), parts[1])},
+		{name: "payload not json", token: sign(parts[0], segment("payload"))},
+		{name: "payload not base64", token: sign(parts[0], "!!!")},
+		{name: "no subject", token: sign(parts[0], segment(`{"username":"user1","exp":9999999999}`))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := manager.Verify(tt.token); !errors.Is(err, InvalidTokenError) {
+				t.Errorf("Verify err = %v, want %v", err, InvalidTokenError)
+			}
+		})
+	}
+}
@@ -1,11 +1,19 @@
 package common
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/google/uuid"
 	"net/http"
 	"strconv"
+	"strings"
 	"tenders/internal/domain/entity"
+	"tenders/internal/domain/repository"
+	"tenders/internal/utils"
+	"tenders/internal/utils/consts"
+	"time"
 )
 
 func GetPaginationParams(r *http.Request) (int, int, error) {
@@ -35,13 +43,128 @@ func GetPaginationParams(r *http.Request) (int, int, error) {
 	return limit, offset, nil
 }
 
+// GetPageParams возвращает страницу из параметров limit, offset, sort, cursor и total.
+// cursor продолжает список после страницы, на которой он был выдан, с той же сортировкой и не сочетается с offset
+func GetPageParams(r *http.Request) (repository.Page, error) {
+	return GetPageParamsWithSort(r, repository.ValidSortFields)
+}
+
+// GetPageParamsWithSort то же самое, что GetPageParams, но со своим набором полей сортировки
+func GetPageParamsWithSort(r *http.Request, sortFields map[string]bool) (repository.Page, error) {
+	limit, offset, err := GetPaginationParams(r)
+	if err != nil {
+		return repository.Page{}, utils.IncorrectLimitOffsetError
+	}
+	page := repository.Page{Limit: limit, Offset: offset}
+
+	query := r.URL.Query()
+	if sort := query.Get("sort"); sort != "" {
+		if page.Sort, err = parseSort(sort, sortFields); err != nil {
+			return repository.Page{}, utils.IncorrectFilterError.WithFields("sort")
+		}
+	}
+
+	if token := query.Get("cursor"); token != "" {
+		if query.Has("offset") {
+			return repository.Page{}, utils.IncorrectCursorError.WithFields("cursor", "offset")
+		}
+		page.After, err = DecodeCursor(token)
+		if err != nil || page.After.Sort != page.Sort.String() ||
+			!page.Sort.ValidCursorKey(page.After.Key) || !page.Sort.ValidCursorGroup(page.After.Group) {
+			return repository.Page{}, utils.IncorrectCursorError.WithFields("cursor")
+		}
+	}
+
+	if total := query.Get("total"); total != "" {
+		if page.WithTotal, err = strconv.ParseBool(total); err != nil {
+			return repository.Page{}, utils.IncorrectParamsError.WithFields("total")
+		}
+	}
+
+	return page, nil
+}
+
+// parseSort разбирает сортировку вида поле или поле:asc, поле:desc
+func parseSort(value string, sortFields map[string]bool) (repository.Sort, error) {
+	field, direction, _ := strings.Cut(value, ":")
+	if !sortFields[field] {
+		return repository.Sort{}, errors.New("invalid sort field")
+	}
+
+	switch direction {
+	case "", "asc":
+		return repository.Sort{Field: field}, nil
+	case "desc":
+		return repository.Sort{Field: field, Desc: true}, nil
+	default:
+		return repository.Sort{}, errors.New("invalid sort direction")
+	}
+}
+
+// GetCreatedRangeParams возвращает время из параметров created_from и created_to (RFC3339),
+// незаданная граница равна нулевому времени
+func GetCreatedRangeParams(r *http.Request) (time.Time, time.Time, error) {
+	from, err := GetOptionalTimeParam(r, "created_from")
+	if err != nil {
+		return from, time.Time{}, utils.IncorrectFilterError.WithFields("created_from")
+	}
+
+	to, err := GetOptionalTimeParam(r, "created_to")
+	if err != nil {
+		return from, to, utils.IncorrectFilterError.WithFields("created_to")
+	}
+
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		return from, to, utils.IncorrectFilterError.WithFields("created_from", "created_to")
+	}
+	return from, to, nil
+}
+
+// EncodeCursor кодирует курсор в непрозрачную для клиента строку
+func EncodeCursor(cursor *repository.Cursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(token string) (*repository.Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor repository.Cursor
+	if err = json.Unmarshal(data, &cursor); err != nil {
+		return nil, err
+	}
+	return &cursor, nil
+}
+
+// SetPageHeaders выставляет курсор следующей страницы в X-Next-Cursor и общее число записей в X-Total-Count
+func SetPageHeaders(w http.ResponseWriter, next *repository.Cursor, total *int) {
+	if next != nil {
+		w.Header().Set("X-Next-Cursor", EncodeCursor(next))
+	}
+	if total != nil {
+		w.Header().Set("X-Total-Count", strconv.Itoa(*total))
+	}
+}
+
+// SetPriceStatsHeaders отдает сводку цен списка заголовком X-Price-Stats, по одному значению на валюту:
+// RUB; count=3; min=100; median=150; max=200
+func SetPriceStatsHeaders(w http.ResponseWriter, stats []entity.PriceStats) {
+	for _, currencyStats := range stats {
+		w.Header().Add("X-Price-Stats", fmt.Sprintf("%s; count=%d; min=%s; median=%s; max=%s",
+			currencyStats.Currency, currencyStats.Count, currencyStats.Min, currencyStats.Median, currencyStats.Max))
+	}
+}
+
 func GetServiceTypeFilter(r *http.Request) ([]string, error) {
 	query := r.URL.Query()
 	serviceTypeFilter := query["service_type"] // Массив фильтров по типу услуг
 
 	for _, serviceType := range serviceTypeFilter {
 		if !entity.ValidServiceTypes[serviceType] {
-			return nil, errors.New("указан некорректный service_type: " + serviceType)
+			return nil, utils.IncorrectServiceTypeError.WithFields("service_type")
 		}
 	}
 
@@ -71,6 +194,89 @@ func GetUUIDFromRequestPath(r *http.Request, pathValue string) (uuid.UUID, error
 	return id, nil
 }
 
+// GetExpectedVersion возвращает версию из заголовка If-Match или 0, если заголовок не передан либо равен *
+func GetExpectedVersion(r *http.Request) (int, error) {
+	ifMatch := strings.TrimSpace(r.Header.Get("If-Match"))
+	if ifMatch == "" || ifMatch == "*" {
+		return 0, nil
+	}
+
+	ifMatch = strings.TrimPrefix(ifMatch, "W/")
+	if len(ifMatch) < 2 || ifMatch[0] != '"' || ifMatch[len(ifMatch)-1] != '"' {
+		return 0, errors.New("invalid If-Match header")
+	}
+
+	version, err := strconv.Atoi(ifMatch[1 : len(ifMatch)-1])
+	if err != nil || version < 1 {
+		return 0, errors.New("invalid If-Match header")
+	}
+	return version, nil
+}
+
+// SetETag выставляет ETag по версии тендера или предложения
+func SetETag(w http.ResponseWriter, version int) {
+	w.Header().Set("ETag", `"`+strconv.Itoa(version)+`"`)
+}
+
+// GetVersionRangeParams возвращает версии из обязательных параметров from и to
+func GetVersionRangeParams(r *http.Request) (int, int, error) {
+	query := r.URL.Query()
+
+	from, err := strconv.Atoi(query.Get("from"))
+	if err != nil || from < 1 {
+		return 0, 0, errors.New("invalid from parameter")
+	}
+
+	to, err := strconv.Atoi(query.Get("to"))
+	if err != nil || to < 1 {
+		return 0, 0, errors.New("invalid to parameter")
+	}
+
+	return from, to, nil
+}
+
+// IsJsonPatchRequested возвращает true, если изменения нужно отдать в виде JSON Patch (format=json-patch)
+func IsJsonPatchRequested(r *http.Request) (bool, error) {
+	switch r.URL.Query().Get("format") {
+	case "", consts.DiffFormatChanges:
+		return false, nil
+	case consts.DiffFormatJsonPatch:
+		return true, nil
+	default:
+		return false, errors.New("invalid format parameter")
+	}
+}
+
+// IsCompactViewRequested возвращает true, если список нужно отдать в кратком представлении (view=compact)
+func IsCompactViewRequested(r *http.Request) (bool, error) {
+	switch r.URL.Query().Get("view") {
+	case "", consts.ViewFull:
+		return false, nil
+	case consts.ViewCompact:
+		return true, nil
+	default:
+		return false, errors.New("invalid view parameter")
+	}
+}
+
+// GetOptionalUUIDParam возвращает uuid из параметра запроса или uuid.Nil, если параметр не задан
+func GetOptionalUUIDParam(r *http.Request, name string) (uuid.UUID, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return uuid.Nil, nil
+	}
+	return uuid.Parse(value)
+}
+
+// GetOptionalTimeParam возвращает время в формате RFC3339 из параметра запроса или нулевое время, если параметр не задан
+func GetOptionalTimeParam(r *http.Request, name string) (time.Time, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
 func GetVersionFromRequestPath(r *http.Request) (int, error) {
 	versionStr := r.PathValue("version")
 
@@ -0,0 +1,55 @@
+package common
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetExpectedVersion(t *testing.T) {
+	tests := []struct {
+		ifMatch string
+		want    int
+		wantErr bool
+	}{
+		{ifMatch: "", want: 0},
+		{ifMatch: "*", want: 0},
+		{ifMatch: `"3"`, want: 3},
+		{ifMatch: ` "12" `, want: 12},
+		{ifMatch: `W/"3"`, want: 3},
+		{ifMatch: "3", wantErr: true},
+		{ifMatch: `"0"`, wantErr: true},
+		{ifMatch: `"-1"`, wantErr: true},
+		{ifMatch: `"abc"`, wantErr: true},
+		{ifMatch: `""`, wantErr: true},
+		{ifMatch: `"`, wantErr: true},
+		{ifMatch: `"1", "2"`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ifMatch, func(t *testing.T) {
+			r := httptest.NewRequest("PATCH", "/api/bids/1/edit", nil)
+			if tt.ifMatch != "" {
+				r.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			got, err := GetExpectedVersion(r)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetExpectedVersion(%q) err = %v, wantErr %v", tt.ifMatch, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("GetExpectedVersion(%q) = %d, want %d", tt.ifMatch, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetETag(t *testing.T) {
+	w := httptest.NewRecorder()
+	SetETag(w, 7)
+
+	r := httptest.NewRequest("PATCH", "/api/bids/1/edit", nil)
+	r.Header.Set("If-Match", w.Header().Get("ETag"))
+	if version, err := GetExpectedVersion(r); err != nil || version != 7 {
+		t.Errorf("ETag %q read back as %d, %v", w.Header().Get("ETag"), version, err)
+	}
+}
@@ -0,0 +1,146 @@
+package common
+
+import (
+	"encoding/base64"
+	"errors"
+	"github.com/google/uuid"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"tenders/internal/domain/repository"
+	"tenders/internal/utils"
+	"testing"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor repository.Cursor
+	}{
+		{"default sort", repository.Cursor{Key: "Тендер на поставку", Id: uuid.New()}},
+		{"created at", repository.Cursor{Sort: "created_at:desc", Key: "2024-09-16 10:00:00.123456", Id: uuid.New()}},
+		{"search rank", repository.Cursor{Rank: 0.25, Key: "name", Id: uuid.New()}},
+		{"price", repository.Cursor{Sort: "price:asc", Group: "RUB", Key: "1500.25", Id: uuid.New()}},
+		{"price unset", repository.Cursor{
+			Sort: "price:asc", Group: repository.PriceCursorGroupUnset, Key: repository.PriceCursorKeyUnset, Id: uuid.New(),
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoded, err := DecodeCursor(EncodeCursor(&tt.cursor))
+			if err != nil {
+				t.Fatalf("DecodeCursor: %v", err)
+			}
+			if !reflect.DeepEqual(*decoded, tt.cursor) {
+				t.Errorf("round trip = %+v, want %+v", *decoded, tt.cursor)
+			}
+		})
+	}
+}
+
+func TestGetPageParamsWithSortCursor(t *testing.T) {
+	id := uuid.New()
+	encode := func(cursor repository.Cursor) string {
+		return EncodeCursor(&cursor)
+	}
+
+	tests := []struct {
+		name    string
+		query   url.Values
+		wantErr error
+		want    *repository.Cursor
+	}{
+		{
+			name:  "valid cursor",
+			query: url.Values{"cursor": {encode(repository.Cursor{Key: "name", Id: id})}},
+			want:  &repository.Cursor{Key: "name", Id: id},
+		},
+		{
+			name: "valid price cursor",
+			query: url.Values{"sort": {"price"}, "cursor": {encode(repository.Cursor{
+				Sort: "price:asc", Group: "USD", Key: "99.5", Id: id,
+			})}},
+			want: &repository.Cursor{Sort: "price:asc", Group: "USD", Key: "99.5", Id: id},
+		},
+		{
+			name:    "not base64",
+			query:   url.Values{"cursor": {"not a cursor!"}},
+			wantErr: utils.IncorrectCursorError,
+		},
+		{
+			name:    "not json",
+			query:   url.Values{"cursor": {base64.RawURLEncoding.EncodeToString([]byte("{broken"))}},
+			wantErr: utils.IncorrectCursorError,
+		},
+		{
+			name:    "issued for another sort",
+			query:   url.Values{"sort": {"name:desc"}, "cursor": {encode(repository.Cursor{Key: "name", Id: id})}},
+			wantErr: utils.IncorrectCursorError,
+		},
+		{
+			name: "tampered sort",
+			query: url.Values{"cursor": {encode(repository.Cursor{
+				Sort: "created_at:asc", Key: "2024-09-16 10:00:00", Id: id,
+			})}},
+			wantErr: utils.IncorrectCursorError,
+		},
+		{
+			name: "tampered time key",
+			query: url.Values{"sort": {"created_at"}, "cursor": {encode(repository.Cursor{
+				Sort: "created_at:asc", Key: "yesterday", Id: id,
+			})}},
+			wantErr: utils.IncorrectCursorError,
+		},
+		{
+			name: "tampered version key",
+			query: url.Values{"sort": {"version:desc"}, "cursor": {encode(repository.Cursor{
+				Sort: "version:desc", Key: "1; DROP TABLE bid", Id: id,
+			})}},
+			wantErr: utils.IncorrectCursorError,
+		},
+		{
+			name: "tampered price key",
+			query: url.Values{"sort": {"price"}, "cursor": {encode(repository.Cursor{
+				Sort: "price:asc", Group: "RUB", Key: "-1", Id: id,
+			})}},
+			wantErr: utils.IncorrectCursorError,
+		},
+		{
+			name: "tampered price group",
+			query: url.Values{"sort": {"price"}, "cursor": {encode(repository.Cursor{
+				Sort: "price:asc", Group: "rub", Key: "10", Id: id,
+			})}},
+			wantErr: utils.IncorrectCursorError,
+		},
+		{
+			name:    "group without price sort",
+			query:   url.Values{"cursor": {encode(repository.Cursor{Group: "RUB", Key: "name", Id: id})}},
+			wantErr: utils.IncorrectCursorError,
+		},
+		{
+			name:    "cursor with offset",
+			query:   url.Values{"offset": {"5"}, "cursor": {encode(repository.Cursor{Key: "name", Id: id})}},
+			wantErr: utils.IncorrectCursorError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/api/bids/my?"+tt.query.Encode(), nil)
+			page, err := GetPageParamsWithSort(r, repository.ValidBidSortFields)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(page.After, tt.want) {
+				t.Errorf("After = %+v, want %+v", page.After, tt.want)
+			}
+		})
+	}
+}
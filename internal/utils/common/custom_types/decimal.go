@@ -0,0 +1,92 @@
+package custom_types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+// Ограничения суммы совпадают с колонками NUMERIC(19, 4)
+const (
+	DecimalIntegerDigits  = 15
+	DecimalFractionDigits = 4
+)
+
+var decimalPattern = regexp.MustCompile(fmt.Sprintf(
+	`^(0|[1-9][0-9]{0,%d})(\.[0-9]{1,%d})?$`, DecimalIntegerDigits-1, DecimalFractionDigits,
+))
+
+// Decimal неотрицательная денежная сумма. Хранится строкой, чтобы не терять точность на float,
+// в JSON отдается числом
+type Decimal string
+
+// ParseDecimal разбирает сумму вида 1500 или 1500.25 и приводит ее к каноническому виду без лишних нулей
+func ParseDecimal(value string) (Decimal, error) {
+	if !decimalPattern.MatchString(value) {
+		return "", errors.New("некорректная сумма: " + value)
+	}
+	return normalizeDecimal(value), nil
+}
+
+func normalizeDecimal(value string) Decimal {
+	if strings.Contains(value, ".") {
+		value = strings.TrimRight(strings.TrimRight(value, "0"), ".")
+	}
+	return Decimal(value)
+}
+
+// Cmp сравнивает суммы: -1, если d меньше other, 0, если равны, и 1, если больше
+func (d Decimal) Cmp(other Decimal) int {
+	return d.rat().Cmp(other.rat())
+}
+
+func (d Decimal) rat() *big.Rat {
+	value, ok := new(big.Rat).SetString(string(d))
+	if !ok {
+		return new(big.Rat)
+	}
+	return value
+}
+
+func (d Decimal) String() string {
+	return string(d)
+}
+
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d), nil
+}
+
+// UnmarshalJSON принимает сумму числом или строкой
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	var number json.Number
+	if err := json.Unmarshal(data, &number); err != nil {
+		return err
+	}
+	parsed, err := ParseDecimal(number.String())
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// Scan читает NUMERIC из базы
+func (d *Decimal) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case []byte:
+		*d = normalizeDecimal(string(value))
+	case string:
+		*d = normalizeDecimal(value)
+	default:
+		return fmt.Errorf("неподдерживаемый тип суммы %T", src)
+	}
+	return nil
+}
+
+func (d Decimal) Value() (driver.Value, error) {
+	return string(d), nil
+}
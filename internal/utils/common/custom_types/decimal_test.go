@@ -0,0 +1,153 @@
+package custom_types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Decimal
+		wantErr bool
+	}{
+		{value: "0", want: "0"},
+		{value: "1500", want: "1500"},
+		{value: "1500.25", want: "1500.25"},
+		{value: "1500.2500", want: "1500.25"},
+		{value: "1500.0", want: "1500"},
+		{value: "0.0001", want: "0.0001"},
+		{value: "0.000", want: "0"},
+		{value: "100", want: "100"},
+		{value: "999999999999999.9999", want: "999999999999999.9999"},
+		{value: "1000000000000000", wantErr: true},
+		{value: "1.00001", wantErr: true},
+		{value: "-1", wantErr: true},
+		{value: "01", wantErr: true},
+		{value: "1.", wantErr: true},
+		{value: ".5", wantErr: true},
+		{value: "1e3", wantErr: true},
+		{value: "1,5", wantErr: true},
+		{value: " 1", wantErr: true},
+		{value: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseDecimal(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseDecimal(%q) = %q, want error", tt.value, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseDecimal(%q): %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseDecimal(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecimalCmp(t *testing.T) {
+	tests := []struct {
+		a, b Decimal
+		want int
+	}{
+		{"100", "100", 0},
+		{"100", "100.0001", -1},
+		{"99.9999", "100", -1},
+		{"1000", "999.5", 1},
+		{"0", "0.0001", -1},
+		{"999999999999999.9999", "999999999999999.9998", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.a)+" vs "+string(tt.b), func(t *testing.T) {
+			if got := tt.a.Cmp(tt.b); got != tt.want {
+				t.Errorf("%s.Cmp(%s) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecimalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Decimal
+		wantErr bool
+	}{
+		{name: "number", input: `1500.25`, want: "1500.25"},
+		{name: "string", input: `"1500.25"`, want: "1500.25"},
+		{name: "trailing zeros", input: `10.50`, want: "10.5"},
+		{name: "beyond float64 precision", input: `123456789012345.6789`, want: "123456789012345.6789"},
+		{name: "negative", input: `-5`, wantErr: true},
+		{name: "exponent", input: `1e3`, wantErr: true},
+		{name: "not a number", input: `"abc"`, wantErr: true},
+		{name: "bool", input: `true`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Decimal
+			err := json.Unmarshal([]byte(tt.input), &got)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Unmarshal(%s) = %q, want error", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unmarshal(%s): %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Fatalf("Unmarshal(%s) = %q, want %q", tt.input, got, tt.want)
+			}
+
+			encoded, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+			if string(encoded) != string(tt.want) {
+				t.Errorf("Marshal = %s, want %s", encoded, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecimalScan(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     interface{}
+		want    Decimal
+		wantErr bool
+	}{
+		{name: "numeric bytes", src: []byte("1500.2500"), want: "1500.25"},
+		{name: "integer numeric", src: []byte("1500.0000"), want: "1500"},
+		{name: "string", src: "0.5000", want: "0.5"},
+		{name: "whole string", src: "200", want: "200"},
+		{name: "unsupported", src: 1.5, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Decimal
+			err := got.Scan(tt.src)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Scan(%v) = %q, want error", tt.src, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Scan(%v): %v", tt.src, err)
+			}
+			if got != tt.want {
+				t.Errorf("Scan(%v) = %q, want %q", tt.src, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,32 +1,76 @@
 package common
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"io"
+	"log"
 	"net/http"
 	"strings"
 	"tenders/internal/interfaces/dto/response"
 	"tenders/internal/utils"
-	"tenders/internal/utils/consts"
+	"tenders/internal/utils/i18n"
 )
 
-func RespondWithError(w http.ResponseWriter, statusCode int, errorMsg string) {
-	j, err := json.Marshal(response.ErrorResponse{Reason: errorMsg})
+// RespondWithError отвечает ошибкой в едином формате. Статус код и текст берутся из utils.Error,
+// при истекшем дедлайне запроса отдается 504, при отмене 503, остальные ошибки логируются
+// и отдаются клиенту как 500 без подробностей
+func RespondWithError(w http.ResponseWriter, r *http.Request, err error) {
+	var appErr *utils.Error
+	ctxErr := r.Context().Err()
+	switch {
+	case errors.As(err, &appErr):
+	case errors.Is(err, context.DeadlineExceeded) || errors.Is(ctxErr, context.DeadlineExceeded):
+		appErr = utils.RequestTimeoutError
+	case errors.Is(err, context.Canceled) || errors.Is(ctxErr, context.Canceled):
+		appErr = utils.RequestCanceledError
+	default:
+		log.Printf("%s %s: %v", r.Method, r.URL.Path, err)
+		appErr = utils.InternalError
+	}
+
+	writeError(w, i18n.FromContext(r.Context()), appErr)
+}
 
+// writeError отдает ошибку с текстом на языке language
+func writeError(w http.ResponseWriter, language i18n.Language, appErr *utils.Error) {
+	fields := appErr.Fields
+	if fields == nil {
+		fields = []string{}
+	}
+
+	j, err := json.Marshal(response.ErrorResponse{
+		Code:       appErr.Code,
+		Reason:     i18n.Message(language, appErr.Code, fields, appErr.Permission),
+		Fields:     fields,
+		Permission: appErr.Permission,
+	})
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
-	w.WriteHeader(statusCode)
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Language", string(language))
+	w.WriteHeader(appErr.StatusCode())
 
 	_, _ = w.Write(j)
 }
 
 func RespondOKWithJson(w http.ResponseWriter, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
+	respondOK(w, "application/json", data)
+}
+
+// RespondOKWithJsonPatch отдает документ JSON Patch (RFC 6902) с соответствующим Content-Type
+func RespondOKWithJsonPatch(w http.ResponseWriter, patch interface{}) {
+	respondOK(w, "application/json-patch+json", patch)
+}
+
+func respondOK(w http.ResponseWriter, contentType string, data interface{}) {
+	w.Header().Set("Content-Type", contentType)
 	if err := json.NewEncoder(w).Encode(data); err != nil {
-		RespondWithError(w, http.StatusInternalServerError, consts.FailedToWriteResponse)
+		writeError(w, i18n.DefaultLanguage(), utils.FailedToWriteResponseError)
 		return
 	}
 }
@@ -51,7 +95,10 @@ func CheckForExtraParams(r *http.Request, expectedParams []string) bool {
 
 func DecodeAndValidateJSON(body io.Reader, v interface{}) error {
 	var rawRequest map[string]interface{}
-	if err := json.NewDecoder(body).Decode(&rawRequest); err != nil {
+	// Числа остаются json.Number, иначе суммы теряли бы точность на float64 при повторной сериализации
+	rawDecoder := json.NewDecoder(body)
+	rawDecoder.UseNumber()
+	if err := rawDecoder.Decode(&rawRequest); err != nil {
 		return utils.IncorrectRequestBody
 	}
 
@@ -0,0 +1,18 @@
+package common
+
+import "net"
+
+// sharedAddressSpace диапазон 100.64.0.0/10 (RFC 6598), используется провайдерами для CGNAT
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// IsPublicIP адрес не loopback, не из частных, link-local и служебных сетей.
+// На такие адреса нельзя отправлять запросы по адресу, который задал пользователь
+func IsPublicIP(ip net.IP) bool {
+	if ip == nil || ip.IsUnspecified() || ip.IsLoopback() || ip.IsPrivate() || ip.IsMulticast() {
+		return false
+	}
+	if ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() {
+		return false
+	}
+	return !sharedAddressSpace.Contains(ip)
+}
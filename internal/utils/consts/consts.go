@@ -19,29 +19,94 @@ const (
 	BidApproved string = "Approved"
 	BidRejected string = "Rejected"
 
-	IncorrectRequestBody    string = "Некорректное тело запроса"
-	InternalServerError     string = "Неизвестная ошибка сервера"
-	InsufficientPermissions string = "У вас недостаточно прав для выполнения данного запроса"
-	FailedToWriteResponse   string = "Не удалось сформировать ответ на запрос"
-
-	IncorrectLimitOffsetParams      string = "Некорректно задан limit или/и offset"
-	NoUsernameParamPresent          string = "Не задан username пользователя"
-	NoAuthorUsernameParamPresent    string = "Не задан параметр authorUsername"
-	NoRequesterUsernameParamPresent string = "Не задан параметр requesterUsername"
-	IncorrectDecision               string = "Некорректно задано решение, варианты: Approved, Rejected"
-	IncorrectFeedback               string = "Параметр bidFeedback обязателен"
-	IncorrectVersion                string = "Версия указана не числом"
-	IncorrectTenderId               string = "Id тендера должно быть в формате uuid"
-	IncorrectBidId                  string = "Id предложения должно быть в формате uuid"
-	IncorrectStatus                 string = "Статус не указан либо указан некорректный"
-	IncorrectParams                 string = "Указаны лишние параметры в запросе"
-
-	UserNotExists              string = "Пользователь не существует или некорректен для данного запроса"
-	ReviewsNotFound            string = "Отзывы не найдены"
-	UserOrOrgNotExists         string = "Пользователь или организация не существует"
-	TenderNotExists            string = "Тендер не существует"
-	BidNotExists               string = "Предложение не существует"
-	BidForTenderNotExistsError string = "У автора нет предложений созданных для указанного тендера"
-	TenderOrVersionNotExists   string = "Тендер или его версия не существует"
-	VersionNotExists           string = "Версия не существует"
+	AuditEntityTender string = "Tender"
+	AuditEntityBid    string = "Bid"
+	AuditEntityReview string = "Review"
+
+	AuditActionCreate       string = "Create"
+	AuditActionEdit         string = "Edit"
+	AuditActionRollback     string = "Rollback"
+	AuditActionStatusChange string = "StatusChange"
+	AuditActionDecision     string = "Decision"
+	AuditActionFeedback     string = "Feedback"
+	AuditActionCriteria     string = "Criteria"
+	AuditActionScore        string = "Score"
+
+	EventTenderPublished string = "tender.published"
+	EventTenderClosed    string = "tender.closed"
+	EventBidSubmitted    string = "bid.submitted"
+	EventBidDecision     string = "bid.decision"
+
+	WebhookDeliveryPending    string = "Pending"
+	WebhookDeliveryDelivered  string = "Delivered"
+	WebhookDeliveryDeadLetter string = "DeadLetter"
+
+	DiffFormatChanges   string = "changes"
+	DiffFormatJsonPatch string = "json-patch"
+
+	ViewFull    string = "full"
+	ViewCompact string = "compact"
+
+	// BidApprovalQuorum максимальное число одобрений, необходимое для принятия предложения
+	BidApprovalQuorum int = 3
+
+	// Коды ошибок, по ним клиенты различают ошибки, а каталог i18n подбирает текст
+	ErrCodeInsufficientPermissions string = "insufficient_permissions"
+	ErrCodePermissionDenied        string = "permission_denied"
+	ErrCodeIncorrectRequestBody    string = "incorrect_request_body"
+	ErrCodeIncorrectParams         string = "incorrect_params"
+	ErrCodeIncorrectLimitOffset    string = "incorrect_limit_offset"
+	ErrCodeIncorrectTenderId       string = "incorrect_tender_id"
+	ErrCodeIncorrectBidId          string = "incorrect_bid_id"
+	ErrCodeIncorrectStatus         string = "incorrect_status"
+	ErrCodeIncorrectServiceType    string = "incorrect_service_type"
+	ErrCodeIncorrectVersion        string = "incorrect_version"
+	ErrCodeIncorrectVersionRange   string = "incorrect_version_range"
+	ErrCodeIncorrectDiffFormat     string = "incorrect_diff_format"
+	ErrCodeIncorrectView           string = "incorrect_view"
+	ErrCodeIncorrectIfMatch        string = "incorrect_if_match"
+	ErrCodeIncorrectDecision       string = "incorrect_decision"
+	ErrCodeIncorrectFeedback       string = "incorrect_feedback"
+	ErrCodeIncorrectFilter         string = "incorrect_filter"
+	ErrCodeIncorrectWebhookId      string = "incorrect_webhook_id"
+	ErrCodeIncorrectLastEventId    string = "incorrect_last_event_id"
+	ErrCodeIncorrectCursor         string = "incorrect_cursor"
+	ErrCodeIncorrectEmployeeId     string = "incorrect_employee_id"
+	ErrCodeIncorrectOrganizationId string = "incorrect_organization_id"
+	ErrCodeNoAuthorUsername        string = "no_author_username"
+	ErrCodeAuthorNotFound          string = "author_not_found"
+	ErrCodeTenderNotFound          string = "tender_not_found"
+	ErrCodeTenderVersionNotFound   string = "tender_version_not_found"
+	ErrCodeBidNotFound             string = "bid_not_found"
+	ErrCodeBidForTenderNotFound    string = "bid_for_tender_not_found"
+	ErrCodeReviewsNotFound         string = "reviews_not_found"
+	ErrCodeWebhookNotFound         string = "webhook_not_found"
+	ErrCodeEmployeeNotFound        string = "employee_not_found"
+	ErrCodeOrganizationNotFound    string = "organization_not_found"
+	ErrCodeResponsibleNotFound     string = "responsible_not_found"
+	ErrCodeUserNotFound            string = "user_not_found"
+	ErrCodeVersionNotFound         string = "version_not_found"
+	ErrCodeBidAlreadyDecided       string = "bid_already_decided"
+	ErrCodeSubmissionClosed        string = "submission_deadline_passed"
+	ErrCodeDecisionClosed          string = "decision_deadline_passed"
+	ErrCodeBudgetExceeded          string = "budget_exceeded"
+	ErrCodeCriteriaNotDefined      string = "criteria_not_defined"
+	ErrCodeCriteriaLocked          string = "criteria_locked"
+	ErrCodeBidNotPublished         string = "bid_not_published"
+	ErrCodeTenderNotPublished      string = "tender_not_published"
+	ErrCodeVersionConflict         string = "version_conflict"
+	ErrCodeStatusTransition        string = "status_transition_not_allowed"
+	ErrCodeUsernameTaken           string = "username_taken"
+	ErrCodeLastOwner               string = "last_owner"
+	ErrCodeEmployeeInactive        string = "employee_inactive"
+	ErrCodeOrganizationInactive    string = "organization_inactive"
+	ErrCodeOrganizationRequired    string = "organization_context_required"
+	ErrCodeOrganizationMismatch    string = "organization_context_mismatch"
+	ErrCodeInvalidCredentials      string = "invalid_credentials"
+	ErrCodeInvalidToken            string = "invalid_token"
+	ErrCodeRequestTimeout          string = "request_timeout"
+	ErrCodeRequestCanceled         string = "request_canceled"
+	ErrCodeInternalError           string = "internal_error"
+	ErrCodeFailedToWriteResponse   string = "failed_to_write_response"
+	ErrCodeValidationFailed        string = "validation_failed"
 )
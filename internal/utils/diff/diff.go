@@ -0,0 +1,57 @@
+package diff
+
+import (
+	"reflect"
+	"strings"
+)
+
+// FieldChange изменение одного поля между двумя версиями
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+// PatchOperation операция JSON Patch (RFC 6902)
+type PatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	Value any    `json:"value"`
+}
+
+// Fields сравнивает две версии сущности по полям с тегом diff и возвращает изменившиеся поля
+// в порядке их объявления в структуре. from и to должны быть одного типа
+func Fields[T any](from, to T) []FieldChange {
+	fromValue := reflect.Indirect(reflect.ValueOf(from))
+	toValue := reflect.Indirect(reflect.ValueOf(to))
+
+	changes := []FieldChange{}
+	for i := 0; i < fromValue.NumField(); i++ {
+		name := fromValue.Type().Field(i).Tag.Get("diff")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		oldValue := fromValue.Field(i).Interface()
+		newValue := toValue.Field(i).Interface()
+		if !reflect.DeepEqual(oldValue, newValue) {
+			changes = append(changes, FieldChange{Field: name, Old: oldValue, New: newValue})
+		}
+	}
+	return changes
+}
+
+// Patch превращает список изменений в документ JSON Patch, который переводит старую версию в новую
+func Patch(changes []FieldChange) []PatchOperation {
+	pointerEscaper := strings.NewReplacer("~", "~0", "/", "~1")
+
+	operations := make([]PatchOperation, 0, len(changes))
+	for _, change := range changes {
+		operations = append(operations, PatchOperation{
+			Op:    "replace",
+			Path:  "/" + pointerEscaper.Replace(change.Field),
+			Value: change.New,
+		})
+	}
+	return operations
+}
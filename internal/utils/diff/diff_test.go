@@ -0,0 +1,120 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+)
+
+type version struct {
+	Id       int     `json:"id"`
+	Name     string  `diff:"name"`
+	Status   string  `diff:"status"`
+	Deadline *string `diff:"deadline"`
+	Path     string  `diff:"a/b~c"`
+	Ignored  string  `diff:"-"`
+}
+
+func TestFields(t *testing.T) {
+	deadline, otherDeadline := "2024-09-16T10:00:00Z", "2024-09-20T10:00:00Z"
+
+	tests := []struct {
+		name string
+		from version
+		to   version
+		want []FieldChange
+	}{
+		{
+			name: "no changes",
+			from: version{Id: 1, Name: "a", Status: "Created"},
+			to:   version{Id: 1, Name: "a", Status: "Created"},
+			want: []FieldChange{},
+		},
+		{
+			name: "untagged and ignored fields",
+			from: version{Id: 1, Ignored: "x"},
+			to:   version{Id: 2, Ignored: "y"},
+			want: []FieldChange{},
+		},
+		{
+			name: "changes in declaration order",
+			from: version{Name: "a", Status: "Created"},
+			to:   version{Name: "b", Status: "Published"},
+			want: []FieldChange{
+				{Field: "name", Old: "a", New: "b"},
+				{Field: "status", Old: "Created", New: "Published"},
+			},
+		},
+		{
+			name: "pointer set",
+			from: version{},
+			to:   version{Deadline: &deadline},
+			want: []FieldChange{{Field: "deadline", Old: (*string)(nil), New: &deadline}},
+		},
+		{
+			name: "equal pointers by value",
+			from: version{Deadline: &deadline},
+			to:   version{Deadline: &[]string{deadline}[0]},
+			want: []FieldChange{},
+		},
+		{
+			name: "pointer changed",
+			from: version{Deadline: &deadline},
+			to:   version{Deadline: &otherDeadline},
+			want: []FieldChange{{Field: "deadline", Old: &deadline, New: &otherDeadline}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Fields(tt.from, tt.to); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Fields() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFieldsOfPointers(t *testing.T) {
+	got := Fields(&version{Name: "a"}, &version{Name: "b"})
+	want := []FieldChange{{Field: "name", Old: "a", New: "b"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Fields() = %+v, want %+v", got, want)
+	}
+}
+
+func TestPatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		changes []FieldChange
+		want    []PatchOperation
+	}{
+		{
+			name:    "no changes",
+			changes: []FieldChange{},
+			want:    []PatchOperation{},
+		},
+		{
+			name: "replace with new values",
+			changes: []FieldChange{
+				{Field: "name", Old: "a", New: "b"},
+				{Field: "status", Old: "Created", New: "Published"},
+			},
+			want: []PatchOperation{
+				{Op: "replace", Path: "/name", Value: "b"},
+				{Op: "replace", Path: "/status", Value: "Published"},
+			},
+		},
+		{
+			name:    "escaped pointer",
+			changes: []FieldChange{{Field: "a/b~c", Old: "x", New: "y"}},
+			want:    []PatchOperation{{Op: "replace", Path: "/a~1b~0c", Value: "y"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Patch(tt.changes); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Patch() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
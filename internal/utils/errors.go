@@ -1,22 +1,139 @@
 package utils
 
 import (
-	"errors"
-	"strings"
+	"net/http"
+	"tenders/internal/utils/consts"
 )
 
+// Kind категория ошибки, по ней определяется статус код ответа
+type Kind int
+
+const (
+	KindInternal Kind = iota
+	KindInvalidInput
+	KindUnauthenticated
+	KindForbidden
+	KindNotFound
+	KindConflict
+	KindTimeout
+	KindCanceled
+)
+
+var kindStatusCodes = map[Kind]int{
+	KindInternal:        http.StatusInternalServerError,
+	KindInvalidInput:    http.StatusBadRequest,
+	KindUnauthenticated: http.StatusUnauthorized,
+	KindForbidden:       http.StatusForbidden,
+	KindNotFound:        http.StatusNotFound,
+	KindConflict:        http.StatusConflict,
+	KindTimeout:         http.StatusGatewayTimeout,
+	KindCanceled:        http.StatusServiceUnavailable,
+}
+
+// Error ошибка, которую можно отдать клиенту: по Code каталог i18n подбирает текст на языке запроса,
+// Fields содержит поля запроса, к которым относится ошибка, Permission недостающее разрешение
+type Error struct {
+	Kind       Kind
+	Code       string
+	Fields     []string
+	Permission string
+}
+
+func newError(kind Kind, code string) *Error {
+	return &Error{Kind: kind, Code: code}
+}
+
+func (e *Error) Error() string {
+	return e.Code
+}
+
+// Is сравнивает ошибки по коду, чтобы копии с полями (WithFields) совпадали с исходной ошибкой
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// WithFields возвращает копию ошибки с указанными полями
+func (e *Error) WithFields(fields ...string) *Error {
+	withFields := *e
+	withFields.Fields = fields
+	return &withFields
+}
+
+// WithPermission возвращает копию ошибки с названием недостающего разрешения
+func (e *Error) WithPermission(permission string) *Error {
+	withPermission := *e
+	withPermission.Permission = permission
+	return &withPermission
+}
+
+func (e *Error) StatusCode() int {
+	return kindStatusCodes[e.Kind]
+}
+
 var (
-	UnauthorizedAccessError = errors.New("unauthorized access")
-	IncorrectRequestBody    = errors.New("incorrect request body")
-
-	ElementNotExistsError      = errors.New("element not exist")
-	TenderNotExistsError       = errors.New("tender not exist")
-	BidNotExistsError          = errors.New("bid not exist")
-	BidForTenderNotExistsError = errors.New("bid for tender not exist")
-	UserNotExistsError         = errors.New("user not exist")
-	VersionNotExistsError      = errors.New("version not exist")
+	UnauthorizedAccessError = newError(KindForbidden, consts.ErrCodeInsufficientPermissions)
+	PermissionDeniedError   = newError(KindForbidden, consts.ErrCodePermissionDenied)
+	IncorrectRequestBody    = newError(KindInvalidInput, consts.ErrCodeIncorrectRequestBody)
+
+	IncorrectParamsError         = newError(KindInvalidInput, consts.ErrCodeIncorrectParams)
+	IncorrectLimitOffsetError    = newError(KindInvalidInput, consts.ErrCodeIncorrectLimitOffset)
+	IncorrectTenderIdError       = newError(KindInvalidInput, consts.ErrCodeIncorrectTenderId)
+	IncorrectBidIdError          = newError(KindInvalidInput, consts.ErrCodeIncorrectBidId)
+	IncorrectStatusError         = newError(KindInvalidInput, consts.ErrCodeIncorrectStatus)
+	IncorrectServiceTypeError    = newError(KindInvalidInput, consts.ErrCodeIncorrectServiceType)
+	IncorrectVersionError        = newError(KindInvalidInput, consts.ErrCodeIncorrectVersion)
+	IncorrectVersionRangeError   = newError(KindInvalidInput, consts.ErrCodeIncorrectVersionRange)
+	IncorrectDiffFormatError     = newError(KindInvalidInput, consts.ErrCodeIncorrectDiffFormat)
+	IncorrectViewError           = newError(KindInvalidInput, consts.ErrCodeIncorrectView)
+	IncorrectIfMatchError        = newError(KindInvalidInput, consts.ErrCodeIncorrectIfMatch)
+	IncorrectDecisionError       = newError(KindInvalidInput, consts.ErrCodeIncorrectDecision)
+	IncorrectFeedbackError       = newError(KindInvalidInput, consts.ErrCodeIncorrectFeedback)
+	IncorrectFilterError         = newError(KindInvalidInput, consts.ErrCodeIncorrectFilter)
+	IncorrectWebhookIdError      = newError(KindInvalidInput, consts.ErrCodeIncorrectWebhookId)
+	IncorrectLastEventIdError    = newError(KindInvalidInput, consts.ErrCodeIncorrectLastEventId)
+	IncorrectCursorError         = newError(KindInvalidInput, consts.ErrCodeIncorrectCursor)
+	IncorrectEmployeeIdError     = newError(KindInvalidInput, consts.ErrCodeIncorrectEmployeeId)
+	IncorrectOrganizationIdError = newError(KindInvalidInput, consts.ErrCodeIncorrectOrganizationId)
+	NoAuthorUsernameError        = newError(KindInvalidInput, consts.ErrCodeNoAuthorUsername)
+	OrganizationRequiredError    = newError(KindInvalidInput, consts.ErrCodeOrganizationRequired)
+	OrganizationMismatchError    = newError(KindInvalidInput, consts.ErrCodeOrganizationMismatch)
+
+	ElementNotExistsError       = newError(KindUnauthenticated, consts.ErrCodeAuthorNotFound)
+	TenderNotExistsError        = newError(KindNotFound, consts.ErrCodeTenderNotFound)
+	TenderVersionNotExistsError = newError(KindNotFound, consts.ErrCodeTenderVersionNotFound)
+	BidNotExistsError           = newError(KindNotFound, consts.ErrCodeBidNotFound)
+	BidForTenderNotExistsError  = newError(KindInvalidInput, consts.ErrCodeBidForTenderNotFound)
+	ReviewsNotExistsError       = newError(KindNotFound, consts.ErrCodeReviewsNotFound)
+	WebhookNotExistsError       = newError(KindNotFound, consts.ErrCodeWebhookNotFound)
+	EmployeeNotExistsError      = newError(KindNotFound, consts.ErrCodeEmployeeNotFound)
+	OrganizationNotExistsError  = newError(KindNotFound, consts.ErrCodeOrganizationNotFound)
+	ResponsibleNotExistsError   = newError(KindNotFound, consts.ErrCodeResponsibleNotFound)
+	UserNotExistsError          = newError(KindUnauthenticated, consts.ErrCodeUserNotFound)
+	VersionNotExistsError       = newError(KindNotFound, consts.ErrCodeVersionNotFound)
+	BidAlreadyDecidedError      = newError(KindInvalidInput, consts.ErrCodeBidAlreadyDecided)
+	SubmissionClosedError       = newError(KindInvalidInput, consts.ErrCodeSubmissionClosed)
+	DecisionClosedError         = newError(KindInvalidInput, consts.ErrCodeDecisionClosed)
+	BudgetExceededError         = newError(KindInvalidInput, consts.ErrCodeBudgetExceeded)
+	CriteriaNotDefinedError     = newError(KindConflict, consts.ErrCodeCriteriaNotDefined)
+	CriteriaLockedError         = newError(KindConflict, consts.ErrCodeCriteriaLocked)
+	BidNotPublishedError        = newError(KindConflict, consts.ErrCodeBidNotPublished)
+	TenderNotPublishedError     = newError(KindInvalidInput, consts.ErrCodeTenderNotPublished)
+	VersionConflictError        = newError(KindConflict, consts.ErrCodeVersionConflict)
+	StatusTransitionError       = newError(KindConflict, consts.ErrCodeStatusTransition)
+	UsernameTakenError          = newError(KindConflict, consts.ErrCodeUsernameTaken)
+	LastOwnerError              = newError(KindConflict, consts.ErrCodeLastOwner)
+	EmployeeInactiveError       = newError(KindConflict, consts.ErrCodeEmployeeInactive)
+	OrganizationInactiveError   = newError(KindConflict, consts.ErrCodeOrganizationInactive)
+	InvalidCredentialsError     = newError(KindUnauthenticated, consts.ErrCodeInvalidCredentials)
+	InvalidTokenError           = newError(KindUnauthenticated, consts.ErrCodeInvalidToken)
+	RequestTimeoutError         = newError(KindTimeout, consts.ErrCodeRequestTimeout)
+	RequestCanceledError        = newError(KindCanceled, consts.ErrCodeRequestCanceled)
+	InternalError               = newError(KindInternal, consts.ErrCodeInternalError)
+	FailedToWriteResponseError  = newError(KindInternal, consts.ErrCodeFailedToWriteResponse)
+	validationError             = newError(KindInvalidInput, consts.ErrCodeValidationFailed)
 )
 
 func NewValidationError(errorFields []string) error {
-	return errors.New("Неправильно заполнены поля: " + strings.Join(errorFields, ", "))
+	return validationError.WithFields(errorFields...)
 }
@@ -0,0 +1,105 @@
+package i18n
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type Language string
+
+const (
+	Russian Language = "ru"
+	English Language = "en"
+)
+
+var catalogs = map[Language]map[string]string{
+	Russian: messagesRu,
+	English: messagesEn,
+}
+
+// defaultLanguage используется, если клиент не передал Accept-Language или ни один из языков не поддерживается
+var defaultLanguage = Russian
+
+type languageKey struct{}
+
+// ParseLanguage проверяет, что язык есть в каталоге
+func ParseLanguage(tag string) (Language, bool) {
+	language := Language(strings.ToLower(strings.TrimSpace(tag)))
+	_, found := catalogs[language]
+	return language, found
+}
+
+// SetDefaultLanguage задает язык по умолчанию, вызывается один раз при старте приложения
+func SetDefaultLanguage(language Language) {
+	defaultLanguage = language
+}
+
+func DefaultLanguage() Language {
+	return defaultLanguage
+}
+
+// Negotiate выбирает поддерживаемый язык с наибольшим весом q из заголовка Accept-Language,
+// регион не учитывается: en-US считается en
+func Negotiate(acceptLanguage string) Language {
+	type candidate struct {
+		language Language
+		weight   float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		weight := 1.0
+		if q, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			weight = parsed
+		}
+		if weight <= 0 {
+			continue
+		}
+
+		primary, _, _ := strings.Cut(tag, "-")
+		if language, found := ParseLanguage(primary); found {
+			candidates = append(candidates, candidate{language: language, weight: weight})
+		}
+	}
+
+	if len(candidates) == 0 {
+		return defaultLanguage
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].weight > candidates[j].weight
+	})
+	return candidates[0].language
+}
+
+func WithLanguage(ctx context.Context, language Language) context.Context {
+	return context.WithValue(ctx, languageKey{}, language)
+}
+
+// FromContext возвращает язык запроса или язык по умолчанию
+func FromContext(ctx context.Context) Language {
+	if language, ok := ctx.Value(languageKey{}).(Language); ok {
+		return language
+	}
+	return defaultLanguage
+}
+
+// Message возвращает текст ошибки по коду, {fields} в тексте заменяется списком полей, {permission} разрешением.
+// Если перевода нет, используется язык по умолчанию, а затем сам код
+func Message(language Language, code string, fields []string, permission string) string {
+	message, found := catalogs[language][code]
+	if !found {
+		message, found = catalogs[defaultLanguage][code]
+	}
+	if !found {
+		return code
+	}
+	return strings.NewReplacer("{fields}", strings.Join(fields, ", "), "{permission}", permission).Replace(message)
+}
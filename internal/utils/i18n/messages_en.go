@@ -0,0 +1,65 @@
+package i18n
+
+import "tenders/internal/utils/consts"
+
+// messagesEn тексты ошибок на английском
+var messagesEn = map[string]string{
+	consts.ErrCodeInsufficientPermissions: "You do not have permission to perform this request",
+	consts.ErrCodePermissionDenied:        "Your role in the organization does not grant the {permission} permission",
+	consts.ErrCodeIncorrectRequestBody:    "Invalid request body",
+	consts.ErrCodeIncorrectParams:         "The request contains unexpected parameters",
+	consts.ErrCodeIncorrectLimitOffset:    "Invalid limit and/or offset",
+	consts.ErrCodeIncorrectTenderId:       "Tender id must be a uuid",
+	consts.ErrCodeIncorrectBidId:          "Bid id must be a uuid",
+	consts.ErrCodeIncorrectStatus:         "Status is missing or invalid",
+	consts.ErrCodeIncorrectServiceType:    "Invalid service_type",
+	consts.ErrCodeIncorrectVersion:        "Version must be a number",
+	consts.ErrCodeIncorrectVersionRange:   "Parameters from and to are required and must be version numbers",
+	consts.ErrCodeIncorrectDiffFormat:     "Invalid format, allowed values: changes, json-patch",
+	consts.ErrCodeIncorrectView:           "Invalid view, allowed values: full, compact",
+	consts.ErrCodeIncorrectIfMatch:        "If-Match header must contain a version as an ETag, e.g. \"3\"",
+	consts.ErrCodeIncorrectDecision:       "Invalid decision, allowed values: Approved, Rejected",
+	consts.ErrCodeIncorrectFeedback:       "Parameter bidFeedback is required",
+	consts.ErrCodeIncorrectFilter:         "Invalid filter: {fields}",
+	consts.ErrCodeIncorrectWebhookId:      "Webhook id must be a uuid",
+	consts.ErrCodeIncorrectLastEventId:    "Last-Event-ID must be a non-negative event id",
+	consts.ErrCodeIncorrectCursor:         "Parameter cursor must be the next_cursor of the previous page and cannot be combined with offset",
+	consts.ErrCodeIncorrectEmployeeId:     "Employee id must be a uuid",
+	consts.ErrCodeIncorrectOrganizationId: "Organization id must be a uuid",
+	consts.ErrCodeNoAuthorUsername:        "Parameter authorUsername is required",
+	consts.ErrCodeAuthorNotFound:          "User or organization does not exist",
+	consts.ErrCodeTenderNotFound:          "Tender does not exist",
+	consts.ErrCodeTenderVersionNotFound:   "Tender or its version does not exist",
+	consts.ErrCodeBidNotFound:             "Bid does not exist",
+	consts.ErrCodeBidForTenderNotFound:    "The author has no bids for this tender",
+	consts.ErrCodeReviewsNotFound:         "No reviews found",
+	consts.ErrCodeWebhookNotFound:         "Webhook subscription does not exist",
+	consts.ErrCodeEmployeeNotFound:        "Employee does not exist",
+	consts.ErrCodeOrganizationNotFound:    "Organization does not exist",
+	consts.ErrCodeResponsibleNotFound:     "The employee is not responsible for the organization",
+	consts.ErrCodeUserNotFound:            "User does not exist or is not valid for this request",
+	consts.ErrCodeVersionNotFound:         "Version does not exist",
+	consts.ErrCodeBidAlreadyDecided:       "A final decision has already been made on this bid",
+	consts.ErrCodeSubmissionClosed:        "The tender's bid submission deadline has passed",
+	consts.ErrCodeDecisionClosed:          "The tender's decision deadline has passed",
+	consts.ErrCodeBudgetExceeded:          "The bid amount exceeds the tender budget",
+	consts.ErrCodeCriteriaNotDefined:      "The tender has no evaluation criteria",
+	consts.ErrCodeCriteriaLocked:          "Criteria cannot be changed after bids have been scored or the tender is closed",
+	consts.ErrCodeBidNotPublished:         "Only published bids can be scored",
+	consts.ErrCodeTenderNotPublished:      "Bids are accepted only for published tenders",
+	consts.ErrCodeVersionConflict:         "The version is outdated, fetch the current version and retry",
+	consts.ErrCodeStatusTransition:        "This status change is not allowed from the current status",
+	consts.ErrCodeUsernameTaken:           "The username is already taken",
+	consts.ErrCodeLastOwner:               "The last owner of an organization cannot be removed or demoted",
+	consts.ErrCodeEmployeeInactive:        "The employee is deactivated",
+	consts.ErrCodeOrganizationInactive:    "The organization is deactivated",
+	consts.ErrCodeOrganizationRequired:    "You are responsible for several organizations, choose one with the X-Organization-Id header or the organizationId parameter",
+	consts.ErrCodeOrganizationMismatch:    "authorId does not match the organization chosen for the request",
+	consts.ErrCodeInvalidCredentials:      "Invalid username or password",
+	consts.ErrCodeInvalidToken:            "Token is invalid or expired",
+	consts.ErrCodeRequestTimeout:          "Timed out waiting for the database",
+	consts.ErrCodeRequestCanceled:         "The request was canceled",
+	consts.ErrCodeInternalError:           "Unknown server error",
+	consts.ErrCodeFailedToWriteResponse:   "Failed to write the response",
+	consts.ErrCodeValidationFailed:        "Invalid fields: {fields}",
+}
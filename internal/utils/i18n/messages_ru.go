@@ -0,0 +1,65 @@
+package i18n
+
+import "tenders/internal/utils/consts"
+
+// messagesRu тексты ошибок на русском, {fields} заменяется списком полей, {permission} недостающим разрешением
+var messagesRu = map[string]string{
+	consts.ErrCodeInsufficientPermissions: "У вас недостаточно прав для выполнения данного запроса",
+	consts.ErrCodePermissionDenied:        "Ваша роль в организации не дает разрешения {permission}",
+	consts.ErrCodeIncorrectRequestBody:    "Некорректное тело запроса",
+	consts.ErrCodeIncorrectParams:         "Указаны лишние параметры в запросе",
+	consts.ErrCodeIncorrectLimitOffset:    "Некорректно задан limit или/и offset",
+	consts.ErrCodeIncorrectTenderId:       "Id тендера должно быть в формате uuid",
+	consts.ErrCodeIncorrectBidId:          "Id предложения должно быть в формате uuid",
+	consts.ErrCodeIncorrectStatus:         "Статус не указан либо указан некорректный",
+	consts.ErrCodeIncorrectServiceType:    "Указан некорректный service_type",
+	consts.ErrCodeIncorrectVersion:        "Версия указана не числом",
+	consts.ErrCodeIncorrectVersionRange:   "Параметры from и to обязательны и должны быть номерами версий",
+	consts.ErrCodeIncorrectDiffFormat:     "Некорректно задан format, варианты: changes, json-patch",
+	consts.ErrCodeIncorrectView:           "Некорректно задан view, варианты: full, compact",
+	consts.ErrCodeIncorrectIfMatch:        "Заголовок If-Match должен содержать версию в формате ETag, например \"3\"",
+	consts.ErrCodeIncorrectDecision:       "Некорректно задано решение, варианты: Approved, Rejected",
+	consts.ErrCodeIncorrectFeedback:       "Параметр bidFeedback обязателен",
+	consts.ErrCodeIncorrectFilter:         "Некорректно задан фильтр: {fields}",
+	consts.ErrCodeIncorrectWebhookId:      "Id вебхука должно быть в формате uuid",
+	consts.ErrCodeIncorrectLastEventId:    "Last-Event-ID должен быть неотрицательным id события",
+	consts.ErrCodeIncorrectCursor:         "Параметр cursor должен быть значением next_cursor предыдущей страницы и не сочетается с offset",
+	consts.ErrCodeIncorrectEmployeeId:     "Id сотрудника должно быть в формате uuid",
+	consts.ErrCodeIncorrectOrganizationId: "Id организации должно быть в формате uuid",
+	consts.ErrCodeNoAuthorUsername:        "Не задан параметр authorUsername",
+	consts.ErrCodeAuthorNotFound:          "Пользователь или организация не существует",
+	consts.ErrCodeTenderNotFound:          "Тендер не существует",
+	consts.ErrCodeTenderVersionNotFound:   "Тендер или его версия не существует",
+	consts.ErrCodeBidNotFound:             "Предложение не существует",
+	consts.ErrCodeBidForTenderNotFound:    "У автора нет предложений созданных для указанного тендера",
+	consts.ErrCodeReviewsNotFound:         "Отзывы не найдены",
+	consts.ErrCodeWebhookNotFound:         "Подписка на вебхук не найдена",
+	consts.ErrCodeEmployeeNotFound:        "Сотрудник не существует",
+	consts.ErrCodeOrganizationNotFound:    "Организация не существует",
+	consts.ErrCodeResponsibleNotFound:     "Сотрудник не является ответственным за организацию",
+	consts.ErrCodeUserNotFound:            "Пользователь не существует или некорректен для данного запроса",
+	consts.ErrCodeVersionNotFound:         "Версия не существует",
+	consts.ErrCodeBidAlreadyDecided:       "По предложению уже принято окончательное решение",
+	consts.ErrCodeSubmissionClosed:        "Срок приема предложений по тендеру истек",
+	consts.ErrCodeDecisionClosed:          "Срок принятия решений по тендеру истек",
+	consts.ErrCodeBudgetExceeded:          "Цена предложения превышает бюджет тендера",
+	consts.ErrCodeCriteriaNotDefined:      "У тендера не заданы критерии оценки",
+	consts.ErrCodeCriteriaLocked:          "Критерии нельзя менять после оценки предложений или закрытия тендера",
+	consts.ErrCodeBidNotPublished:         "Оценивать можно только опубликованные предложения",
+	consts.ErrCodeTenderNotPublished:      "Предложения принимаются только по опубликованным тендерам",
+	consts.ErrCodeVersionConflict:         "Версия устарела, получите актуальную версию и повторите запрос",
+	consts.ErrCodeStatusTransition:        "Из текущего статуса нельзя перейти в указанный",
+	consts.ErrCodeUsernameTaken:           "Имя пользователя уже занято",
+	consts.ErrCodeLastOwner:               "Нельзя удалить или понизить последнего владельца организации",
+	consts.ErrCodeEmployeeInactive:        "Сотрудник деактивирован",
+	consts.ErrCodeOrganizationInactive:    "Организация деактивирована",
+	consts.ErrCodeOrganizationRequired:    "Вы отвечаете за несколько организаций, выберите одну заголовком X-Organization-Id или параметром organizationId",
+	consts.ErrCodeOrganizationMismatch:    "authorId не совпадает с организацией, выбранной для запроса",
+	consts.ErrCodeInvalidCredentials:      "Неверное имя пользователя или пароль",
+	consts.ErrCodeInvalidToken:            "Токен недействителен или истек",
+	consts.ErrCodeRequestTimeout:          "Превышено время ожидания ответа от базы данных",
+	consts.ErrCodeRequestCanceled:         "Запрос был отменен",
+	consts.ErrCodeInternalError:           "Неизвестная ошибка сервера",
+	consts.ErrCodeFailedToWriteResponse:   "Не удалось сформировать ответ на запрос",
+	consts.ErrCodeValidationFailed:        "Неправильно заполнены поля: {fields}",
+}
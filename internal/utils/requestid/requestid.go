@@ -0,0 +1,15 @@
+package requestid
+
+import "context"
+
+type requestIdKey struct{}
+
+func WithRequestId(ctx context.Context, requestId string) context.Context {
+	return context.WithValue(ctx, requestIdKey{}, requestId)
+}
+
+// FromContext возвращает id запроса или пустую строку, если его нет (например, в фоновых задачах)
+func FromContext(ctx context.Context) string {
+	requestId, _ := ctx.Value(requestIdKey{}).(string)
+	return requestId
+}